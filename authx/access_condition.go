@@ -0,0 +1,193 @@
+package authx
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/boostgo/core/convert"
+)
+
+// ConditionOperator compares condition attribute with value
+type ConditionOperator string
+
+const (
+	ConditionEq          ConditionOperator = "eq"
+	ConditionNotEq       ConditionOperator = "ne"
+	ConditionIn          ConditionOperator = "in"
+	ConditionNotIn       ConditionOperator = "not_in"
+	ConditionContains    ConditionOperator = "contains"
+	ConditionGreater     ConditionOperator = "gt"
+	ConditionGreaterOrEq ConditionOperator = "gte"
+	ConditionLess        ConditionOperator = "lt"
+	ConditionLessOrEq    ConditionOperator = "lte"
+	ConditionExists      ConditionOperator = "exists"
+)
+
+// Condition is ABAC condition of AccessRule.
+//
+// Attribute is path to subject or resource attribute: "subject.id", "subject.roles", "subject.department",
+// "resource.id", "resource.owner_id", "resource.status". Nested attributes are separated by dots.
+//
+// Attribute is compared with Value or with another attribute by ValueFrom path
+type Condition struct {
+	Attribute string            `json:"attribute" yaml:"attribute"`
+	Operator  ConditionOperator `json:"operator" yaml:"operator"`
+	Value     any               `json:"value" yaml:"value"`
+	ValueFrom string            `json:"value_from" yaml:"valueFrom"`
+}
+
+func (condition Condition) validate() error {
+	switch condition.Operator {
+	case ConditionEq, ConditionNotEq, ConditionIn, ConditionNotIn, ConditionContains,
+		ConditionGreater, ConditionGreaterOrEq, ConditionLess, ConditionLessOrEq, ConditionExists:
+		return nil
+	default:
+		return ErrInvalidAccessPolicy.
+			AddParam("reason", "unknown_operator").
+			AddParam("operator", condition.Operator)
+	}
+}
+
+func (condition Condition) evaluate(subject Subject, resource Resource) bool {
+	actual, exist := lookupAttribute(subject, resource, condition.Attribute)
+	if condition.Operator == ConditionExists {
+		// "exists" without value checks that attribute exists
+		return exist == (condition.Value == nil || convert.Bool(condition.Value))
+	}
+
+	if !exist {
+		return false
+	}
+
+	expected := condition.Value
+	if condition.ValueFrom != "" {
+		var ok bool
+		if expected, ok = lookupAttribute(subject, resource, condition.ValueFrom); !ok {
+			return false
+		}
+	}
+
+	switch condition.Operator {
+	case ConditionEq:
+		return equalValues(actual, expected)
+	case ConditionNotEq:
+		return !equalValues(actual, expected)
+	case ConditionIn:
+		return inList(expected, actual)
+	case ConditionNotIn:
+		return !inList(expected, actual)
+	case ConditionContains:
+		return containsValue(actual, expected)
+	case ConditionGreater:
+		return convert.Float64(actual) > convert.Float64(expected)
+	case ConditionGreaterOrEq:
+		return convert.Float64(actual) >= convert.Float64(expected)
+	case ConditionLess:
+		return convert.Float64(actual) < convert.Float64(expected)
+	case ConditionLessOrEq:
+		return convert.Float64(actual) <= convert.Float64(expected)
+	default:
+		return false
+	}
+}
+
+func lookupAttribute(subject Subject, resource Resource, path string) (any, bool) {
+	root, path, _ := strings.Cut(path, ".")
+
+	switch root {
+	case "subject":
+		switch path {
+		case "id":
+			return subject.ID, subject.ID != ""
+		case "roles":
+			return subject.Roles, true
+		case "permissions":
+			return subject.Permissions, true
+		default:
+			return lookupPath(subject.Attributes, path)
+		}
+	case "resource":
+		switch path {
+		case "id":
+			return resource.ID, resource.ID != ""
+		case "type":
+			return resource.Type, true
+		case "owner_id":
+			return resource.OwnerID, resource.OwnerID != ""
+		default:
+			return lookupPath(resource.Attributes, path)
+		}
+	default:
+		return nil, false
+	}
+}
+
+func lookupPath(attributes map[string]any, path string) (any, bool) {
+	var current any = attributes
+	for _, key := range strings.Split(path, ".") {
+		values, ok := current.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+
+		if current, ok = values[key]; !ok {
+			return nil, false
+		}
+	}
+
+	return current, true
+}
+
+// equalValues compares numbers as numbers and other values as strings
+func equalValues(left, right any) bool {
+	if isNumber(left) && isNumber(right) {
+		return convert.Float64(left) == convert.Float64(right)
+	}
+
+	return convert.String(left) == convert.String(right)
+}
+
+// containsValue checks if attribute contains value: string attribute by substring, slice attribute by items
+func containsValue(attribute, value any) bool {
+	if text, ok := attribute.(string); ok {
+		return strings.Contains(text, convert.String(value))
+	}
+
+	return inList(attribute, value)
+}
+
+// inList checks if list contains value. List is slice or string of comma separated items,
+// every item is compared by equalValues, so "admin" is not in "finance-admin"
+func inList(list, value any) bool {
+	if text, ok := list.(string); ok {
+		for _, item := range strings.Split(text, ",") {
+			if equalValues(strings.TrimSpace(item), value) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	reflected := reflect.ValueOf(list)
+	if reflected.Kind() != reflect.Slice && reflected.Kind() != reflect.Array {
+		return false
+	}
+
+	for i := 0; i < reflected.Len(); i++ {
+		if equalValues(reflected.Index(i).Interface(), value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isNumber(value any) bool {
+	switch value.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,100 @@
+package authx
+
+import "testing"
+
+func TestConditionEvaluate(t *testing.T) {
+	subject := Subject{
+		ID:    "user",
+		Roles: []string{"manager", "finance-admin"},
+		Attributes: map[string]any{
+			"department": "finance",
+			"level":      float64(3),
+			"org":        map[string]any{"id": "acme"},
+		},
+	}
+
+	resource := Resource{
+		Type:    "orders",
+		ID:      "order",
+		OwnerID: "user",
+		Attributes: map[string]any{
+			"status":     "closed",
+			"department": "finance",
+			"amount":     100,
+			"tags":       []string{"urgent", "vip"},
+			"approvers":  "admin, finance-admin",
+			"title":      "quarterly report",
+		},
+	}
+
+	tests := []struct {
+		name      string
+		condition Condition
+		expected  bool
+	}{
+		{name: "eq", condition: Condition{Attribute: "resource.status", Operator: ConditionEq, Value: "closed"}, expected: true},
+		{name: "eq mismatch", condition: Condition{Attribute: "resource.status", Operator: ConditionEq, Value: "open"}},
+		{name: "eq numbers", condition: Condition{Attribute: "resource.amount", Operator: ConditionEq, Value: float64(100)}, expected: true},
+		{name: "eq value from", condition: Condition{Attribute: "resource.department", Operator: ConditionEq, ValueFrom: "subject.department"}, expected: true},
+		{name: "eq value from missing", condition: Condition{Attribute: "resource.department", Operator: ConditionEq, ValueFrom: "subject.unknown"}},
+		{name: "eq nested", condition: Condition{Attribute: "subject.org.id", Operator: ConditionEq, Value: "acme"}, expected: true},
+		{name: "eq owner", condition: Condition{Attribute: "resource.owner_id", Operator: ConditionEq, ValueFrom: "subject.id"}, expected: true},
+		{name: "ne", condition: Condition{Attribute: "resource.status", Operator: ConditionNotEq, Value: "open"}, expected: true},
+		{name: "ne mismatch", condition: Condition{Attribute: "resource.status", Operator: ConditionNotEq, Value: "closed"}},
+		{name: "missing attribute", condition: Condition{Attribute: "resource.unknown", Operator: ConditionNotEq, Value: "closed"}},
+		{name: "in slice", condition: Condition{Attribute: "resource.status", Operator: ConditionIn, Value: []any{"open", "closed"}}, expected: true},
+		{name: "in slice mismatch", condition: Condition{Attribute: "resource.status", Operator: ConditionIn, Value: []any{"open", "new"}}},
+		{name: "in comma separated", condition: Condition{Attribute: "resource.status", Operator: ConditionIn, Value: "open, closed"}, expected: true},
+		{name: "in is not substring", condition: Condition{Attribute: "resource.status", Operator: ConditionIn, Value: "closed-archived"}},
+		{name: "in attribute list", condition: Condition{Attribute: "subject.id", Operator: ConditionIn, ValueFrom: "resource.approvers"}},
+		{name: "in attribute list item", condition: Condition{Attribute: "subject.department", Operator: ConditionIn, Value: []string{"finance"}}, expected: true},
+		{name: "in numbers", condition: Condition{Attribute: "resource.amount", Operator: ConditionIn, Value: []any{float64(50), float64(100)}}, expected: true},
+		{name: "not in", condition: Condition{Attribute: "resource.status", Operator: ConditionNotIn, Value: []any{"open", "new"}}, expected: true},
+		{name: "not in mismatch", condition: Condition{Attribute: "resource.status", Operator: ConditionNotIn, Value: "open,closed"}},
+		{name: "not in substring", condition: Condition{Attribute: "resource.status", Operator: ConditionNotIn, Value: "closed-archived"}, expected: true},
+		{name: "contains slice", condition: Condition{Attribute: "resource.tags", Operator: ConditionContains, Value: "vip"}, expected: true},
+		{name: "contains slice is not substring", condition: Condition{Attribute: "resource.tags", Operator: ConditionContains, Value: "ur"}},
+		{name: "contains roles", condition: Condition{Attribute: "subject.roles", Operator: ConditionContains, Value: "manager"}, expected: true},
+		{name: "contains string", condition: Condition{Attribute: "resource.title", Operator: ConditionContains, Value: "report"}, expected: true},
+		{name: "contains string mismatch", condition: Condition{Attribute: "resource.title", Operator: ConditionContains, Value: "invoice"}},
+		{name: "gt", condition: Condition{Attribute: "resource.amount", Operator: ConditionGreater, Value: 99}, expected: true},
+		{name: "gt equal", condition: Condition{Attribute: "resource.amount", Operator: ConditionGreater, Value: 100}},
+		{name: "gte", condition: Condition{Attribute: "resource.amount", Operator: ConditionGreaterOrEq, Value: 100}, expected: true},
+		{name: "lt", condition: Condition{Attribute: "subject.level", Operator: ConditionLess, Value: 5}, expected: true},
+		{name: "lt equal", condition: Condition{Attribute: "subject.level", Operator: ConditionLess, Value: 3}},
+		{name: "lte", condition: Condition{Attribute: "subject.level", Operator: ConditionLessOrEq, Value: 3}, expected: true},
+		{name: "exists", condition: Condition{Attribute: "resource.status", Operator: ConditionExists}, expected: true},
+		{name: "exists missing", condition: Condition{Attribute: "resource.deleted_at", Operator: ConditionExists}},
+		{name: "not exists", condition: Condition{Attribute: "resource.deleted_at", Operator: ConditionExists, Value: false}, expected: true},
+		{name: "unknown root", condition: Condition{Attribute: "request.ip", Operator: ConditionEq, Value: "127.0.0.1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := tt.condition.evaluate(subject, resource); result != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestConditionInEmptyValue(t *testing.T) {
+	// empty attribute must not be found in list as substring of any item
+	condition := Condition{Attribute: "subject.role", Operator: ConditionIn, Value: "finance-admin"}
+	for _, role := range []string{"", "admin", "finance"} {
+		subject := Subject{Attributes: map[string]any{"role": role}}
+		if condition.evaluate(subject, Resource{}) {
+			t.Errorf("expected role %q not to be in list", role)
+		}
+	}
+}
+
+func TestConditionValidate(t *testing.T) {
+	if err := (Condition{Attribute: "resource.status", Operator: ConditionEq}).validate(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if err := (Condition{Attribute: "resource.status", Operator: "like"}).validate(); err == nil {
+		t.Error("expected unknown operator error")
+	}
+}
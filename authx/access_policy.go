@@ -0,0 +1,156 @@
+package authx
+
+import (
+	"slices"
+
+	"github.com/boostgo/core/configx"
+)
+
+// AccessEffect is result of matched AccessRule
+type AccessEffect string
+
+const (
+	AccessAllow AccessEffect = "allow"
+	AccessDeny  AccessEffect = "deny"
+)
+
+// Role is named permissions set. Role gets all permissions of inherited roles
+type Role struct {
+	Inherits    []string     `json:"inherits" yaml:"inherits"`
+	Permissions []Permission `json:"permissions" yaml:"permissions"`
+}
+
+// AccessRule is attribute-based rule which allows or denies action if all conditions are met.
+//
+// Empty Resources, Actions or Roles means any. Deny rules override any grant
+type AccessRule struct {
+	Name       string       `json:"name" yaml:"name"`
+	Effect     AccessEffect `json:"effect" yaml:"effect"`
+	Resources  []string     `json:"resources" yaml:"resources"`
+	Actions    []string     `json:"actions" yaml:"actions"`
+	Roles      []string     `json:"roles" yaml:"roles"`
+	Conditions []Condition  `json:"conditions" yaml:"conditions"`
+}
+
+// AccessPolicy describes roles & rules of Authorizer. Could be loaded from YAML:
+//
+//	roles:
+//	  viewer:
+//	    permissions: ["orders:read:own"]
+//	  manager:
+//	    inherits: ["viewer"]
+//	    permissions: ["orders:read", "orders:write"]
+//	  admin:
+//	    permissions: ["*"]
+//	rules:
+//	  - name: closed_orders_readonly
+//	    effect: deny
+//	    resources: ["orders"]
+//	    actions: ["write"]
+//	    conditions:
+//	      - attribute: resource.status
+//	        operator: eq
+//	        value: closed
+type AccessPolicy struct {
+	Roles map[string]Role `json:"roles" yaml:"roles"`
+	Rules []AccessRule    `json:"rules" yaml:"rules"`
+}
+
+// LoadAccessPolicy reads access policy from YAML or JSON files by configx
+func LoadAccessPolicy(path ...string) (AccessPolicy, error) {
+	var policy AccessPolicy
+	if err := configx.Read(&policy, path...); err != nil {
+		return policy, ErrLoadAccessPolicy.SetError(err)
+	}
+
+	return policy, nil
+}
+
+// resolveRoles returns permissions of every role including inherited roles permissions.
+//
+// Returns error if role inherits unknown role or inheritance has cycle
+func (policy AccessPolicy) resolveRoles() (map[string][]Permission, error) {
+	resolved := make(map[string][]Permission, len(policy.Roles))
+
+	var resolve func(name string, path []string) ([]Permission, error)
+	resolve = func(name string, path []string) ([]Permission, error) {
+		if permissions, ok := resolved[name]; ok {
+			return permissions, nil
+		}
+
+		if slices.Contains(path, name) {
+			return nil, ErrInvalidAccessPolicy.
+				AddParam("reason", "inheritance_cycle").
+				AddParam("roles", append(path, name))
+		}
+
+		role, ok := policy.Roles[name]
+		if !ok {
+			return nil, ErrInvalidAccessPolicy.
+				AddParam("reason", "unknown_role").
+				AddParam("role", name)
+		}
+
+		permissions := slices.Clone(role.Permissions)
+		for _, inherited := range role.Inherits {
+			inheritedPermissions, err := resolve(inherited, append(path, name))
+			if err != nil {
+				return nil, err
+			}
+
+			permissions = append(permissions, inheritedPermissions...)
+		}
+
+		resolved[name] = permissions
+		return permissions, nil
+	}
+
+	for name := range policy.Roles {
+		if _, err := resolve(name, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	return resolved, nil
+}
+
+func (rule AccessRule) validate() error {
+	if rule.Effect != AccessAllow && rule.Effect != AccessDeny {
+		return ErrInvalidAccessPolicy.
+			AddParam("reason", "unknown_effect").
+			AddParam("rule", rule.Name).
+			AddParam("effect", rule.Effect)
+	}
+
+	for _, condition := range rule.Conditions {
+		if err := condition.validate(); err != nil {
+			return ErrInvalidAccessPolicy.
+				SetError(err).
+				AddParam("rule", rule.Name)
+		}
+	}
+
+	return nil
+}
+
+func (rule AccessRule) matches(subject Subject, resource, action string) bool {
+	if len(rule.Resources) > 0 && !slices.ContainsFunc(rule.Resources, func(pattern string) bool {
+		return matchPermissionPart(pattern, resource)
+	}) {
+		return false
+	}
+
+	if len(rule.Actions) > 0 && !slices.ContainsFunc(rule.Actions, func(pattern string) bool {
+		return matchPermissionPart(pattern, action)
+	}) {
+		return false
+	}
+
+	if len(rule.Roles) > 0 && !slices.ContainsFunc(subject.Roles, func(role string) bool {
+		return slices.Contains(rule.Roles, role)
+	}) {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,208 @@
+package authx
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/boostgo/core/errorx"
+)
+
+const (
+	apiKeySeparator   = "."
+	apiKeyIDBytes     = 8
+	apiKeySecretBytes = 32
+)
+
+// APIKey is stored API key of machine-to-machine caller.
+//
+// Key secret is not stored, only its hash. Plain key has "<ID>.<secret>" format
+type APIKey struct {
+	ID        string    `json:"id" db:"id"`
+	Hash      string    `json:"hash" db:"hash"`
+	Name      string    `json:"name" db:"name"`
+	Subject   string    `json:"subject" db:"subject"`
+	Scopes    []string  `json:"scopes" db:"-"`
+	ExpiresAt time.Time `json:"expires_at" db:"-"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	Revoked   bool      `json:"revoked" db:"revoked"`
+}
+
+// Expired checks if key has expiration time and it passed
+func (key APIKey) Expired() bool {
+	return !key.ExpiresAt.IsZero() && time.Now().After(key.ExpiresAt)
+}
+
+// HasScopes checks if key has all provided scopes. Scope "*" allows everything
+func (key APIKey) HasScopes(scopes ...string) bool {
+	if slices.Contains(key.Scopes, permissionWildcard) {
+		return true
+	}
+
+	for _, scope := range scopes {
+		if !slices.Contains(key.Scopes, scope) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// GetPermissions returns key scopes as permissions, so key could be checked by HasPermissions policy
+func (key APIKey) GetPermissions() []Permission {
+	permissions := make([]Permission, 0, len(key.Scopes))
+	for _, scope := range key.Scopes {
+		permissions = append(permissions, Permission(scope))
+	}
+
+	return permissions
+}
+
+// APIKeyStore keeps API keys
+type APIKeyStore interface {
+	// Save saves new key
+	Save(ctx context.Context, key APIKey) error
+	// Get returns key by ID. Returns error based on errorx.ErrNotFound if there is no key
+	Get(ctx context.Context, id string) (APIKey, error)
+	// Revoke marks key as revoked
+	Revoke(ctx context.Context, id string) error
+}
+
+// APIKeyOption modifies created API key
+type APIKeyOption func(key *APIKey)
+
+// WithAPIKeyName sets key name (description)
+func WithAPIKeyName(name string) APIKeyOption {
+	return func(key *APIKey) {
+		key.Name = name
+	}
+}
+
+// WithAPIKeyScopes sets key scopes
+func WithAPIKeyScopes(scopes ...string) APIKeyOption {
+	return func(key *APIKey) {
+		key.Scopes = append(key.Scopes, scopes...)
+	}
+}
+
+// WithAPIKeyTTL sets key lifetime. By default, key has no expiration
+func WithAPIKeyTTL(ttl time.Duration) APIKeyOption {
+	return func(key *APIKey) {
+		if ttl > 0 {
+			key.ExpiresAt = key.CreatedAt.Add(ttl)
+		}
+	}
+}
+
+// APIKeys creates & verifies API keys
+type APIKeys struct {
+	store  APIKeyStore
+	prefix string
+}
+
+// NewAPIKeys creates API keys manager. Prefix is added to keys ID, for example "live_"
+func NewAPIKeys(store APIKeyStore, prefix ...string) *APIKeys {
+	keys := &APIKeys{
+		store: store,
+	}
+
+	if len(prefix) > 0 {
+		keys.prefix = prefix[0]
+	}
+
+	return keys
+}
+
+// Create creates new key for subject and returns plain key which must be shown to caller only once
+func (keys *APIKeys) Create(ctx context.Context, subject string, opts ...APIKeyOption) (string, APIKey, error) {
+	id, err := randomHex(apiKeyIDBytes)
+	if err != nil {
+		return "", APIKey{}, err
+	}
+
+	secret, err := randomHex(apiKeySecretBytes)
+	if err != nil {
+		return "", APIKey{}, err
+	}
+
+	key := APIKey{
+		ID:        keys.prefix + id,
+		Hash:      HashAPIKeySecret(secret),
+		Subject:   subject,
+		CreatedAt: time.Now(),
+	}
+
+	for _, opt := range opts {
+		opt(&key)
+	}
+
+	if err = keys.store.Save(ctx, key); err != nil {
+		return "", APIKey{}, err
+	}
+
+	return key.ID + apiKeySeparator + secret, key, nil
+}
+
+// Verify checks plain key and its scopes.
+//
+// Returns ErrInvalidAPIKey if key is unknown, revoked, expired or secret does not match.
+// Returns ErrAPIKeyScope if key has not all required scopes
+func (keys *APIKeys) Verify(ctx context.Context, plainKey string, scopes ...string) (APIKey, error) {
+	id, secret, ok := strings.Cut(plainKey, apiKeySeparator)
+	if !ok || id == "" || secret == "" {
+		return APIKey{}, ErrInvalidAPIKey
+	}
+
+	key, err := keys.store.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, errorx.ErrNotFound) {
+			return APIKey{}, ErrInvalidAPIKey
+		}
+
+		return APIKey{}, err
+	}
+
+	if subtle.ConstantTimeCompare([]byte(key.Hash), []byte(HashAPIKeySecret(secret))) != 1 {
+		return APIKey{}, ErrInvalidAPIKey
+	}
+
+	if key.Revoked {
+		return APIKey{}, ErrInvalidAPIKey.AddParam("reason", "revoked")
+	}
+
+	if key.Expired() {
+		return APIKey{}, ErrInvalidAPIKey.AddParam("reason", "expired")
+	}
+
+	if !key.HasScopes(scopes...) {
+		return APIKey{}, ErrAPIKeyScope.AddParam("scopes", scopes)
+	}
+
+	return key, nil
+}
+
+// Revoke revokes key by its ID
+func (keys *APIKeys) Revoke(ctx context.Context, id string) error {
+	return keys.store.Revoke(ctx, id)
+}
+
+// HashAPIKeySecret returns hash of API key secret which is stored
+func HashAPIKeySecret(secret string) string {
+	hash := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(hash[:])
+}
+
+func randomHex(size int) (string, error) {
+	blob := make([]byte, size)
+	if _, err := rand.Read(blob); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(blob), nil
+}
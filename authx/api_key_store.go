@@ -0,0 +1,160 @@
+package authx
+
+import (
+	"context"
+	sqldriver "database/sql"
+	"encoding/json"
+	"slices"
+	"sync"
+
+	"github.com/boostgo/core/sql"
+)
+
+const defaultAPIKeysTable = "api_keys"
+
+// APIKeysSchema is PostgreSQL schema of API keys table used by NewSQLAPIKeyStore with default table name
+const APIKeysSchema = `
+CREATE TABLE IF NOT EXISTS api_keys (
+	id         TEXT PRIMARY KEY,
+	hash       TEXT NOT NULL,
+	name       TEXT NOT NULL DEFAULT '',
+	subject    TEXT NOT NULL,
+	scopes     TEXT NOT NULL DEFAULT '[]',
+	expires_at TIMESTAMPTZ,
+	created_at TIMESTAMPTZ NOT NULL,
+	revoked    BOOLEAN NOT NULL DEFAULT FALSE
+);
+`
+
+type memoryAPIKeyStore struct {
+	keys map[string]APIKey
+	mx   sync.RWMutex
+}
+
+// NewMemoryAPIKeyStore creates in-memory API keys store with provided keys. Could be used for keys from config
+func NewMemoryAPIKeyStore(keys ...APIKey) APIKeyStore {
+	store := &memoryAPIKeyStore{
+		keys: make(map[string]APIKey, len(keys)),
+	}
+
+	for _, key := range keys {
+		store.keys[key.ID] = key
+	}
+
+	return store
+}
+
+func (store *memoryAPIKeyStore) Save(_ context.Context, key APIKey) error {
+	store.mx.Lock()
+	defer store.mx.Unlock()
+
+	key.Scopes = slices.Clone(key.Scopes)
+	store.keys[key.ID] = key
+	return nil
+}
+
+func (store *memoryAPIKeyStore) Get(_ context.Context, id string) (APIKey, error) {
+	store.mx.RLock()
+	defer store.mx.RUnlock()
+
+	key, ok := store.keys[id]
+	if !ok {
+		return APIKey{}, ErrAPIKeyNotFound
+	}
+
+	return key, nil
+}
+
+func (store *memoryAPIKeyStore) Revoke(_ context.Context, id string) error {
+	store.mx.Lock()
+	defer store.mx.Unlock()
+
+	key, ok := store.keys[id]
+	if !ok {
+		return ErrAPIKeyNotFound
+	}
+
+	key.Revoked = true
+	store.keys[id] = key
+	return nil
+}
+
+type sqlAPIKey struct {
+	APIKey
+	ScopesJSON string             `db:"scopes"`
+	ExpiresAt  sqldriver.NullTime `db:"expires_at"`
+}
+
+type sqlAPIKeyStore struct {
+	db    sql.DB
+	table string
+}
+
+// NewSQLAPIKeyStore creates API keys store based on SQL table (see APIKeysSchema)
+func NewSQLAPIKeyStore(db sql.DB, table ...string) APIKeyStore {
+	store := &sqlAPIKeyStore{
+		db:    db,
+		table: defaultAPIKeysTable,
+	}
+
+	if len(table) > 0 && table[0] != "" {
+		store.table = table[0]
+	}
+
+	return store
+}
+
+func (store *sqlAPIKeyStore) Save(ctx context.Context, key APIKey) error {
+	scopes, err := json.Marshal(key.Scopes)
+	if err != nil {
+		return err
+	}
+
+	var expiresAt any
+	if !key.ExpiresAt.IsZero() {
+		expiresAt = key.ExpiresAt
+	}
+
+	args := sql.NewArguments()
+	query := `INSERT INTO ` + store.table + ` (id, hash, name, subject, scopes, expires_at, created_at, revoked) VALUES ` +
+		args.AddMany(key.ID, key.Hash, key.Name, key.Subject, string(scopes), expiresAt, key.CreatedAt, key.Revoked)
+
+	_, err = store.db.ExecContext(ctx, query, args.Args()...)
+	return err
+}
+
+func (store *sqlAPIKeyStore) Get(ctx context.Context, id string) (APIKey, error) {
+	var row sqlAPIKey
+
+	args := sql.NewArguments()
+	query := `SELECT id, hash, name, subject, scopes, expires_at, created_at, revoked FROM ` + store.table +
+		` WHERE id = ` + args.Add(id).Number()
+	if err := store.db.GetContext(ctx, &row, query, args.Args()...); err != nil {
+		if sql.NotFound(err) {
+			return APIKey{}, ErrAPIKeyNotFound
+		}
+
+		return APIKey{}, err
+	}
+
+	key := row.APIKey
+	if row.ExpiresAt.Valid {
+		key.ExpiresAt = row.ExpiresAt.Time
+	}
+
+	if row.ScopesJSON != "" {
+		if err := json.Unmarshal([]byte(row.ScopesJSON), &key.Scopes); err != nil {
+			return APIKey{}, err
+		}
+	}
+
+	return key, nil
+}
+
+func (store *sqlAPIKeyStore) Revoke(ctx context.Context, id string) error {
+	args := sql.NewArguments()
+	query := `UPDATE ` + store.table + ` SET revoked = TRUE WHERE id = ` + args.Add(id).Number()
+
+	_, err := store.db.ExecContext(ctx, query, args.Args()...)
+	return err
+}
@@ -0,0 +1,229 @@
+package authx
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/boostgo/core/sql"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+)
+
+func TestAPIKeysVerify(t *testing.T) {
+	ctx := context.Background()
+	keys := NewAPIKeys(NewMemoryAPIKeyStore(), "live_")
+
+	plain, created, err := keys.Create(ctx, "service", WithAPIKeyName("billing"), WithAPIKeyScopes("orders:read", "orders:write"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.HasPrefix(created.ID, "live_") || created.Name != "billing" {
+		t.Fatalf("unexpected key: %+v", created)
+	}
+
+	if strings.Contains(created.Hash, plain[len(created.ID)+1:]) {
+		t.Fatal("expected secret not to be stored")
+	}
+
+	expiredPlain, _, err := keys.Create(ctx, "service", WithAPIKeyTTL(time.Millisecond))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	revokedPlain, revoked, err := keys.Create(ctx, "service")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err = keys.Revoke(ctx, revoked.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	wildcardPlain, _, err := keys.Create(ctx, "admin", WithAPIKeyScopes("*"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	time.Sleep(time.Millisecond * 5)
+
+	tests := []struct {
+		name   string
+		key    string
+		scopes []string
+		err    error
+		reason string
+	}{
+		{name: "valid", key: plain},
+		{name: "valid with scopes", key: plain, scopes: []string{"orders:read", "orders:write"}},
+		{name: "missing scope", key: plain, scopes: []string{"orders:delete"}, err: ErrAPIKeyScope},
+		{name: "wildcard scope", key: wildcardPlain, scopes: []string{"orders:delete"}},
+		{name: "wrong secret", key: created.ID + apiKeySeparator + "wrong", err: ErrInvalidAPIKey},
+		{name: "unknown key", key: "live_unknown" + apiKeySeparator + "secret", err: ErrInvalidAPIKey},
+		{name: "no separator", key: created.ID, err: ErrInvalidAPIKey},
+		{name: "empty secret", key: created.ID + apiKeySeparator, err: ErrInvalidAPIKey},
+		{name: "revoked", key: revokedPlain, err: ErrInvalidAPIKey, reason: "revoked"},
+		{name: "expired", key: expiredPlain, err: ErrInvalidAPIKey, reason: "expired"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := keys.Verify(ctx, tt.key, tt.scopes...)
+			if tt.err == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+
+				if key.ID == "" {
+					t.Error("expected verified key")
+				}
+				return
+			}
+
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("expected %v, got %v", tt.err, err)
+			}
+
+			if reason := errorReason(err); reason != tt.reason {
+				t.Errorf("expected reason %q, got %q", tt.reason, reason)
+			}
+		})
+	}
+}
+
+func TestMemoryAPIKeyStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryAPIKeyStore(APIKey{ID: "config", Hash: HashAPIKeySecret("secret"), Scopes: []string{"read"}})
+
+	key, err := store.Get(ctx, "config")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !key.HasScopes("read") {
+		t.Errorf("unexpected key: %+v", key)
+	}
+
+	// saved scopes are copied
+	scopes := []string{"write"}
+	if err = store.Save(ctx, APIKey{ID: "saved", Scopes: scopes}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	scopes[0] = "admin"
+
+	if key, _ = store.Get(ctx, "saved"); key.Scopes[0] != "write" {
+		t.Errorf("expected scopes copy, got %v", key.Scopes)
+	}
+
+	if err = store.Revoke(ctx, "saved"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if key, _ = store.Get(ctx, "saved"); !key.Revoked {
+		t.Error("expected revoked key")
+	}
+
+	if _, err = store.Get(ctx, "unknown"); !errors.Is(err, ErrAPIKeyNotFound) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+
+	if err = store.Revoke(ctx, "unknown"); !errors.Is(err, ErrAPIKeyNotFound) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
+
+func newTestSQLDB(t *testing.T) (sql.DB, sqlmock.Sqlmock) {
+	t.Helper()
+
+	conn, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	return sql.Client(sqlx.NewDb(conn, "postgres")), mock
+}
+
+func TestSQLAPIKeyStore(t *testing.T) {
+	ctx := context.Background()
+	db, mock := newTestSQLDB(t)
+	store := NewSQLAPIKeyStore(db, "keys")
+
+	createdAt := time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)
+	expiresAt := createdAt.Add(time.Hour)
+	key := APIKey{
+		ID:        "live_1",
+		Hash:      "hash",
+		Name:      "billing",
+		Subject:   "service",
+		Scopes:    []string{"orders:read"},
+		ExpiresAt: expiresAt,
+		CreatedAt: createdAt,
+	}
+
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO keys (id, hash, name, subject, scopes, expires_at, created_at, revoked) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`)).
+		WithArgs("live_1", "hash", "billing", "service", `["orders:read"]`, expiresAt, createdAt, false).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	if err := store.Save(ctx, key); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	columns := []string{"id", "hash", "name", "subject", "scopes", "expires_at", "created_at", "revoked"}
+	selectQuery := regexp.QuoteMeta(`SELECT id, hash, name, subject, scopes, expires_at, created_at, revoked FROM keys WHERE id = $1`)
+
+	mock.ExpectQuery(selectQuery).
+		WithArgs("live_1").
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow("live_1", "hash", "billing", "service", `["orders:read"]`, expiresAt, createdAt, false))
+
+	got, err := store.Get(ctx, "live_1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got.ID != key.ID || got.Subject != key.Subject || !got.ExpiresAt.Equal(expiresAt) || !got.HasScopes("orders:read") {
+		t.Errorf("unexpected key: %+v", got)
+	}
+
+	// key without expiration
+	mock.ExpectQuery(selectQuery).
+		WithArgs("live_2").
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow("live_2", "hash", "", "service", `[]`, nil, createdAt, true))
+
+	if got, err = store.Get(ctx, "live_2"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !got.ExpiresAt.IsZero() || !got.Revoked {
+		t.Errorf("unexpected key: %+v", got)
+	}
+
+	mock.ExpectQuery(selectQuery).
+		WithArgs("unknown").
+		WillReturnRows(sqlmock.NewRows(columns))
+
+	if _, err = store.Get(ctx, "unknown"); !errors.Is(err, ErrAPIKeyNotFound) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE keys SET revoked = TRUE WHERE id = $1`)).
+		WithArgs("live_1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	if err = store.Revoke(ctx, "live_1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err = mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,229 @@
+package authx
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const (
+	// ScopeOwn is permission scope which allows access only to resources owned by subject ("orders:read:own")
+	ScopeOwn = "own"
+	// ScopeAll is permission scope which allows access to any resource, same as empty scope
+	ScopeAll = "all"
+)
+
+// Subject is who requests access
+type Subject struct {
+	ID          string
+	Roles       []string
+	Permissions []Permission
+	Attributes  map[string]any
+}
+
+// NewSubject creates subject from token claims.
+//
+// Roles are taken from GroupsClaims, permissions from PermissionsClaims,
+// attributes are all claims fields and ID is "sub" claim
+func NewSubject(claims any) Subject {
+	subject := Subject{
+		Attributes: claimsAttributes(claims),
+	}
+
+	if groupsClaims, ok := claims.(GroupsClaims); ok {
+		for _, group := range groupsClaims.GetGroups() {
+			subject.Roles = append(subject.Roles, group.String())
+		}
+	}
+
+	if permissionsClaims, ok := claims.(PermissionsClaims); ok {
+		subject.Permissions = permissionsClaims.GetPermissions()
+	}
+
+	subject.ID, _ = subject.Attributes["sub"].(string)
+	return subject
+}
+
+// Resource is what subject wants to access
+type Resource struct {
+	// Type is resource name in permissions, for example "orders"
+	Type       string
+	ID         string
+	OwnerID    string
+	Attributes map[string]any
+}
+
+// ScopeFunc checks if subject has access to resource by permission scope
+type ScopeFunc func(subject Subject, resource Resource) bool
+
+// AuthorizerOption modifies Authorizer settings
+type AuthorizerOption func(authorizer *Authorizer)
+
+// WithScope registers permission scope. Scopes ScopeOwn & ScopeAll are registered by default
+func WithScope(name string, scope ScopeFunc) AuthorizerOption {
+	return func(authorizer *Authorizer) {
+		authorizer.scopes[name] = scope
+	}
+}
+
+// Authorizer is role-based & attribute-based access control engine.
+//
+// Subject gets access if one of its permissions (own or from roles) grants action on resource
+// and permission scope is satisfied, or if one of allow rules matches. Matched deny rule rejects access anyway
+type Authorizer struct {
+	roles  map[string][]Permission
+	rules  []AccessRule
+	scopes map[string]ScopeFunc
+}
+
+// NewAuthorizer creates authorizer by policy. Returns error if policy is invalid (unknown roles, inheritance cycle, etc...)
+func NewAuthorizer(policy AccessPolicy, opts ...AuthorizerOption) (*Authorizer, error) {
+	roles, err := policy.resolveRoles()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rule := range policy.Rules {
+		if err = rule.validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	authorizer := &Authorizer{
+		roles: roles,
+		rules: policy.Rules,
+		scopes: map[string]ScopeFunc{
+			ScopeAll: func(Subject, Resource) bool {
+				return true
+			},
+			ScopeOwn: func(subject Subject, resource Resource) bool {
+				return subject.ID != "" && subject.ID == resource.OwnerID
+			},
+		},
+	}
+
+	for _, opt := range opts {
+		opt(authorizer)
+	}
+
+	return authorizer, nil
+}
+
+// MustAuthorizer calls NewAuthorizer and panics on error
+func MustAuthorizer(policy AccessPolicy, opts ...AuthorizerOption) *Authorizer {
+	authorizer, err := NewAuthorizer(policy, opts...)
+	if err != nil {
+		panic(err)
+	}
+
+	return authorizer
+}
+
+// Permissions returns all subject permissions including permissions of its roles
+func (a *Authorizer) Permissions(subject Subject) []Permission {
+	permissions := append([]Permission{}, subject.Permissions...)
+	for _, role := range subject.Roles {
+		permissions = append(permissions, a.roles[role]...)
+	}
+
+	return permissions
+}
+
+// Authorize checks if subject could do action with resource.
+//
+// Returns ErrResourceForbidden with "reason" param: "no_permission", "scope" or "denied_by_rule"
+func (a *Authorizer) Authorize(_ context.Context, subject Subject, action string, resource Resource) error {
+	granted := false
+	deniedScope := ""
+
+	for _, permission := range a.Permissions(subject) {
+		if !permission.Grants(resource.Type, action) {
+			continue
+		}
+
+		scope := permission.Scope()
+		if scope == "" {
+			granted = true
+			break
+		}
+
+		if check, ok := a.scopes[scope]; ok && check(subject, resource) {
+			granted = true
+			break
+		}
+
+		deniedScope = scope
+	}
+
+	for _, rule := range a.rules {
+		if !rule.matches(subject, resource.Type, action) || !rule.conditionsMet(subject, resource) {
+			continue
+		}
+
+		if rule.Effect == AccessDeny {
+			return newResourceForbiddenError(subject, action, resource).
+				AddParam("reason", "denied_by_rule").
+				AddParam("rule", rule.Name)
+		}
+
+		granted = true
+	}
+
+	if granted {
+		return nil
+	}
+
+	if deniedScope != "" {
+		return newResourceForbiddenError(subject, action, resource).
+			AddParam("reason", "scope").
+			AddParam("scope", deniedScope)
+	}
+
+	return newResourceForbiddenError(subject, action, resource).
+		AddParam("reason", "no_permission")
+}
+
+// AuthorizeClaims checks access of subject created by token claims from context (see SetClaims).
+//
+// Returns ErrNoToken if there are no claims in context
+func (a *Authorizer) AuthorizeClaims(ctx context.Context, action string, resource Resource) error {
+	claims, ok := ClaimsAny(ctx)
+	if !ok {
+		return ErrNoToken
+	}
+
+	return a.Authorize(ctx, NewSubject(claims), action, resource)
+}
+
+func (rule AccessRule) conditionsMet(subject Subject, resource Resource) bool {
+	for _, condition := range rule.Conditions {
+		if !condition.evaluate(subject, resource) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// claimsAttributes converts claims to attributes map. Struct claims are converted by their JSON representation
+func claimsAttributes(claims any) map[string]any {
+	switch typed := claims.(type) {
+	case nil:
+		return map[string]any{}
+	case map[string]any:
+		return typed
+	case jwt.MapClaims:
+		return typed
+	}
+
+	attributes := make(map[string]any)
+
+	blob, err := json.Marshal(claims)
+	if err != nil {
+		return attributes
+	}
+
+	_ = json.Unmarshal(blob, &attributes)
+	return attributes
+}
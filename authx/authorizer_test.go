@@ -0,0 +1,247 @@
+package authx
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/boostgo/core/errorx"
+)
+
+func testAccessPolicy() AccessPolicy {
+	return AccessPolicy{
+		Roles: map[string]Role{
+			"viewer": {
+				Permissions: []Permission{"orders:read:own"},
+			},
+			"manager": {
+				Inherits:    []string{"viewer"},
+				Permissions: []Permission{"orders:read", "orders:write"},
+			},
+			"auditor": {
+				Permissions: []Permission{"reports:*"},
+			},
+			"admin": {
+				Permissions: []Permission{"*"},
+			},
+		},
+		Rules: []AccessRule{
+			{
+				Name:      "closed_orders_readonly",
+				Effect:    AccessDeny,
+				Resources: []string{"orders"},
+				Actions:   []string{"write"},
+				Conditions: []Condition{
+					{Attribute: "resource.status", Operator: ConditionEq, Value: "closed"},
+				},
+			},
+			{
+				Name:      "department_invoices",
+				Effect:    AccessAllow,
+				Resources: []string{"invoices"},
+				Actions:   []string{"read"},
+				Conditions: []Condition{
+					{Attribute: "resource.department", Operator: ConditionEq, ValueFrom: "subject.department"},
+				},
+			},
+		},
+	}
+}
+
+// forbiddenReason returns "reason" param of ErrResourceForbidden
+func forbiddenReason(t *testing.T, err error) string {
+	t.Helper()
+
+	if !errors.Is(err, ErrResourceForbidden) {
+		t.Fatalf("expected resource forbidden error, got %v", err)
+	}
+
+	return errorReason(err)
+}
+
+// errorReason returns "reason" param of errorx error
+func errorReason(err error) string {
+	var custom *errorx.Error
+	if !errors.As(err, &custom) {
+		return ""
+	}
+
+	for _, param := range custom.Params() {
+		if param.Key == "reason" {
+			reason, _ := param.Value.(string)
+			return reason
+		}
+	}
+
+	return ""
+}
+
+func TestAuthorizerAuthorize(t *testing.T) {
+	authorizer, err := NewAuthorizer(testAccessPolicy())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	own := Resource{Type: "orders", ID: "1", OwnerID: "user"}
+	other := Resource{Type: "orders", ID: "2", OwnerID: "other"}
+	closed := Resource{Type: "orders", ID: "3", OwnerID: "user", Attributes: map[string]any{"status": "closed"}}
+	financeInvoice := Resource{Type: "invoices", Attributes: map[string]any{"department": "finance"}}
+
+	tests := []struct {
+		name     string
+		subject  Subject
+		action   string
+		resource Resource
+		reason   string
+	}{
+		{name: "own scope", subject: Subject{ID: "user", Roles: []string{"viewer"}}, action: "read", resource: own},
+		{name: "own scope of other resource", subject: Subject{ID: "user", Roles: []string{"viewer"}}, action: "read", resource: other, reason: "scope"},
+		{name: "own scope without subject id", subject: Subject{Roles: []string{"viewer"}}, action: "read", resource: Resource{Type: "orders"}, reason: "scope"},
+		{name: "no permission", subject: Subject{ID: "user", Roles: []string{"viewer"}}, action: "write", resource: own, reason: "no_permission"},
+		{name: "inherited role", subject: Subject{ID: "user", Roles: []string{"manager"}}, action: "read", resource: other},
+		{name: "role permission", subject: Subject{ID: "user", Roles: []string{"manager"}}, action: "write", resource: other},
+		{name: "action wildcard", subject: Subject{ID: "user", Roles: []string{"auditor"}}, action: "export", resource: Resource{Type: "reports"}},
+		{name: "action wildcard of other resource", subject: Subject{ID: "user", Roles: []string{"auditor"}}, action: "read", resource: own, reason: "no_permission"},
+		{name: "full wildcard", subject: Subject{ID: "user", Roles: []string{"admin"}}, action: "delete", resource: other},
+		{name: "subject permission", subject: Subject{ID: "user", Permissions: []Permission{"orders:delete"}}, action: "delete", resource: other},
+		{name: "unknown role", subject: Subject{ID: "user", Roles: []string{"guest"}}, action: "read", resource: own, reason: "no_permission"},
+		{name: "deny overrides role", subject: Subject{ID: "user", Roles: []string{"manager"}}, action: "write", resource: closed, reason: "denied_by_rule"},
+		{name: "deny overrides wildcard", subject: Subject{ID: "user", Roles: []string{"admin"}}, action: "write", resource: closed, reason: "denied_by_rule"},
+		{name: "deny condition not met", subject: Subject{ID: "user", Roles: []string{"manager"}}, action: "read", resource: closed},
+		{name: "allow rule", subject: Subject{ID: "user", Attributes: map[string]any{"department": "finance"}}, action: "read", resource: financeInvoice},
+		{name: "allow rule condition not met", subject: Subject{ID: "user", Attributes: map[string]any{"department": "sales"}}, action: "read", resource: financeInvoice, reason: "no_permission"},
+		{name: "allow rule other action", subject: Subject{ID: "user", Attributes: map[string]any{"department": "finance"}}, action: "write", resource: financeInvoice, reason: "no_permission"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := authorizer.Authorize(context.Background(), tt.subject, tt.action, tt.resource)
+			if tt.reason == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+
+			if reason := forbiddenReason(t, err); reason != tt.reason {
+				t.Errorf("expected reason %q, got %q", tt.reason, reason)
+			}
+		})
+	}
+}
+
+func TestAuthorizerRuleRoles(t *testing.T) {
+	authorizer := MustAuthorizer(AccessPolicy{
+		Roles: map[string]Role{"admin": {Permissions: []Permission{"*"}}},
+		Rules: []AccessRule{
+			{Name: "no_admin_delete", Effect: AccessDeny, Actions: []string{"delete"}, Roles: []string{"admin"}},
+		},
+	})
+
+	err := authorizer.Authorize(context.Background(), Subject{Roles: []string{"admin"}}, "delete", Resource{Type: "orders"})
+	if reason := forbiddenReason(t, err); reason != "denied_by_rule" {
+		t.Errorf("expected denied by rule, got %q", reason)
+	}
+
+	// rule with roles does not match subject without them
+	err = authorizer.Authorize(context.Background(), Subject{Permissions: []Permission{"orders:delete"}}, "delete", Resource{Type: "orders"})
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestAuthorizerCustomScope(t *testing.T) {
+	authorizer := MustAuthorizer(
+		AccessPolicy{Roles: map[string]Role{"member": {Permissions: []Permission{"projects:read:team"}}}},
+		WithScope("team", func(subject Subject, resource Resource) bool {
+			return subject.Attributes["team"] == resource.Attributes["team"]
+		}),
+	)
+
+	subject := Subject{Roles: []string{"member"}, Attributes: map[string]any{"team": "core"}}
+	if err := authorizer.Authorize(context.Background(), subject, "read", Resource{
+		Type:       "projects",
+		Attributes: map[string]any{"team": "core"},
+	}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	err := authorizer.Authorize(context.Background(), subject, "read", Resource{
+		Type:       "projects",
+		Attributes: map[string]any{"team": "web"},
+	})
+	if reason := forbiddenReason(t, err); reason != "scope" {
+		t.Errorf("expected scope reason, got %q", reason)
+	}
+}
+
+func TestAuthorizerClaims(t *testing.T) {
+	authorizer := MustAuthorizer(testAccessPolicy())
+
+	if err := authorizer.AuthorizeClaims(context.Background(), "read", Resource{Type: "orders"}); !errors.Is(err, ErrNoToken) {
+		t.Fatalf("expected no token error, got %v", err)
+	}
+
+	ctx := SetClaims(context.Background(), map[string]any{"sub": "user", "department": "finance"})
+	if err := authorizer.AuthorizeClaims(ctx, "read", Resource{
+		Type:       "invoices",
+		Attributes: map[string]any{"department": "finance"},
+	}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestAuthorizerPermissions(t *testing.T) {
+	authorizer := MustAuthorizer(testAccessPolicy())
+
+	permissions := authorizer.Permissions(Subject{
+		Roles:       []string{"manager"},
+		Permissions: []Permission{"reports:read"},
+	})
+
+	for _, expected := range []Permission{"reports:read", "orders:read", "orders:write", "orders:read:own"} {
+		if !expected.Is(permissions...) {
+			t.Errorf("expected permission %q in %v", expected, permissions)
+		}
+	}
+}
+
+func TestNewAuthorizerInvalidPolicy(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy AccessPolicy
+	}{
+		{
+			name:   "unknown inherited role",
+			policy: AccessPolicy{Roles: map[string]Role{"manager": {Inherits: []string{"viewer"}}}},
+		},
+		{
+			name: "inheritance cycle",
+			policy: AccessPolicy{Roles: map[string]Role{
+				"a": {Inherits: []string{"b"}},
+				"b": {Inherits: []string{"c"}},
+				"c": {Inherits: []string{"a"}},
+			}},
+		},
+		{
+			name:   "unknown effect",
+			policy: AccessPolicy{Rules: []AccessRule{{Name: "rule", Effect: "maybe"}}},
+		},
+		{
+			name: "unknown operator",
+			policy: AccessPolicy{Rules: []AccessRule{{
+				Name:       "rule",
+				Effect:     AccessAllow,
+				Conditions: []Condition{{Attribute: "resource.status", Operator: "like"}},
+			}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewAuthorizer(tt.policy); !errors.Is(err, ErrInvalidAccessPolicy) {
+				t.Errorf("expected invalid policy error, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package authx
+
+import "context"
+
+// claimsContextKey is key of token claims in context
+type claimsContextKey struct{}
+
+// GroupsClaims is claims which contain user groups. Used by InGroups policy
+type GroupsClaims interface {
+	GetGroups() []Group
+}
+
+// PermissionsClaims is claims which contain user permissions. Used by HasPermissions policy
+type PermissionsClaims interface {
+	GetPermissions() []Permission
+}
+
+// SetClaims returns context with provided token claims
+func SetClaims(ctx context.Context, claims any) context.Context {
+	return context.WithValue(ctx, claimsContextKey{}, claims)
+}
+
+// ClaimsAny returns token claims from context without type conversion
+func ClaimsAny(ctx context.Context) (any, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+
+	claims := ctx.Value(claimsContextKey{})
+	return claims, claims != nil
+}
+
+// Claims returns typed token claims from context.
+//
+// Returns false if there are no claims or claims have another type
+func Claims[T any](ctx context.Context) (T, bool) {
+	claims, ok := ClaimsAny(ctx)
+	if !ok {
+		var empty T
+		return empty, false
+	}
+
+	typed, ok := claims.(T)
+	return typed, ok
+}
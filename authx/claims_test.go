@@ -0,0 +1,28 @@
+package authx
+
+import (
+	"context"
+	"testing"
+)
+
+func TestClaims(t *testing.T) {
+	if _, ok := ClaimsAny(context.Background()); ok {
+		t.Error("expected no claims")
+	}
+
+	// string key of other package does not collide with claims key
+	ctx := context.WithValue(context.Background(), "authx-claims", "other")
+	if _, ok := ClaimsAny(ctx); ok {
+		t.Error("expected no claims by string key")
+	}
+
+	ctx = SetClaims(ctx, testClaims{groups: []Group{"admin"}})
+	claims, ok := Claims[testClaims](ctx)
+	if !ok || !claims.groups[0].Is("admin") {
+		t.Errorf("unexpected claims: %v", claims)
+	}
+
+	if _, ok = Claims[map[string]any](ctx); ok {
+		t.Error("expected claims of other type not to be returned")
+	}
+}
@@ -10,6 +10,34 @@ var (
 	ErrUnsupportedSigningMethod = errorx.New("authx.jwt.unsupported_signing_method")
 	ErrFailedToDecodePemBlock   = errorx.New("authx.jwt.failed_to_decode_pem_block")
 	ErrParsePkixPublicKey       = errorx.New("authx.jwt.parse_pkix_public_key")
+	ErrKeyNotFound              = errorx.New("authx.jwt.key_not_found").SetError(errorx.ErrUnauthorized)
+	ErrParseJWK                 = errorx.New("authx.jwt.parse_jwk")
+	ErrFetchJWKS                = errorx.New("authx.jwt.fetch_jwks")
+
+	ErrTokenExpired       = errorx.New("authx.jwt.token_expired").SetError(errorx.ErrUnauthorized)
+	ErrTokenNotValidYet   = errorx.New("authx.jwt.token_not_valid_yet").SetError(errorx.ErrUnauthorized)
+	ErrTokenNoExpiration  = errorx.New("authx.jwt.token_no_expiration").SetError(errorx.ErrUnauthorized)
+	ErrInvalidIssuer      = errorx.New("authx.jwt.invalid_issuer").SetError(errorx.ErrUnauthorized)
+	ErrInvalidAudience    = errorx.New("authx.jwt.invalid_audience").SetError(errorx.ErrUnauthorized)
+	ErrInvalidClaimFormat = errorx.New("authx.jwt.invalid_claim_format").SetError(errorx.ErrUnauthorized)
+	ErrTokenRevoked       = errorx.New("authx.jwt.token_revoked").SetError(errorx.ErrUnauthorized)
+
+	ErrInvalidSigningKey = errorx.New("authx.jwt.invalid_signing_key")
+	ErrSignToken         = errorx.New("authx.jwt.sign_token")
+
+	ErrInvalidRefreshToken  = errorx.New("authx.refresh.invalid_token").SetError(errorx.ErrUnauthorized)
+	ErrRefreshTokenReused   = errorx.New("authx.refresh.token_reused").SetError(errorx.ErrUnauthorized)
+	ErrRefreshTokenNotFound = errorx.New("authx.refresh.token_not_found").SetError(errorx.ErrNotFound)
+
+	ErrInvalidAccessPolicy = errorx.New("authx.access.invalid_policy")
+	ErrLoadAccessPolicy    = errorx.New("authx.access.load_policy")
+
+	ErrInvalidAPIKey  = errorx.New("authx.api_key.invalid").SetError(errorx.ErrUnauthorized)
+	ErrAPIKeyScope    = errorx.New("authx.api_key.scope").SetError(errorx.ErrForbidden)
+	ErrAPIKeyNotFound = errorx.New("authx.api_key.not_found").SetError(errorx.ErrNotFound)
+
+	ErrSignRequest      = errorx.New("authx.hmac.sign_request")
+	ErrInvalidSignature = errorx.New("authx.hmac.invalid_signature").SetError(errorx.ErrUnauthorized)
 
 	ErrNoToken           = errorx.New("auth.no_token").SetError(errorx.ErrUnauthorized)
 	ErrNoAccess          = errorx.New("auth.no_access").SetError(errorx.ErrForbidden)
@@ -22,6 +50,22 @@ type parseTokenContext struct {
 	Token string `json:"token"`
 }
 
+type resourceForbiddenContext struct {
+	Subject    string `json:"subject"`
+	Action     string `json:"action"`
+	Resource   string `json:"resource"`
+	ResourceID string `json:"resource_id,omitempty"`
+}
+
+func newResourceForbiddenError(subject Subject, action string, resource Resource) *errorx.Error {
+	return ErrResourceForbidden.SetData(resourceForbiddenContext{
+		Subject:    subject.ID,
+		Action:     action,
+		Resource:   resource.Type,
+		ResourceID: resource.ID,
+	})
+}
+
 func NewParseTokenError(err error, token string) error {
 	return ErrParseToken.
 		SetError(err).
@@ -0,0 +1,274 @@
+package authx
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	HeaderSignatureKeyID     = "X-Signature-Key-Id"
+	HeaderSignatureTimestamp = "X-Signature-Timestamp"
+	HeaderSignatureNonce     = "X-Signature-Nonce"
+	HeaderContentSHA256      = "X-Content-SHA256"
+	HeaderSignature          = "X-Signature"
+
+	defaultSignatureMaxSkew = time.Minute * 5
+	signatureNonceBytes     = 16
+)
+
+// CanonicalRequest builds string which is signed by HMAC:
+//
+//	METHOD
+//	/escaped/path
+//	sorted=query&values=encoded
+//	unix timestamp
+//	nonce
+//	hex(sha256(body))
+func CanonicalRequest(method, path string, query url.Values, timestamp, nonce, bodyHash string) string {
+	if path == "" {
+		path = "/"
+	}
+
+	return strings.Join([]string{
+		strings.ToUpper(method),
+		path,
+		canonicalQuery(query),
+		timestamp,
+		nonce,
+		bodyHash,
+	}, "\n")
+}
+
+// SignHMAC returns hex HMAC-SHA256 signature of canonical request
+func SignHMAC(secret []byte, canonicalRequest string) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(canonicalRequest))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// SignRequest signs HTTP request by HMAC-SHA256 and sets signature headers.
+//
+// Request body is read and restored, so request could be sent after signing
+func SignRequest(request *http.Request, keyID string, secret []byte) error {
+	body, err := readRequestBody(request)
+	if err != nil {
+		return ErrSignRequest.SetError(err)
+	}
+
+	nonce, err := randomHex(signatureNonceBytes)
+	if err != nil {
+		return ErrSignRequest.SetError(err)
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	bodyHash := hashBody(body)
+
+	canonical := CanonicalRequest(request.Method, request.URL.EscapedPath(), request.URL.Query(), timestamp, nonce, bodyHash)
+
+	request.Header.Set(HeaderSignatureKeyID, keyID)
+	request.Header.Set(HeaderSignatureTimestamp, timestamp)
+	request.Header.Set(HeaderSignatureNonce, nonce)
+	request.Header.Set(HeaderContentSHA256, bodyHash)
+	request.Header.Set(HeaderSignature, SignHMAC(secret, canonical))
+	return nil
+}
+
+// HMACSecretProvider returns signing secret by key ID
+type HMACSecretProvider interface {
+	Secret(ctx context.Context, keyID string) ([]byte, error)
+}
+
+type staticHMACSecrets map[string][]byte
+
+// StaticHMACSecrets creates HMACSecretProvider with constant "key ID - secret" map
+func StaticHMACSecrets(secrets map[string]string) HMACSecretProvider {
+	provider := make(staticHMACSecrets, len(secrets))
+	for keyID, secret := range secrets {
+		provider[keyID] = []byte(secret)
+	}
+
+	return provider
+}
+
+func (secrets staticHMACSecrets) Secret(_ context.Context, keyID string) ([]byte, error) {
+	secret, ok := secrets[keyID]
+	if !ok {
+		return nil, ErrInvalidSignature.AddParam("reason", "unknown_key")
+	}
+
+	return secret, nil
+}
+
+// SignedRequest is verified signed request info
+type SignedRequest struct {
+	KeyID     string    `json:"key_id"`
+	Nonce     string    `json:"nonce"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// HMACVerifierOption modifies HMACVerifier settings
+type HMACVerifierOption func(verifier *HMACVerifier)
+
+// WithSignatureMaxSkew sets max difference between request timestamp and server time. By default, it is 5 minutes
+func WithSignatureMaxSkew(skew time.Duration) HMACVerifierOption {
+	return func(verifier *HMACVerifier) {
+		if skew > 0 {
+			verifier.maxSkew = skew
+		}
+	}
+}
+
+// WithNonceStore sets nonce store for replay protection. Without store the same request could be replayed within max skew
+func WithNonceStore(store NonceStore) HMACVerifierOption {
+	return func(verifier *HMACVerifier) {
+		verifier.nonces = store
+	}
+}
+
+// HMACVerifier verifies requests signed by SignRequest
+type HMACVerifier struct {
+	secrets HMACSecretProvider
+	nonces  NonceStore
+	maxSkew time.Duration
+}
+
+// NewHMACVerifier creates verifier which gets signing secrets from provider
+func NewHMACVerifier(secrets HMACSecretProvider, opts ...HMACVerifierOption) *HMACVerifier {
+	verifier := &HMACVerifier{
+		secrets: secrets,
+		maxSkew: defaultSignatureMaxSkew,
+	}
+
+	for _, opt := range opts {
+		opt(verifier)
+	}
+
+	return verifier
+}
+
+// Verify checks request signature, timestamp and nonce.
+//
+// Request body is read and restored. Returns ErrInvalidSignature with "reason" param
+func (verifier *HMACVerifier) Verify(ctx context.Context, request *http.Request) (SignedRequest, error) {
+	keyID := request.Header.Get(HeaderSignatureKeyID)
+	timestamp := request.Header.Get(HeaderSignatureTimestamp)
+	nonce := request.Header.Get(HeaderSignatureNonce)
+	signature := request.Header.Get(HeaderSignature)
+
+	if keyID == "" || timestamp == "" || nonce == "" || signature == "" {
+		return SignedRequest{}, ErrInvalidSignature.AddParam("reason", "missing_headers")
+	}
+
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return SignedRequest{}, ErrInvalidSignature.AddParam("reason", "invalid_timestamp")
+	}
+
+	signedAt := time.Unix(unix, 0)
+	if skew := time.Since(signedAt); skew > verifier.maxSkew || skew < -verifier.maxSkew {
+		return SignedRequest{}, ErrInvalidSignature.AddParam("reason", "expired_timestamp")
+	}
+
+	body, err := readRequestBody(request)
+	if err != nil {
+		return SignedRequest{}, err
+	}
+
+	bodyHash := hashBody(body)
+	if contentHash := request.Header.Get(HeaderContentSHA256); contentHash != "" && contentHash != bodyHash {
+		return SignedRequest{}, ErrInvalidSignature.AddParam("reason", "body_hash_mismatch")
+	}
+
+	secret, err := verifier.secrets.Secret(ctx, keyID)
+	if err != nil {
+		return SignedRequest{}, err
+	}
+
+	canonical := CanonicalRequest(request.Method, request.URL.EscapedPath(), request.URL.Query(), timestamp, nonce, bodyHash)
+	if !hmac.Equal([]byte(SignHMAC(secret, canonical)), []byte(signature)) {
+		return SignedRequest{}, ErrInvalidSignature.AddParam("reason", "signature_mismatch")
+	}
+
+	if verifier.nonces != nil {
+		// nonce must be kept while timestamp is valid
+		fresh, err := verifier.nonces.Use(ctx, keyID+":"+nonce, verifier.maxSkew*2)
+		if err != nil {
+			return SignedRequest{}, err
+		}
+
+		if !fresh {
+			return SignedRequest{}, ErrInvalidSignature.AddParam("reason", "replayed_nonce")
+		}
+	}
+
+	return SignedRequest{
+		KeyID:     keyID,
+		Nonce:     nonce,
+		Timestamp: signedAt,
+	}, nil
+}
+
+func canonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(query))
+	for _, key := range keys {
+		values := append([]string{}, query[key]...)
+		sort.Strings(values)
+
+		for _, value := range values {
+			pairs = append(pairs, url.QueryEscape(key)+"="+url.QueryEscape(value))
+		}
+	}
+
+	return strings.Join(pairs, "&")
+}
+
+func hashBody(body []byte) string {
+	hash := sha256.Sum256(body)
+	return hex.EncodeToString(hash[:])
+}
+
+// readRequestBody reads request body and restores it for next readers
+func readRequestBody(request *http.Request) ([]byte, error) {
+	if request.Body == nil || request.Body == http.NoBody {
+		return nil, nil
+	}
+
+	if request.GetBody != nil {
+		reader, err := request.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		defer reader.Close()
+
+		return io.ReadAll(reader)
+	}
+
+	body, err := io.ReadAll(request.Body)
+	if err != nil {
+		return nil, err
+	}
+	_ = request.Body.Close()
+
+	request.Body = io.NopCloser(bytes.NewReader(body))
+	request.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+
+	return body, nil
+}
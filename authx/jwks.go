@@ -0,0 +1,170 @@
+package authx
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	defaultJWKSRefreshInterval    = time.Hour
+	defaultJWKSMinRefreshInterval = time.Minute
+	defaultJWKSFetchTimeout       = time.Second * 10
+)
+
+// JWKSOption modifies JWKS provider settings
+type JWKSOption func(provider *JWKS)
+
+// WithJWKSRefreshInterval sets how often keys are reloaded. By default, it is 1 hour
+func WithJWKSRefreshInterval(interval time.Duration) JWKSOption {
+	return func(provider *JWKS) {
+		if interval > 0 {
+			provider.refreshInterval = interval
+		}
+	}
+}
+
+// WithJWKSMinRefreshInterval sets minimum time between reloads caused by unknown "kid". By default, it is 1 minute
+func WithJWKSMinRefreshInterval(interval time.Duration) JWKSOption {
+	return func(provider *JWKS) {
+		if interval > 0 {
+			provider.minRefreshInterval = interval
+		}
+	}
+}
+
+// WithJWKSClient sets HTTP client for loading keys
+func WithJWKSClient(client *http.Client) JWKSOption {
+	return func(provider *JWKS) {
+		if client != nil {
+			provider.client = client
+		}
+	}
+}
+
+// JWKS is KeyProvider which loads keys from JWKS URL.
+//
+// Keys are cached and reloaded by refresh interval. If token has unknown "kid" (keys rotation),
+// keys are reloaded immediately, but not often than min refresh interval.
+//
+// If reload failed, previously loaded keys are used
+type JWKS struct {
+	url                string
+	client             *http.Client
+	refreshInterval    time.Duration
+	minRefreshInterval time.Duration
+
+	keys      []JwtKey
+	fetchedAt time.Time
+	mx        sync.RWMutex
+	fetchMx   sync.Mutex
+}
+
+// NewJWKS creates JWKS key provider by URL, for example Keycloak "https://host/realms/{realm}/protocol/openid-connect/certs"
+func NewJWKS(url string, opts ...JWKSOption) *JWKS {
+	provider := &JWKS{
+		url:                url,
+		client:             &http.Client{Timeout: defaultJWKSFetchTimeout},
+		refreshInterval:    defaultJWKSRefreshInterval,
+		minRefreshInterval: defaultJWKSMinRefreshInterval,
+	}
+
+	for _, opt := range opts {
+		opt(provider)
+	}
+
+	return provider
+}
+
+func (provider *JWKS) Key(kid string, method JwtSignMethod) (any, error) {
+	keys, fetchedAt := provider.snapshot()
+
+	if time.Since(fetchedAt) > provider.refreshInterval {
+		keys = provider.reload(fetchedAt)
+	}
+
+	key, err := selectKey(keys, kid, method)
+	if err == nil {
+		return key, nil
+	}
+
+	// unknown key could be rotated
+	_, fetchedAt = provider.snapshot()
+	if time.Since(fetchedAt) <= provider.minRefreshInterval {
+		return nil, err
+	}
+
+	return selectKey(provider.reload(fetchedAt), kid, method)
+}
+
+// Refresh loads keys from JWKS URL
+func (provider *JWKS) Refresh(ctx context.Context) error {
+	keys, err := provider.fetch(ctx)
+
+	provider.mx.Lock()
+	defer provider.mx.Unlock()
+
+	provider.fetchedAt = time.Now()
+	if err != nil {
+		return err
+	}
+
+	provider.keys = keys
+	return nil
+}
+
+// Keys returns loaded keys
+func (provider *JWKS) Keys() []JwtKey {
+	keys, _ := provider.snapshot()
+	return keys
+}
+
+func (provider *JWKS) snapshot() ([]JwtKey, time.Time) {
+	provider.mx.RLock()
+	defer provider.mx.RUnlock()
+
+	return provider.keys, provider.fetchedAt
+}
+
+// reload refreshes keys if they were not refreshed by another goroutine after "seen" time
+func (provider *JWKS) reload(seen time.Time) []JwtKey {
+	provider.fetchMx.Lock()
+	defer provider.fetchMx.Unlock()
+
+	if keys, fetchedAt := provider.snapshot(); fetchedAt.After(seen) {
+		return keys
+	}
+
+	_ = provider.Refresh(context.Background())
+
+	keys, _ := provider.snapshot()
+	return keys
+}
+
+func (provider *JWKS) fetch(ctx context.Context) ([]JwtKey, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, provider.url, nil)
+	if err != nil {
+		return nil, ErrFetchJWKS.SetError(err)
+	}
+
+	response, err := provider.client.Do(request)
+	if err != nil {
+		return nil, ErrFetchJWKS.SetError(err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, ErrFetchJWKS.
+			AddParam("url", provider.url).
+			AddParam("status", response.StatusCode)
+	}
+
+	blob, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, ErrFetchJWKS.SetError(err)
+	}
+
+	return ParseJWKS(blob)
+}
@@ -0,0 +1,225 @@
+package authx
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"strings"
+	"time"
+
+	"github.com/boostgo/core/convert"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+)
+
+const defaultAccessTokenTTL = time.Minute * 15
+
+// SigningKey is token signing key.
+//
+// Key type depends on method: []byte for HS*, *rsa.PrivateKey for RS* & PS*,
+// *ecdsa.PrivateKey for ES*, ed25519.PrivateKey for EdDSA
+type SigningKey struct {
+	// ID is set to "kid" token header
+	ID     string
+	Method JwtSignMethod
+	Key    any
+}
+
+// VerificationKey returns key which could verify tokens signed by the signing key
+func (key SigningKey) VerificationKey() JwtKey {
+	verification := JwtKey{
+		ID:     key.ID,
+		Method: key.Method,
+	}
+
+	switch typed := key.Key.(type) {
+	case []byte:
+		verification.Key = typed
+	case *rsa.PrivateKey:
+		verification.Key = &typed.PublicKey
+	case *ecdsa.PrivateKey:
+		verification.Key = &typed.PublicKey
+	case ed25519.PrivateKey:
+		verification.Key = typed.Public()
+	}
+
+	return verification
+}
+
+func (key SigningKey) validate() error {
+	var ok bool
+	switch {
+	case key.Method.IsHMAC():
+		_, ok = key.Key.([]byte)
+	case key.Method.IsRSA():
+		_, ok = key.Key.(*rsa.PrivateKey)
+	case key.Method.IsECDSA():
+		_, ok = key.Key.(*ecdsa.PrivateKey)
+	case key.Method.IsEdDSA():
+		_, ok = key.Key.(ed25519.PrivateKey)
+	default:
+		return ErrUnsupportedSigningMethod.AddParam("algorithm", key.Method)
+	}
+
+	if !ok {
+		return ErrInvalidSigningKey.AddParam("algorithm", key.Method)
+	}
+
+	return nil
+}
+
+// ParsePrivateKeyPEM parses RSA, ECDSA or Ed25519 private key in PKCS8, PKCS1 or SEC1 PEM format
+func ParsePrivateKeyPEM(privateKeyPEM string) (any, error) {
+	privateKeyPEM = strings.TrimSpace(strings.ReplaceAll(privateKeyPEM, "\\n", "\n"))
+
+	block, _ := pem.Decode(convert.BytesFromString(privateKeyPEM))
+	if block == nil {
+		return nil, ErrFailedToDecodePemBlock
+	}
+
+	if key, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	key, err := x509.ParseECPrivateKey(block.Bytes)
+	if err != nil {
+		return nil, ErrInvalidSigningKey.SetError(err)
+	}
+
+	return key, nil
+}
+
+// JwtIssuerOption modifies JwtIssuer settings
+type JwtIssuerOption func(issuer *JwtIssuer)
+
+// WithTokenIssuer sets "iss" claim of issued tokens
+func WithTokenIssuer(issuer string) JwtIssuerOption {
+	return func(jwtIssuer *JwtIssuer) {
+		jwtIssuer.issuer = issuer
+	}
+}
+
+// WithTokenAudience sets "aud" claim of issued tokens
+func WithTokenAudience(audiences ...string) JwtIssuerOption {
+	return func(jwtIssuer *JwtIssuer) {
+		jwtIssuer.audiences = append(jwtIssuer.audiences, audiences...)
+	}
+}
+
+// WithTokenTTL sets issued tokens lifetime. By default, it is 15 minutes
+func WithTokenTTL(ttl time.Duration) JwtIssuerOption {
+	return func(jwtIssuer *JwtIssuer) {
+		if ttl > 0 {
+			jwtIssuer.ttl = ttl
+		}
+	}
+}
+
+// IssuedToken is signed token with its registered claims
+type IssuedToken struct {
+	Token     string
+	ID        string
+	ExpiresAt time.Time
+}
+
+// JwtIssuer signs access tokens
+type JwtIssuer struct {
+	key       SigningKey
+	method    jwt.SigningMethod
+	issuer    string
+	audiences []string
+	ttl       time.Duration
+}
+
+// NewJwtIssuer creates issuer with provided signing key
+func NewJwtIssuer(key SigningKey, opts ...JwtIssuerOption) (*JwtIssuer, error) {
+	if err := key.validate(); err != nil {
+		return nil, err
+	}
+
+	method := jwt.GetSigningMethod(key.Method.String())
+	if method == nil {
+		return nil, ErrUnsupportedSigningMethod.AddParam("algorithm", key.Method)
+	}
+
+	issuer := &JwtIssuer{
+		key:    key,
+		method: method,
+		ttl:    defaultAccessTokenTTL,
+	}
+
+	for _, opt := range opts {
+		opt(issuer)
+	}
+
+	return issuer, nil
+}
+
+// MustIssuer calls NewJwtIssuer and panics on error
+func MustIssuer(key SigningKey, opts ...JwtIssuerOption) *JwtIssuer {
+	issuer, err := NewJwtIssuer(key, opts...)
+	if err != nil {
+		panic(err)
+	}
+
+	return issuer
+}
+
+// Keys returns key provider which verifies tokens of the issuer. Could be used by NewJwtParserWithKeys
+func (i *JwtIssuer) Keys() KeyProvider {
+	return StaticKeys(i.key.VerificationKey())
+}
+
+// TTL returns issued tokens lifetime
+func (i *JwtIssuer) TTL() time.Duration {
+	return i.ttl
+}
+
+// Issue signs new token for subject with provided custom claims.
+//
+// Registered claims (iss, sub, aud, iat, nbf, exp, jti) are set by issuer and override custom claims
+func (i *JwtIssuer) Issue(subject string, claims map[string]any) (IssuedToken, error) {
+	now := time.Now()
+	issued := IssuedToken{
+		ID:        uuid.NewString(),
+		ExpiresAt: now.Add(i.ttl),
+	}
+
+	mapClaims := make(jwt.MapClaims, len(claims)+7)
+	for key, value := range claims {
+		mapClaims[key] = value
+	}
+
+	mapClaims["sub"] = subject
+	mapClaims["iat"] = now.Unix()
+	mapClaims["nbf"] = now.Unix()
+	mapClaims["exp"] = issued.ExpiresAt.Unix()
+	mapClaims["jti"] = issued.ID
+
+	if i.issuer != "" {
+		mapClaims["iss"] = i.issuer
+	}
+
+	if len(i.audiences) > 0 {
+		mapClaims["aud"] = i.audiences
+	}
+
+	token := jwt.NewWithClaims(i.method, mapClaims)
+	if i.key.ID != "" {
+		token.Header["kid"] = i.key.ID
+	}
+
+	signed, err := token.SignedString(i.key.Key)
+	if err != nil {
+		return IssuedToken{}, ErrSignToken.SetError(err)
+	}
+
+	issued.Token = signed
+	return issued, nil
+}
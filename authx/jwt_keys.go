@@ -0,0 +1,239 @@
+package authx
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"strings"
+
+	"github.com/boostgo/core/convert"
+)
+
+// JwtKey is token verification key.
+//
+// Key type depends on method: []byte for HS*, *rsa.PublicKey for RS* & PS*,
+// *ecdsa.PublicKey for ES*, ed25519.PublicKey for EdDSA
+type JwtKey struct {
+	// ID is key id ("kid" token header). Empty ID matches any kid
+	ID string
+	// Method is the only method the key could be used with. Empty method means any method matching the key type
+	Method JwtSignMethod
+	Key    any
+}
+
+// KeyProvider returns verification key by token "kid" header & signing method
+type KeyProvider interface {
+	Key(kid string, method JwtSignMethod) (any, error)
+}
+
+type staticKeys []JwtKey
+
+// StaticKeys creates KeyProvider with constant keys list. Keys are selected by kid & method
+func StaticKeys(keys ...JwtKey) KeyProvider {
+	return staticKeys(keys)
+}
+
+func (keys staticKeys) Key(kid string, method JwtSignMethod) (any, error) {
+	return selectKey(keys, kid, method)
+}
+
+// selectKey returns the first key matching kid & method
+func selectKey(keys []JwtKey, kid string, method JwtSignMethod) (any, error) {
+	for _, key := range keys {
+		if kid != "" && key.ID != "" && key.ID != kid {
+			continue
+		}
+
+		if key.Method != "" && key.Method != method {
+			continue
+		}
+
+		if !keyMatchesMethod(key.Key, method) {
+			continue
+		}
+
+		return key.Key, nil
+	}
+
+	return nil, ErrKeyNotFound.
+		AddParam("kid", kid).
+		AddParam("alg", method)
+}
+
+// keyMatchesMethod checks key type, so key of one type could not be used with method of another type
+func keyMatchesMethod(key any, method JwtSignMethod) bool {
+	switch {
+	case method.IsHMAC():
+		_, ok := key.([]byte)
+		return ok
+	case method.IsRSA():
+		_, ok := key.(*rsa.PublicKey)
+		return ok
+	case method.IsECDSA():
+		_, ok := key.(*ecdsa.PublicKey)
+		return ok
+	case method.IsEdDSA():
+		_, ok := key.(ed25519.PublicKey)
+		return ok
+	default:
+		return false
+	}
+}
+
+// ParsePublicKeyPEM parses RSA, ECDSA or Ed25519 public key in PKIX PEM format.
+//
+// Key could be provided without "BEGIN/END PUBLIC KEY" lines
+func ParsePublicKeyPEM(publicKeyPEM string) (any, error) {
+	publicKeyPEM = strings.TrimSpace(strings.ReplaceAll(publicKeyPEM, "\\n", "\n"))
+	if !strings.HasPrefix(publicKeyPEM, "-----BEGIN") {
+		publicKeyPEM = "-----BEGIN PUBLIC KEY-----\n" + publicKeyPEM + "\n-----END PUBLIC KEY-----\n"
+	}
+
+	block, _ := pem.Decode(convert.BytesFromString(publicKeyPEM))
+	if block == nil {
+		return nil, ErrFailedToDecodePemBlock
+	}
+
+	publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, ErrParsePkixPublicKey.SetError(err)
+	}
+
+	return publicKey, nil
+}
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	Crv string `json:"crv"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+	K   string `json:"k"`
+}
+
+type jwks struct {
+	Keys []jwk `json:"keys"`
+}
+
+// ParseJWKS parses JWKS document ({"keys": [...]}) to keys list.
+//
+// Encryption keys ("use": "enc") and keys of unsupported types are skipped
+func ParseJWKS(blob []byte) ([]JwtKey, error) {
+	var document jwks
+	if err := json.Unmarshal(blob, &document); err != nil {
+		return nil, ErrParseJWK.SetError(err)
+	}
+
+	keys := make([]JwtKey, 0, len(document.Keys))
+	for _, raw := range document.Keys {
+		if raw.Use == "enc" {
+			continue
+		}
+
+		key, err := raw.publicKey()
+		if err != nil {
+			return nil, ErrParseJWK.
+				SetError(err).
+				AddParam("kid", raw.Kid)
+		}
+
+		if key == nil {
+			continue
+		}
+
+		keys = append(keys, JwtKey{
+			ID:     raw.Kid,
+			Method: JwtSignMethod(raw.Alg),
+			Key:    key,
+		})
+	}
+
+	return keys, nil
+}
+
+// publicKey returns verification key of JWK. Returns nil if key type is not supported
+func (key jwk) publicKey() (any, error) {
+	switch key.Kty {
+	case "RSA":
+		n, err := decodeBigInt(key.N)
+		if err != nil {
+			return nil, err
+		}
+
+		e, err := decodeBigInt(key.E)
+		if err != nil {
+			return nil, err
+		}
+
+		return &rsa.PublicKey{
+			N: n,
+			E: int(e.Int64()),
+		}, nil
+
+	case "EC":
+		var curve elliptic.Curve
+		switch key.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, nil
+		}
+
+		x, err := decodeBigInt(key.X)
+		if err != nil {
+			return nil, err
+		}
+
+		y, err := decodeBigInt(key.Y)
+		if err != nil {
+			return nil, err
+		}
+
+		return &ecdsa.PublicKey{
+			Curve: curve,
+			X:     x,
+			Y:     y,
+		}, nil
+
+	case "OKP":
+		if key.Crv != "Ed25519" {
+			return nil, nil
+		}
+
+		x, err := base64.RawURLEncoding.DecodeString(key.X)
+		if err != nil {
+			return nil, err
+		}
+
+		return ed25519.PublicKey(x), nil
+
+	case "oct":
+		return base64.RawURLEncoding.DecodeString(key.K)
+
+	default:
+		return nil, nil
+	}
+}
+
+func decodeBigInt(value string) (*big.Int, error) {
+	blob, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(big.Int).SetBytes(blob), nil
+}
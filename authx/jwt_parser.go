@@ -1,10 +1,11 @@
 package authx
 
 import (
+	"context"
 	"crypto/rsa"
-	"crypto/x509"
-	"encoding/pem"
-	"strings"
+	"encoding/json"
+	"slices"
+	"time"
 
 	"github.com/boostgo/core/convert"
 	"github.com/golang-jwt/jwt/v4"
@@ -12,32 +13,160 @@ import (
 
 type ClaimsParser[T jwt.Claims] func(claims jwt.MapClaims) (T, error)
 
+// JwtValidation describes registered claims validation
+type JwtValidation struct {
+	// Disabled turns off all claims validation
+	Disabled bool
+	// Issuers are allowed "iss" values. Empty means any issuer
+	Issuers []string
+	// Audiences are allowed "aud" values, token must contain at least one of them. Empty means any audience
+	Audiences []string
+	// Leeway is allowed clock skew for "exp" & "nbf" checks
+	Leeway time.Duration
+	// RequireExpiration rejects tokens without "exp" claim
+	RequireExpiration bool
+}
+
+// JwtParserOption modifies JwtParser settings
+type JwtParserOption func(options *jwtParserOptions)
+
+type jwtParserOptions struct {
+	methods     []JwtSignMethod
+	validation  JwtValidation
+	revocations RevocationList
+}
+
+// WithMethods sets allowed signing methods. By default, all supported methods are allowed (key type must match method)
+func WithMethods(methods ...JwtSignMethod) JwtParserOption {
+	return func(options *jwtParserOptions) {
+		options.methods = append(options.methods, methods...)
+	}
+}
+
+// WithIssuer sets allowed "iss" claim values
+func WithIssuer(issuers ...string) JwtParserOption {
+	return func(options *jwtParserOptions) {
+		options.validation.Issuers = append(options.validation.Issuers, issuers...)
+	}
+}
+
+// WithAudience sets allowed "aud" claim values
+func WithAudience(audiences ...string) JwtParserOption {
+	return func(options *jwtParserOptions) {
+		options.validation.Audiences = append(options.validation.Audiences, audiences...)
+	}
+}
+
+// WithLeeway sets allowed clock skew for "exp" & "nbf" checks
+func WithLeeway(leeway time.Duration) JwtParserOption {
+	return func(options *jwtParserOptions) {
+		options.validation.Leeway = leeway
+	}
+}
+
+// WithRequiredExpiration rejects tokens without "exp" claim
+func WithRequiredExpiration() JwtParserOption {
+	return func(options *jwtParserOptions) {
+		options.validation.RequireExpiration = true
+	}
+}
+
+// WithValidation sets all claims validation settings
+func WithValidation(validation JwtValidation) JwtParserOption {
+	return func(options *jwtParserOptions) {
+		options.validation = validation
+	}
+}
+
+// WithoutValidation turns off claims validation. Only signature is checked
+func WithoutValidation() JwtParserOption {
+	return func(options *jwtParserOptions) {
+		options.validation.Disabled = true
+	}
+}
+
+// WithRevocationList rejects tokens which "jti" claim is revoked. Check is made by ParseContext
+func WithRevocationList(list RevocationList) JwtParserOption {
+	return func(options *jwtParserOptions) {
+		options.revocations = list
+	}
+}
+
 type JwtParser[T jwt.Claims] struct {
-	secret       []byte
-	algorithm    string
-	publicKey    *rsa.PublicKey
+	keys         KeyProvider
+	methods      []JwtSignMethod
+	validation   JwtValidation
+	revocations  RevocationList
 	parser       *jwt.Parser
 	claimsParser ClaimsParser[T]
 }
 
+// NewJwtParser creates parser with single HS256 secret or RS256 public key (PEM) chosen by algorithm.
+//
+// Claims are not validated (only signature), use NewJwtParserWithKeys for claims validation
 func NewJwtParser[T jwt.Claims](
 	secret, algorithm, publicKey string,
 	claimsParser ClaimsParser[T],
 ) (*JwtParser[T], error) {
-	publicKey = strings.ReplaceAll(publicKey, "\\n", "\n")
+	var key JwtKey
 
-	parsedPublicKey, err := parseRSAPublicKey(publicKey)
-	if err != nil {
-		return nil, err
+	switch JwtSignMethod(algorithm) {
+	case JwtSignMethodHS256:
+		key = JwtKey{
+			Key: convert.BytesFromString(secret),
+		}
+	case JwtSignMethodRS256:
+		parsedPublicKey, err := parseRSAPublicKey(publicKey)
+		if err != nil {
+			return nil, err
+		}
+
+		key = JwtKey{
+			Key: parsedPublicKey,
+		}
+	default:
+		return nil, ErrUnsupportedSigningMethod.AddParam("algorithm", algorithm)
+	}
+
+	return NewJwtParserWithKeys(StaticKeys(key), claimsParser, WithoutValidation()), nil
+}
+
+// NewJwtParserWithKeys creates parser which selects verification key by token "kid" header & method.
+//
+// Keys could be static (StaticKeys) or loaded from JWKS URL (NewJWKS).
+//
+// By default, "exp" & "nbf" claims are validated if they exist
+func NewJwtParserWithKeys[T jwt.Claims](
+	keys KeyProvider,
+	claimsParser ClaimsParser[T],
+	opts ...JwtParserOption,
+) *JwtParser[T] {
+	options := jwtParserOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	parserOptions := []jwt.ParserOption{
+		jwt.WithoutClaimsValidation(),
+	}
+
+	if len(options.methods) > 0 {
+		methods := make([]string, 0, len(options.methods))
+		for _, method := range options.methods {
+			methods = append(methods, method.String())
+		}
+
+		parserOptions = append(parserOptions, jwt.WithValidMethods(methods))
 	}
 
 	return &JwtParser[T]{
-		secret:       convert.BytesFromString(secret),
-		algorithm:    algorithm,
-		publicKey:    parsedPublicKey,
-		parser:       jwt.NewParser(jwt.WithoutClaimsValidation()),
+		keys:         keys,
+		methods:      options.methods,
+		validation:   options.validation,
+		revocations:  options.revocations,
+		parser:       jwt.NewParser(parserOptions...),
 		claimsParser: claimsParser,
-	}, nil
+	}
 }
 
 func MustParser[T jwt.Claims](
@@ -53,31 +182,21 @@ func MustParser[T jwt.Claims](
 }
 
 func (p JwtParser[T]) Parse(tokenString string) (T, error) {
+	return p.ParseContext(context.Background(), tokenString)
+}
+
+// ParseContext parses token and checks if it is not revoked (if revocation list is set)
+func (p JwtParser[T]) ParseContext(ctx context.Context, tokenString string) (T, error) {
 	var empty T
 
 	token, err := p.parser.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		switch p.algorithm {
-		case "HS256":
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, NewUnexpectedSigningMethodError(JwtSignMethodHS256, convert.String(token.Header["alg"]))
-			}
-
-			return p.secret, nil
-
-		case "RS256":
-			if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
-				return nil, NewUnexpectedSigningMethodError(JwtSignMethodRS256, convert.String(token.Header["alg"]))
-			}
-
-			if p.publicKey != nil {
-				return p.publicKey, nil
-			}
-
-			return nil, ErrNoPublicKeyRS256
-
-		default:
-			return nil, ErrUnsupportedSigningMethod
+		method := JwtSignMethod(token.Method.Alg())
+		if len(p.methods) > 0 && !slices.Contains(p.methods, method) {
+			return nil, NewUnexpectedSigningMethodError(p.methods[0], method.String())
 		}
+
+		kid, _ := token.Header["kid"].(string)
+		return p.keys.Key(kid, method)
 	})
 	if err != nil {
 		return empty, NewParseTokenError(err, tokenString)
@@ -88,24 +207,142 @@ func (p JwtParser[T]) Parse(tokenString string) (T, error) {
 		return empty, ErrInvalidClaims
 	}
 
+	if err = p.validate(mapClaims, time.Now()); err != nil {
+		return empty, err
+	}
+
+	if err = p.checkRevoked(ctx, mapClaims); err != nil {
+		return empty, err
+	}
+
 	return p.claimsParser(mapClaims)
 }
 
-func parseRSAPublicKey(publicKeyPEM string) (*rsa.PublicKey, error) {
-	if publicKeyPEM == "" {
-		return nil, ErrNoPublicKeyRS256.AddParam("stage", "validation")
+func (p JwtParser[T]) validate(claims jwt.MapClaims, now time.Time) error {
+	validation := p.validation
+	if validation.Disabled {
+		return nil
 	}
 
-	wrappedPublicKey := "-----BEGIN PUBLIC KEY-----\n" + publicKeyPEM + "\n-----END PUBLIC KEY-----\n"
+	expiresAt, ok, err := timeClaim(claims, "exp")
+	if err != nil {
+		return err
+	}
 
-	block, _ := pem.Decode(convert.BytesFromString(wrappedPublicKey))
-	if block == nil {
-		return nil, ErrFailedToDecodePemBlock
+	if !ok && validation.RequireExpiration {
+		return ErrTokenNoExpiration
 	}
 
-	pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if ok && now.After(expiresAt.Add(validation.Leeway)) {
+		return ErrTokenExpired.AddParam("exp", expiresAt)
+	}
+
+	notBefore, ok, err := timeClaim(claims, "nbf")
 	if err != nil {
-		return nil, ErrParsePkixPublicKey.SetError(err)
+		return err
+	}
+
+	if ok && now.Add(validation.Leeway).Before(notBefore) {
+		return ErrTokenNotValidYet.AddParam("nbf", notBefore)
+	}
+
+	if len(validation.Issuers) > 0 {
+		issuer, _ := claims["iss"].(string)
+		if !slices.Contains(validation.Issuers, issuer) {
+			return ErrInvalidIssuer.AddParam("iss", issuer)
+		}
+	}
+
+	if len(validation.Audiences) > 0 {
+		audiences := audienceClaim(claims)
+		if !slices.ContainsFunc(audiences, func(audience string) bool {
+			return slices.Contains(validation.Audiences, audience)
+		}) {
+			return ErrInvalidAudience.AddParam("aud", audiences)
+		}
+	}
+
+	return nil
+}
+
+func (p JwtParser[T]) checkRevoked(ctx context.Context, claims jwt.MapClaims) error {
+	if p.revocations == nil {
+		return nil
+	}
+
+	tokenID, _ := claims["jti"].(string)
+	if tokenID == "" {
+		return nil
+	}
+
+	revoked, err := p.revocations.IsRevoked(ctx, tokenID)
+	if err != nil {
+		return err
+	}
+
+	if revoked {
+		return ErrTokenRevoked.AddParam("jti", tokenID)
+	}
+
+	return nil
+}
+
+// timeClaim returns NumericDate claim as time. Returns false if claim does not exist
+func timeClaim(claims jwt.MapClaims, name string) (time.Time, bool, error) {
+	raw, ok := claims[name]
+	if !ok || raw == nil {
+		return time.Time{}, false, nil
+	}
+
+	var seconds float64
+	switch value := raw.(type) {
+	case json.Number:
+		parsed, err := value.Float64()
+		if err != nil {
+			return time.Time{}, false, ErrInvalidClaimFormat.AddParam("claim", name)
+		}
+
+		seconds = parsed
+	case float64:
+		seconds = value
+	case int64:
+		seconds = float64(value)
+	default:
+		return time.Time{}, false, ErrInvalidClaimFormat.AddParam("claim", name)
+	}
+
+	return time.Unix(0, int64(seconds*float64(time.Second))), true, nil
+}
+
+// audienceClaim returns "aud" claim which could be string or array of strings
+func audienceClaim(claims jwt.MapClaims) []string {
+	switch value := claims["aud"].(type) {
+	case string:
+		return []string{value}
+	case []string:
+		return value
+	case []any:
+		audiences := make([]string, 0, len(value))
+		for _, audience := range value {
+			if audienceString, ok := audience.(string); ok {
+				audiences = append(audiences, audienceString)
+			}
+		}
+
+		return audiences
+	default:
+		return nil
+	}
+}
+
+func parseRSAPublicKey(publicKeyPEM string) (*rsa.PublicKey, error) {
+	if publicKeyPEM == "" {
+		return nil, ErrNoPublicKeyRS256.AddParam("stage", "validation")
+	}
+
+	pub, err := ParsePublicKeyPEM(publicKeyPEM)
+	if err != nil {
+		return nil, err
 	}
 
 	rsaPub, ok := pub.(*rsa.PublicKey)
@@ -0,0 +1,330 @@
+package authx
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+func mapClaimsParser(claims jwt.MapClaims) (jwt.MapClaims, error) {
+	return claims, nil
+}
+
+func signTestToken(t *testing.T, method jwt.SigningMethod, key any, kid string, claims jwt.MapClaims) string {
+	t.Helper()
+
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return signed
+}
+
+func TestJwtParserKeySelection(t *testing.T) {
+	secret := []byte("secret")
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	parser := NewJwtParserWithKeys(
+		StaticKeys(
+			JwtKey{ID: "hmac", Method: JwtSignMethodHS256, Key: secret},
+			JwtKey{ID: "rsa", Method: JwtSignMethodRS256, Key: &rsaKey.PublicKey},
+		),
+		mapClaimsParser,
+	)
+
+	claims := jwt.MapClaims{"sub": "user"}
+	tests := []struct {
+		name  string
+		token string
+		valid bool
+	}{
+		{
+			name:  "hmac key",
+			token: signTestToken(t, jwt.SigningMethodHS256, secret, "hmac", claims),
+			valid: true,
+		},
+		{
+			name:  "rsa key",
+			token: signTestToken(t, jwt.SigningMethodRS256, rsaKey, "rsa", claims),
+			valid: true,
+		},
+		{
+			name:  "unknown kid",
+			token: signTestToken(t, jwt.SigningMethodHS256, secret, "other", claims),
+		},
+		{
+			name:  "kid of key with other alg",
+			token: signTestToken(t, jwt.SigningMethodHS256, secret, "rsa", claims),
+		},
+		{
+			name:  "other alg of the key",
+			token: signTestToken(t, jwt.SigningMethodHS512, secret, "hmac", claims),
+		},
+		{
+			name:  "wrong secret",
+			token: signTestToken(t, jwt.SigningMethodHS256, []byte("wrong"), "hmac", claims),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parser.Parse(tt.token)
+			if tt.valid && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !tt.valid && !errors.Is(err, ErrParseToken) {
+				t.Fatalf("expected parse token error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestJwtParserAlgorithmConfusion(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// key without method is matched by key type only, so HMAC token could not be verified by RSA public key
+	parser := NewJwtParserWithKeys(StaticKeys(JwtKey{Key: &rsaKey.PublicKey}), mapClaimsParser)
+
+	blob, err := json.Marshal(rsaKey.PublicKey)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	token := signTestToken(t, jwt.SigningMethodHS256, blob, "", jwt.MapClaims{"sub": "user"})
+	if _, err = parser.Parse(token); !errors.Is(err, ErrParseToken) {
+		t.Fatalf("expected parse token error, got %v", err)
+	}
+
+	// allowed methods
+	parser = NewJwtParserWithKeys(StaticKeys(JwtKey{Key: []byte("secret")}), mapClaimsParser, WithMethods(JwtSignMethodHS512))
+	token = signTestToken(t, jwt.SigningMethodHS256, []byte("secret"), "", jwt.MapClaims{"sub": "user"})
+	if _, err = parser.Parse(token); !errors.Is(err, ErrParseToken) {
+		t.Fatalf("expected parse token error, got %v", err)
+	}
+}
+
+func TestJwtParserClaimsValidation(t *testing.T) {
+	secret := []byte("secret")
+	now := time.Now()
+
+	tests := []struct {
+		name   string
+		claims jwt.MapClaims
+		opts   []JwtParserOption
+		err    error
+	}{
+		{
+			name:   "valid",
+			claims: jwt.MapClaims{"exp": now.Add(time.Minute).Unix(), "nbf": now.Add(-time.Minute).Unix()},
+		},
+		{
+			name:   "expired",
+			claims: jwt.MapClaims{"exp": now.Add(-time.Minute).Unix()},
+			err:    ErrTokenExpired,
+		},
+		{
+			name:   "expired within leeway",
+			claims: jwt.MapClaims{"exp": now.Add(-time.Second * 10).Unix()},
+			opts:   []JwtParserOption{WithLeeway(time.Minute)},
+		},
+		{
+			name:   "not valid yet",
+			claims: jwt.MapClaims{"nbf": now.Add(time.Minute).Unix()},
+			err:    ErrTokenNotValidYet,
+		},
+		{
+			name:   "not valid yet within leeway",
+			claims: jwt.MapClaims{"nbf": now.Add(time.Second * 10).Unix()},
+			opts:   []JwtParserOption{WithLeeway(time.Minute)},
+		},
+		{
+			name:   "no expiration",
+			claims: jwt.MapClaims{"sub": "user"},
+			opts:   []JwtParserOption{WithRequiredExpiration()},
+			err:    ErrTokenNoExpiration,
+		},
+		{
+			name:   "invalid expiration format",
+			claims: jwt.MapClaims{"exp": "tomorrow"},
+			err:    ErrInvalidClaimFormat,
+		},
+		{
+			name:   "wrong issuer",
+			claims: jwt.MapClaims{"iss": "other"},
+			opts:   []JwtParserOption{WithIssuer("auth")},
+			err:    ErrInvalidIssuer,
+		},
+		{
+			name:   "audience from list",
+			claims: jwt.MapClaims{"aud": []string{"web", "api"}},
+			opts:   []JwtParserOption{WithAudience("api")},
+		},
+		{
+			name:   "wrong audience",
+			claims: jwt.MapClaims{"aud": "web"},
+			opts:   []JwtParserOption{WithAudience("api")},
+			err:    ErrInvalidAudience,
+		},
+		{
+			name:   "validation disabled",
+			claims: jwt.MapClaims{"exp": now.Add(-time.Minute).Unix()},
+			opts:   []JwtParserOption{WithoutValidation()},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser := NewJwtParserWithKeys(StaticKeys(JwtKey{Key: secret}), mapClaimsParser, tt.opts...)
+			_, err := parser.Parse(signTestToken(t, jwt.SigningMethodHS256, secret, "", tt.claims))
+			if tt.err == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("expected %v, got %v", tt.err, err)
+			}
+		})
+	}
+}
+
+func TestJWKSKeyRotation(t *testing.T) {
+	first := newTestECKey(t)
+	second := newTestECKey(t)
+
+	var (
+		mx      sync.Mutex
+		current = []JwtKey{{ID: "first", Method: JwtSignMethodES256, Key: &first.PublicKey}}
+		fetches atomic.Int32
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+
+		mx.Lock()
+		defer mx.Unlock()
+
+		_ = json.NewEncoder(w).Encode(testJWKS(current))
+	}))
+	defer server.Close()
+
+	provider := NewJWKS(server.URL, WithJWKSMinRefreshInterval(time.Nanosecond))
+	parser := NewJwtParserWithKeys(provider, mapClaimsParser, WithMethods(JwtSignMethodES256))
+	claims := jwt.MapClaims{"sub": "user"}
+
+	if _, err := parser.Parse(signTestToken(t, jwt.SigningMethodES256, first, "first", claims)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// keys are cached
+	if _, err := parser.Parse(signTestToken(t, jwt.SigningMethodES256, first, "first", claims)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if fetches.Load() != 1 {
+		t.Fatalf("expected 1 fetch, got %d", fetches.Load())
+	}
+
+	// new key is published, old one is removed
+	mx.Lock()
+	current = []JwtKey{{ID: "second", Method: JwtSignMethodES256, Key: &second.PublicKey}}
+	mx.Unlock()
+
+	// unknown kid reloads keys
+	if _, err := parser.Parse(signTestToken(t, jwt.SigningMethodES256, second, "second", claims)); err != nil {
+		t.Fatalf("unexpected error after rotation: %v", err)
+	}
+
+	if fetches.Load() != 2 {
+		t.Fatalf("expected 2 fetches, got %d", fetches.Load())
+	}
+
+	if _, err := parser.Parse(signTestToken(t, jwt.SigningMethodES256, first, "first", claims)); !errors.Is(err, ErrParseToken) {
+		t.Fatalf("expected removed key to be rejected, got %v", err)
+	}
+}
+
+func TestJWKSMinRefreshInterval(t *testing.T) {
+	key := newTestECKey(t)
+
+	var fetches atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+		_ = json.NewEncoder(w).Encode(testJWKS([]JwtKey{{ID: "known", Method: JwtSignMethodES256, Key: &key.PublicKey}}))
+	}))
+	defer server.Close()
+
+	parser := NewJwtParserWithKeys(NewJWKS(server.URL), mapClaimsParser)
+	claims := jwt.MapClaims{"sub": "user"}
+
+	// unknown kids do not reload keys more often than min refresh interval
+	for i := 0; i < 3; i++ {
+		if _, err := parser.Parse(signTestToken(t, jwt.SigningMethodES256, key, "unknown", claims)); !errors.Is(err, ErrParseToken) {
+			t.Fatalf("expected parse token error, got %v", err)
+		}
+	}
+
+	if fetches.Load() != 1 {
+		t.Fatalf("expected 1 fetch, got %d", fetches.Load())
+	}
+}
+
+func newTestECKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return key
+}
+
+// testJWKS builds JWKS document of P-256 keys
+func testJWKS(keys []JwtKey) jwks {
+	document := jwks{
+		Keys: make([]jwk, 0, len(keys)),
+	}
+
+	for _, key := range keys {
+		publicKey := key.Key.(*ecdsa.PublicKey)
+		document.Keys = append(document.Keys, jwk{
+			Kty: "EC",
+			Kid: key.ID,
+			Alg: key.Method.String(),
+			Use: "sig",
+			Crv: "P-256",
+			X:   base64.RawURLEncoding.EncodeToString(publicKey.X.FillBytes(make([]byte, 32))),
+			Y:   base64.RawURLEncoding.EncodeToString(publicKey.Y.FillBytes(make([]byte, 32))),
+		})
+	}
+
+	return document
+}
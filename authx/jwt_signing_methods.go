@@ -1,5 +1,7 @@
 package authx
 
+import "strings"
+
 type JwtSignMethod string
 
 func (m JwtSignMethod) String() string {
@@ -8,5 +10,36 @@ func (m JwtSignMethod) String() string {
 
 const (
 	JwtSignMethodHS256 JwtSignMethod = "HS256"
+	JwtSignMethodHS384 JwtSignMethod = "HS384"
+	JwtSignMethodHS512 JwtSignMethod = "HS512"
 	JwtSignMethodRS256 JwtSignMethod = "RS256"
+	JwtSignMethodRS384 JwtSignMethod = "RS384"
+	JwtSignMethodRS512 JwtSignMethod = "RS512"
+	JwtSignMethodPS256 JwtSignMethod = "PS256"
+	JwtSignMethodPS384 JwtSignMethod = "PS384"
+	JwtSignMethodPS512 JwtSignMethod = "PS512"
+	JwtSignMethodES256 JwtSignMethod = "ES256"
+	JwtSignMethodES384 JwtSignMethod = "ES384"
+	JwtSignMethodES512 JwtSignMethod = "ES512"
+	JwtSignMethodEdDSA JwtSignMethod = "EdDSA"
 )
+
+// IsHMAC returns true if method uses shared secret
+func (m JwtSignMethod) IsHMAC() bool {
+	return strings.HasPrefix(m.String(), "HS")
+}
+
+// IsRSA returns true if method uses RSA keys (RS* & PS* methods)
+func (m JwtSignMethod) IsRSA() bool {
+	return strings.HasPrefix(m.String(), "RS") || strings.HasPrefix(m.String(), "PS")
+}
+
+// IsECDSA returns true if method uses ECDSA keys
+func (m JwtSignMethod) IsECDSA() bool {
+	return strings.HasPrefix(m.String(), "ES")
+}
+
+// IsEdDSA returns true if method uses Ed25519 keys
+func (m JwtSignMethod) IsEdDSA() bool {
+	return m == JwtSignMethodEdDSA
+}
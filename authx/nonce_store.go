@@ -0,0 +1,77 @@
+package authx
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/boostgo/core/orderedmap"
+	"github.com/boostgo/core/redis"
+)
+
+const (
+	defaultNonceStoreCapacity = 100_000
+	defaultNonceRedisPrefix   = "authx:nonce:"
+)
+
+// NonceStore remembers used nonces for replay protection
+type NonceStore interface {
+	// Use marks nonce as used for ttl. Returns false if nonce was already used
+	Use(ctx context.Context, nonce string, ttl time.Duration) (bool, error)
+}
+
+type memoryNonceStore struct {
+	nonces *orderedmap.OrderedMap[string, time.Time]
+	mx     sync.Mutex
+}
+
+// NewMemoryNonceStore creates in-memory nonce store.
+//
+// Store keeps up to "capacity" nonces, the oldest nonces are evicted. Zero capacity means 100000 nonces
+func NewMemoryNonceStore(capacity int) NonceStore {
+	if capacity <= 0 {
+		capacity = defaultNonceStoreCapacity
+	}
+
+	return &memoryNonceStore{
+		nonces: orderedmap.NewOrderedMap[string, time.Time](
+			orderedmap.WithCapacity[string, time.Time](capacity),
+		),
+	}
+}
+
+func (store *memoryNonceStore) Use(_ context.Context, nonce string, ttl time.Duration) (bool, error) {
+	store.mx.Lock()
+	defer store.mx.Unlock()
+
+	now := time.Now()
+	if expiresAt, ok := store.nonces.Get(nonce); ok && now.Before(expiresAt) {
+		return false, nil
+	}
+
+	store.nonces.Set(nonce, now.Add(ttl))
+	return true, nil
+}
+
+type redisNonceStore struct {
+	client redis.Client
+	prefix string
+}
+
+// NewRedisNonceStore creates distributed nonce store. Nonces are marked by SETNX
+func NewRedisNonceStore(client redis.Client, prefix ...string) NonceStore {
+	store := &redisNonceStore{
+		client: client,
+		prefix: defaultNonceRedisPrefix,
+	}
+
+	if len(prefix) > 0 && prefix[0] != "" {
+		store.prefix = prefix[0]
+	}
+
+	return store
+}
+
+func (store *redisNonceStore) Use(ctx context.Context, nonce string, ttl time.Duration) (bool, error) {
+	return store.client.SetNX(ctx, store.prefix+nonce, 1, ttl)
+}
@@ -1,7 +1,18 @@
 package authx
 
-import "slices"
+import (
+	"slices"
+	"strings"
+)
 
+const (
+	permissionSeparator = ":"
+	permissionWildcard  = "*"
+)
+
+// Permission is access permission in "resource:action[:scope]" format, for example "orders:read:own".
+//
+// Resource & action could be "*" wildcard
 type Permission string
 
 func (p Permission) String() string {
@@ -11,3 +22,52 @@ func (p Permission) String() string {
 func (p Permission) Is(permissions ...Permission) bool {
 	return slices.Contains(permissions, p)
 }
+
+// Resource returns resource part of permission
+func (p Permission) Resource() string {
+	resource, _, _ := p.parts()
+	return resource
+}
+
+// Action returns action part of permission
+func (p Permission) Action() string {
+	_, action, _ := p.parts()
+	return action
+}
+
+// Scope returns scope part of permission, for example "own". Empty scope means any resource
+func (p Permission) Scope() string {
+	_, _, scope := p.parts()
+	return scope
+}
+
+// Grants checks if permission allows action on resource type. Scope is not checked
+func (p Permission) Grants(resource, action string) bool {
+	permissionResource, permissionAction, _ := p.parts()
+	return matchPermissionPart(permissionResource, resource) &&
+		matchPermissionPart(permissionAction, action)
+}
+
+func (p Permission) parts() (resource, action, scope string) {
+	parts := strings.SplitN(p.String(), permissionSeparator, 3)
+
+	resource = parts[0]
+	if resource == permissionWildcard {
+		// "*" means all actions of all resources
+		return resource, permissionWildcard, ""
+	}
+
+	if len(parts) > 1 {
+		action = parts[1]
+	}
+
+	if len(parts) > 2 {
+		scope = parts[2]
+	}
+
+	return resource, action, scope
+}
+
+func matchPermissionPart(pattern, value string) bool {
+	return pattern == permissionWildcard || pattern == value
+}
@@ -0,0 +1,99 @@
+package authx
+
+// Policy checks if token claims have access.
+//
+// Policies could be combined by AnyOf & AllOf:
+//
+//	authx.AnyOf(
+//		authx.InGroups("admin"),
+//		authx.AllOf(authx.InGroups("manager"), authx.HasPermissions("orders.write")),
+//	)
+type Policy interface {
+	Check(claims any) error
+}
+
+// PolicyFunc is function implementation of Policy
+type PolicyFunc func(claims any) error
+
+func (fn PolicyFunc) Check(claims any) error {
+	return fn(claims)
+}
+
+// InGroups returns policy which allows claims with at least one of provided groups.
+//
+// Claims must implement GroupsClaims
+func InGroups(groups ...Group) Policy {
+	return PolicyFunc(func(claims any) error {
+		groupsClaims, ok := claims.(GroupsClaims)
+		if !ok {
+			return ErrNoGroups
+		}
+
+		for _, group := range groupsClaims.GetGroups() {
+			if group.Is(groups...) {
+				return nil
+			}
+		}
+
+		return ErrNoAccess.AddParam("groups", groups)
+	})
+}
+
+// HasPermissions returns policy which allows claims with all provided permissions.
+//
+// Claims must implement PermissionsClaims
+func HasPermissions(permissions ...Permission) Policy {
+	return PolicyFunc(func(claims any) error {
+		permissionsClaims, ok := claims.(PermissionsClaims)
+		if !ok {
+			return ErrNoAccess.AddParam("permissions", permissions)
+		}
+
+		owned := permissionsClaims.GetPermissions()
+		for _, permission := range permissions {
+			if !permission.Is(owned...) {
+				return ErrNoAccess.AddParam("permission", permission)
+			}
+		}
+
+		return nil
+	})
+}
+
+// AnyOf returns policy which allows claims if at least one of provided policies allows.
+//
+// If all policies deny, the first error is returned
+func AnyOf(policies ...Policy) Policy {
+	return PolicyFunc(func(claims any) error {
+		var first error
+		for _, policy := range policies {
+			err := policy.Check(claims)
+			if err == nil {
+				return nil
+			}
+
+			if first == nil {
+				first = err
+			}
+		}
+
+		if first == nil {
+			return ErrNoAccess
+		}
+
+		return first
+	})
+}
+
+// AllOf returns policy which allows claims only if all provided policies allow
+func AllOf(policies ...Policy) Policy {
+	return PolicyFunc(func(claims any) error {
+		for _, policy := range policies {
+			if err := policy.Check(claims); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
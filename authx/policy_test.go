@@ -0,0 +1,67 @@
+package authx
+
+import (
+	"errors"
+	"testing"
+)
+
+type testClaims struct {
+	groups      []Group
+	permissions []Permission
+}
+
+func (claims testClaims) GetGroups() []Group {
+	return claims.groups
+}
+
+func (claims testClaims) GetPermissions() []Permission {
+	return claims.permissions
+}
+
+func TestPolicies(t *testing.T) {
+	manager := testClaims{groups: []Group{"manager"}, permissions: []Permission{"orders.read", "orders.write"}}
+	viewer := testClaims{groups: []Group{"viewer"}, permissions: []Permission{"orders.read"}}
+
+	tests := []struct {
+		name   string
+		policy Policy
+		claims any
+		err    error
+	}{
+		{name: "in groups", policy: InGroups("admin", "manager"), claims: manager},
+		{name: "not in groups", policy: InGroups("admin"), claims: viewer, err: ErrNoAccess},
+		{name: "no groups claims", policy: InGroups("admin"), claims: map[string]any{}, err: ErrNoGroups},
+		{name: "has permissions", policy: HasPermissions("orders.read", "orders.write"), claims: manager},
+		{name: "has not all permissions", policy: HasPermissions("orders.read", "orders.write"), claims: viewer, err: ErrNoAccess},
+		{name: "no permissions claims", policy: HasPermissions("orders.read"), claims: nil, err: ErrNoAccess},
+		{name: "any of", policy: AnyOf(InGroups("admin"), HasPermissions("orders.read")), claims: viewer},
+		{name: "any of returns first error", policy: AnyOf(InGroups("admin"), HasPermissions("orders.write")), claims: map[string]any{}, err: ErrNoGroups},
+		{name: "any of empty", policy: AnyOf(), claims: manager, err: ErrNoAccess},
+		{name: "all of", policy: AllOf(InGroups("manager"), HasPermissions("orders.write")), claims: manager},
+		{name: "all of denies", policy: AllOf(InGroups("viewer"), HasPermissions("orders.write")), claims: viewer, err: ErrNoAccess},
+		{
+			name: "nested",
+			policy: AnyOf(
+				InGroups("admin"),
+				AllOf(InGroups("manager"), HasPermissions("orders.write")),
+			),
+			claims: manager,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.Check(tt.claims)
+			if tt.err == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("expected %v, got %v", tt.err, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,205 @@
+package authx
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/boostgo/core/errorx"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultRefreshTokenTTL  = time.Hour * 24 * 30
+	refreshTokenSecretBytes = 32
+)
+
+// RefreshToken is stored refresh token.
+//
+// Tokens issued by rotation of the same login have the same FamilyID.
+// Token value itself is not stored, only its hash (ID)
+type RefreshToken struct {
+	ID        string         `json:"id" db:"id"`
+	FamilyID  string         `json:"family_id" db:"family_id"`
+	Subject   string         `json:"subject" db:"subject"`
+	Claims    map[string]any `json:"claims" db:"-"`
+	ExpiresAt time.Time      `json:"expires_at" db:"expires_at"`
+	CreatedAt time.Time      `json:"created_at" db:"created_at"`
+	Used      bool           `json:"used" db:"used"`
+	Revoked   bool           `json:"revoked" db:"revoked"`
+}
+
+// RefreshTokenStore keeps refresh tokens
+type RefreshTokenStore interface {
+	// Save saves new refresh token
+	Save(ctx context.Context, token RefreshToken) error
+	// Get returns refresh token by ID. Returns error based on errorx.ErrNotFound if there is no token
+	Get(ctx context.Context, id string) (RefreshToken, error)
+	// MarkUsed atomically marks token as used. Returns false if token was already used
+	MarkUsed(ctx context.Context, id string) (bool, error)
+	// RevokeFamily revokes all tokens of the family
+	RevokeFamily(ctx context.Context, familyID string) error
+}
+
+// RevocationList keeps revoked access tokens IDs ("jti" claim) till their expiration
+type RevocationList interface {
+	Revoke(ctx context.Context, tokenID string, expiresAt time.Time) error
+	IsRevoked(ctx context.Context, tokenID string) (bool, error)
+}
+
+// TokenPair is access & refresh tokens
+type TokenPair struct {
+	AccessToken      string    `json:"access_token"`
+	AccessExpiresAt  time.Time `json:"access_expires_at"`
+	RefreshToken     string    `json:"refresh_token"`
+	RefreshExpiresAt time.Time `json:"refresh_expires_at"`
+}
+
+// TokenRotatorOption modifies TokenRotator settings
+type TokenRotatorOption func(rotator *TokenRotator)
+
+// WithRefreshTokenTTL sets refresh token lifetime. By default, it is 30 days
+func WithRefreshTokenTTL(ttl time.Duration) TokenRotatorOption {
+	return func(rotator *TokenRotator) {
+		if ttl > 0 {
+			rotator.ttl = ttl
+		}
+	}
+}
+
+// TokenRotator issues access & refresh tokens pairs and rotates refresh tokens.
+//
+// Every refresh token could be used only once. If used refresh token comes again (it was stolen),
+// all tokens of its family are revoked
+type TokenRotator struct {
+	issuer *JwtIssuer
+	store  RefreshTokenStore
+	ttl    time.Duration
+}
+
+// NewTokenRotator creates TokenRotator which signs access tokens by issuer and keeps refresh tokens in store
+func NewTokenRotator(issuer *JwtIssuer, store RefreshTokenStore, opts ...TokenRotatorOption) *TokenRotator {
+	rotator := &TokenRotator{
+		issuer: issuer,
+		store:  store,
+		ttl:    defaultRefreshTokenTTL,
+	}
+
+	for _, opt := range opts {
+		opt(rotator)
+	}
+
+	return rotator
+}
+
+// Issue issues tokens pair for new login
+func (r *TokenRotator) Issue(ctx context.Context, subject string, claims map[string]any) (TokenPair, error) {
+	return r.issue(ctx, uuid.NewString(), subject, claims)
+}
+
+// Refresh exchanges refresh token to new tokens pair.
+//
+// Returns ErrInvalidRefreshToken if token is unknown, expired or revoked.
+// Returns ErrRefreshTokenReused if token was already used, in this case the whole family is revoked
+func (r *TokenRotator) Refresh(ctx context.Context, refreshToken string) (TokenPair, error) {
+	stored, err := r.load(ctx, refreshToken)
+	if err != nil {
+		return TokenPair{}, err
+	}
+
+	marked, err := r.store.MarkUsed(ctx, stored.ID)
+	if err != nil {
+		return TokenPair{}, err
+	}
+
+	if !marked {
+		if err = r.store.RevokeFamily(ctx, stored.FamilyID); err != nil {
+			return TokenPair{}, err
+		}
+
+		return TokenPair{}, ErrRefreshTokenReused.AddParam("subject", stored.Subject)
+	}
+
+	return r.issue(ctx, stored.FamilyID, stored.Subject, stored.Claims)
+}
+
+// Revoke revokes refresh token family (logout).
+//
+// Access tokens are still valid till expiration, use RevocationList to revoke them
+func (r *TokenRotator) Revoke(ctx context.Context, refreshToken string) error {
+	stored, err := r.load(ctx, refreshToken)
+	if err != nil {
+		return err
+	}
+
+	return r.store.RevokeFamily(ctx, stored.FamilyID)
+}
+
+func (r *TokenRotator) load(ctx context.Context, refreshToken string) (RefreshToken, error) {
+	stored, err := r.store.Get(ctx, HashRefreshToken(refreshToken))
+	if err != nil {
+		if errors.Is(err, errorx.ErrNotFound) {
+			return RefreshToken{}, ErrInvalidRefreshToken
+		}
+
+		return RefreshToken{}, err
+	}
+
+	if stored.Revoked || time.Now().After(stored.ExpiresAt) {
+		return RefreshToken{}, ErrInvalidRefreshToken
+	}
+
+	return stored, nil
+}
+
+func (r *TokenRotator) issue(ctx context.Context, familyID, subject string, claims map[string]any) (TokenPair, error) {
+	access, err := r.issuer.Issue(subject, claims)
+	if err != nil {
+		return TokenPair{}, err
+	}
+
+	refreshToken, err := newRefreshTokenValue()
+	if err != nil {
+		return TokenPair{}, err
+	}
+
+	now := time.Now()
+	stored := RefreshToken{
+		ID:        HashRefreshToken(refreshToken),
+		FamilyID:  familyID,
+		Subject:   subject,
+		Claims:    claims,
+		ExpiresAt: now.Add(r.ttl),
+		CreatedAt: now,
+	}
+
+	if err = r.store.Save(ctx, stored); err != nil {
+		return TokenPair{}, err
+	}
+
+	return TokenPair{
+		AccessToken:      access.Token,
+		AccessExpiresAt:  access.ExpiresAt,
+		RefreshToken:     refreshToken,
+		RefreshExpiresAt: stored.ExpiresAt,
+	}, nil
+}
+
+// HashRefreshToken returns refresh token ID which is used by store
+func HashRefreshToken(refreshToken string) string {
+	hash := sha256.Sum256([]byte(refreshToken))
+	return hex.EncodeToString(hash[:])
+}
+
+func newRefreshTokenValue() (string, error) {
+	secret := make([]byte, refreshTokenSecretBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(secret), nil
+}
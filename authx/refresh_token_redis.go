@@ -0,0 +1,141 @@
+package authx
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/boostgo/core/errorx"
+	"github.com/boostgo/core/redis"
+)
+
+const (
+	defaultRefreshTokenRedisPrefix = "authx:refresh:"
+	defaultRevocationRedisPrefix   = "authx:revoked:"
+)
+
+type redisRefreshTokenStore struct {
+	client redis.Client
+	prefix string
+	ttl    time.Duration
+}
+
+// NewRedisRefreshTokenStore creates refresh tokens store based on redis.
+//
+// Tokens are stored as JSON and expire by their ExpiresAt. Family revocation is stored as separate key
+// which lives till refresh token lifetime (familyTTL) passes
+func NewRedisRefreshTokenStore(client redis.Client, familyTTL time.Duration, prefix ...string) RefreshTokenStore {
+	store := &redisRefreshTokenStore{
+		client: client,
+		prefix: defaultRefreshTokenRedisPrefix,
+		ttl:    familyTTL,
+	}
+
+	if len(prefix) > 0 && prefix[0] != "" {
+		store.prefix = prefix[0]
+	}
+
+	if store.ttl <= 0 {
+		store.ttl = defaultRefreshTokenTTL
+	}
+
+	return store
+}
+
+func (store *redisRefreshTokenStore) Save(ctx context.Context, token RefreshToken) error {
+	blob, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+
+	return store.client.Set(ctx, store.tokenKey(token.ID), blob, time.Until(token.ExpiresAt))
+}
+
+func (store *redisRefreshTokenStore) Get(ctx context.Context, id string) (RefreshToken, error) {
+	var token RefreshToken
+
+	blob, err := store.client.GetBytes(ctx, store.tokenKey(id))
+	if err != nil {
+		if errors.Is(err, errorx.ErrNotFound) {
+			return token, ErrRefreshTokenNotFound
+		}
+
+		return token, err
+	}
+
+	if err = json.Unmarshal(blob, &token); err != nil {
+		return token, err
+	}
+
+	used, err := store.client.Exist(ctx, store.usedKey(id))
+	if err != nil {
+		return token, err
+	}
+
+	revoked, err := store.client.Exist(ctx, store.familyKey(token.FamilyID))
+	if err != nil {
+		return token, err
+	}
+
+	token.Used = used > 0
+	token.Revoked = revoked > 0
+	return token, nil
+}
+
+func (store *redisRefreshTokenStore) MarkUsed(ctx context.Context, id string) (bool, error) {
+	return store.client.SetNX(ctx, store.usedKey(id), 1, store.ttl)
+}
+
+func (store *redisRefreshTokenStore) RevokeFamily(ctx context.Context, familyID string) error {
+	return store.client.Set(ctx, store.familyKey(familyID), 1, store.ttl)
+}
+
+func (store *redisRefreshTokenStore) tokenKey(id string) string {
+	return store.prefix + id
+}
+
+func (store *redisRefreshTokenStore) usedKey(id string) string {
+	return store.prefix + "used:" + id
+}
+
+func (store *redisRefreshTokenStore) familyKey(familyID string) string {
+	return store.prefix + "family:" + familyID
+}
+
+type redisRevocationList struct {
+	client redis.Client
+	prefix string
+}
+
+// NewRedisRevocationList creates revocation list based on redis. Revoked token keys expire with the token
+func NewRedisRevocationList(client redis.Client, prefix ...string) RevocationList {
+	list := &redisRevocationList{
+		client: client,
+		prefix: defaultRevocationRedisPrefix,
+	}
+
+	if len(prefix) > 0 && prefix[0] != "" {
+		list.prefix = prefix[0]
+	}
+
+	return list
+}
+
+func (list *redisRevocationList) Revoke(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	ttl := time.Until(expiresAt)
+	if ttl <= 0 {
+		return nil
+	}
+
+	return list.client.Set(ctx, list.prefix+tokenID, 1, ttl)
+}
+
+func (list *redisRevocationList) IsRevoked(ctx context.Context, tokenID string) (bool, error) {
+	exist, err := list.client.Exist(ctx, list.prefix+tokenID)
+	if err != nil {
+		return false, err
+	}
+
+	return exist > 0, nil
+}
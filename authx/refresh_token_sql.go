@@ -0,0 +1,166 @@
+package authx
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/boostgo/core/sql"
+)
+
+const (
+	defaultRefreshTokensTable = "refresh_tokens"
+	defaultRevokedTokensTable = "revoked_tokens"
+)
+
+// RefreshTokensSchema is PostgreSQL schema of refresh tokens & revoked tokens tables
+// used by NewSQLRefreshTokenStore & NewSQLRevocationList with default table names
+const RefreshTokensSchema = `
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+	id         TEXT PRIMARY KEY,
+	family_id  TEXT NOT NULL,
+	subject    TEXT NOT NULL,
+	claims     TEXT NOT NULL DEFAULT '{}',
+	expires_at TIMESTAMPTZ NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL,
+	used       BOOLEAN NOT NULL DEFAULT FALSE,
+	revoked    BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+CREATE INDEX IF NOT EXISTS refresh_tokens_family_id_idx ON refresh_tokens (family_id);
+
+CREATE TABLE IF NOT EXISTS revoked_tokens (
+	id         TEXT PRIMARY KEY,
+	expires_at TIMESTAMPTZ NOT NULL
+);
+`
+
+type sqlRefreshToken struct {
+	RefreshToken
+	ClaimsJSON string `db:"claims"`
+}
+
+type sqlRefreshTokenStore struct {
+	db    sql.DB
+	table string
+}
+
+// NewSQLRefreshTokenStore creates refresh tokens store based on SQL table (see RefreshTokensSchema)
+func NewSQLRefreshTokenStore(db sql.DB, table ...string) RefreshTokenStore {
+	store := &sqlRefreshTokenStore{
+		db:    db,
+		table: defaultRefreshTokensTable,
+	}
+
+	if len(table) > 0 && table[0] != "" {
+		store.table = table[0]
+	}
+
+	return store
+}
+
+func (store *sqlRefreshTokenStore) Save(ctx context.Context, token RefreshToken) error {
+	claims, err := json.Marshal(token.Claims)
+	if err != nil {
+		return err
+	}
+
+	args := sql.NewArguments()
+	query := `INSERT INTO ` + store.table + ` (id, family_id, subject, claims, expires_at, created_at, used, revoked) VALUES ` +
+		args.AddMany(token.ID, token.FamilyID, token.Subject, string(claims), token.ExpiresAt, token.CreatedAt, token.Used, token.Revoked)
+
+	_, err = store.db.ExecContext(ctx, query, args.Args()...)
+	return err
+}
+
+func (store *sqlRefreshTokenStore) Get(ctx context.Context, id string) (RefreshToken, error) {
+	var row sqlRefreshToken
+
+	args := sql.NewArguments()
+	query := `SELECT id, family_id, subject, claims, expires_at, created_at, used, revoked FROM ` + store.table +
+		` WHERE id = ` + args.Add(id).Number()
+	if err := store.db.GetContext(ctx, &row, query, args.Args()...); err != nil {
+		if sql.NotFound(err) {
+			return RefreshToken{}, ErrRefreshTokenNotFound
+		}
+
+		return RefreshToken{}, err
+	}
+
+	token := row.RefreshToken
+	if row.ClaimsJSON != "" {
+		if err := json.Unmarshal([]byte(row.ClaimsJSON), &token.Claims); err != nil {
+			return RefreshToken{}, err
+		}
+	}
+
+	return token, nil
+}
+
+func (store *sqlRefreshTokenStore) MarkUsed(ctx context.Context, id string) (bool, error) {
+	args := sql.NewArguments()
+	query := `UPDATE ` + store.table + ` SET used = TRUE WHERE id = ` + args.Add(id).Number() + ` AND used = FALSE`
+
+	result, err := store.db.ExecContext(ctx, query, args.Args()...)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected == 1, nil
+}
+
+func (store *sqlRefreshTokenStore) RevokeFamily(ctx context.Context, familyID string) error {
+	args := sql.NewArguments()
+	query := `UPDATE ` + store.table + ` SET revoked = TRUE WHERE family_id = ` + args.Add(familyID).Number()
+
+	_, err := store.db.ExecContext(ctx, query, args.Args()...)
+	return err
+}
+
+type sqlRevocationList struct {
+	db    sql.DB
+	table string
+}
+
+// NewSQLRevocationList creates revocation list based on SQL table (see RefreshTokensSchema).
+//
+// Expired rows are not deleted automatically
+func NewSQLRevocationList(db sql.DB, table ...string) RevocationList {
+	list := &sqlRevocationList{
+		db:    db,
+		table: defaultRevokedTokensTable,
+	}
+
+	if len(table) > 0 && table[0] != "" {
+		list.table = table[0]
+	}
+
+	return list
+}
+
+func (list *sqlRevocationList) Revoke(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	args := sql.NewArguments()
+	query := `INSERT INTO ` + list.table + ` (id, expires_at) VALUES ` + args.AddMany(tokenID, expiresAt) + ` ON CONFLICT (id) DO NOTHING`
+
+	_, err := list.db.ExecContext(ctx, query, args.Args()...)
+	return err
+}
+
+func (list *sqlRevocationList) IsRevoked(ctx context.Context, tokenID string) (bool, error) {
+	var exist bool
+
+	args := sql.NewArguments()
+	query := `SELECT EXISTS(SELECT 1 FROM ` + list.table +
+		` WHERE id = ` + args.Add(tokenID).Number() +
+		` AND expires_at > ` + args.Add(time.Now()).Number() + `)`
+	if err := list.db.GetContext(ctx, &exist, query, args.Args()...); err != nil {
+		return false, err
+	}
+
+	return exist, nil
+}
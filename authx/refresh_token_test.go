@@ -0,0 +1,226 @@
+package authx
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// memoryRefreshTokenStore is RefreshTokenStore for tests
+type memoryRefreshTokenStore struct {
+	tokens map[string]RefreshToken
+	mx     sync.Mutex
+}
+
+func newMemoryRefreshTokenStore() *memoryRefreshTokenStore {
+	return &memoryRefreshTokenStore{
+		tokens: make(map[string]RefreshToken),
+	}
+}
+
+func (store *memoryRefreshTokenStore) Save(_ context.Context, token RefreshToken) error {
+	store.mx.Lock()
+	defer store.mx.Unlock()
+
+	store.tokens[token.ID] = token
+	return nil
+}
+
+func (store *memoryRefreshTokenStore) Get(_ context.Context, id string) (RefreshToken, error) {
+	store.mx.Lock()
+	defer store.mx.Unlock()
+
+	token, ok := store.tokens[id]
+	if !ok {
+		return RefreshToken{}, ErrRefreshTokenNotFound
+	}
+
+	return token, nil
+}
+
+func (store *memoryRefreshTokenStore) MarkUsed(_ context.Context, id string) (bool, error) {
+	store.mx.Lock()
+	defer store.mx.Unlock()
+
+	token, ok := store.tokens[id]
+	if !ok || token.Used {
+		return false, nil
+	}
+
+	token.Used = true
+	store.tokens[id] = token
+	return true, nil
+}
+
+func (store *memoryRefreshTokenStore) RevokeFamily(_ context.Context, familyID string) error {
+	store.mx.Lock()
+	defer store.mx.Unlock()
+
+	for id, token := range store.tokens {
+		if token.FamilyID == familyID {
+			token.Revoked = true
+			store.tokens[id] = token
+		}
+	}
+
+	return nil
+}
+
+func newTestTokenRotator(t *testing.T, opts ...TokenRotatorOption) (*TokenRotator, *JwtIssuer) {
+	t.Helper()
+
+	issuer, err := NewJwtIssuer(SigningKey{ID: "key", Method: JwtSignMethodHS256, Key: []byte("secret")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return NewTokenRotator(issuer, newMemoryRefreshTokenStore(), opts...), issuer
+}
+
+func TestTokenRotatorRefresh(t *testing.T) {
+	rotator, issuer := newTestTokenRotator(t)
+	ctx := context.Background()
+
+	pair, err := rotator.Issue(ctx, "user", map[string]any{"role": "admin"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	refreshed, err := rotator.Refresh(ctx, pair.RefreshToken)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if refreshed.RefreshToken == pair.RefreshToken {
+		t.Fatal("expected new refresh token")
+	}
+
+	// access token keeps subject & claims
+	parser := NewJwtParserWithKeys(issuer.Keys(), mapClaimsParser, WithRequiredExpiration())
+	claims, err := parser.Parse(refreshed.AccessToken)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if claims["sub"] != "user" || claims["role"] != "admin" {
+		t.Errorf("unexpected claims: %v", claims)
+	}
+
+	if _, err = rotator.Refresh(ctx, refreshed.RefreshToken); err != nil {
+		t.Fatalf("expected rotated token to be valid, got %v", err)
+	}
+}
+
+func TestTokenRotatorReuseRevokesFamily(t *testing.T) {
+	rotator, _ := newTestTokenRotator(t)
+	ctx := context.Background()
+
+	pair, err := rotator.Issue(ctx, "user", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	other, err := rotator.Issue(ctx, "user", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	refreshed, err := rotator.Refresh(ctx, pair.RefreshToken)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// used token comes again (it was stolen)
+	if _, err = rotator.Refresh(ctx, pair.RefreshToken); !errors.Is(err, ErrRefreshTokenReused) {
+		t.Fatalf("expected reused token error, got %v", err)
+	}
+
+	// the whole family is revoked, including token issued by rotation
+	if _, err = rotator.Refresh(ctx, refreshed.RefreshToken); !errors.Is(err, ErrInvalidRefreshToken) {
+		t.Fatalf("expected revoked family token to be invalid, got %v", err)
+	}
+
+	// other login is not affected
+	if _, err = rotator.Refresh(ctx, other.RefreshToken); err != nil {
+		t.Fatalf("expected token of other family to be valid, got %v", err)
+	}
+}
+
+func TestTokenRotatorInvalidTokens(t *testing.T) {
+	rotator, _ := newTestTokenRotator(t, WithRefreshTokenTTL(time.Millisecond))
+	ctx := context.Background()
+
+	if _, err := rotator.Refresh(ctx, "unknown"); !errors.Is(err, ErrInvalidRefreshToken) {
+		t.Fatalf("expected invalid token error, got %v", err)
+	}
+
+	pair, err := rotator.Issue(ctx, "user", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	time.Sleep(time.Millisecond * 5)
+	if _, err = rotator.Refresh(ctx, pair.RefreshToken); !errors.Is(err, ErrInvalidRefreshToken) {
+		t.Fatalf("expected expired token to be invalid, got %v", err)
+	}
+}
+
+func TestTokenRotatorRevoke(t *testing.T) {
+	rotator, _ := newTestTokenRotator(t)
+	ctx := context.Background()
+
+	pair, err := rotator.Issue(ctx, "user", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err = rotator.Revoke(ctx, pair.RefreshToken); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err = rotator.Refresh(ctx, pair.RefreshToken); !errors.Is(err, ErrInvalidRefreshToken) {
+		t.Fatalf("expected revoked token to be invalid, got %v", err)
+	}
+}
+
+// memoryRevocationList is RevocationList for tests
+type memoryRevocationList struct {
+	revoked sync.Map
+}
+
+func (list *memoryRevocationList) Revoke(_ context.Context, tokenID string, _ time.Time) error {
+	list.revoked.Store(tokenID, struct{}{})
+	return nil
+}
+
+func (list *memoryRevocationList) IsRevoked(_ context.Context, tokenID string) (bool, error) {
+	_, ok := list.revoked.Load(tokenID)
+	return ok, nil
+}
+
+func TestJwtParserRevocationList(t *testing.T) {
+	rotator, issuer := newTestTokenRotator(t)
+	revocations := &memoryRevocationList{}
+	parser := NewJwtParserWithKeys(issuer.Keys(), mapClaimsParser, WithRevocationList(revocations))
+
+	pair, err := rotator.Issue(context.Background(), "user", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	claims, err := parser.Parse(pair.AccessToken)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tokenID, _ := claims["jti"].(string)
+	if err = revocations.Revoke(context.Background(), tokenID, pair.AccessExpiresAt); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err = parser.Parse(pair.AccessToken); !errors.Is(err, ErrTokenRevoked) {
+		t.Fatalf("expected revoked token error, got %v", err)
+	}
+}
@@ -2,7 +2,16 @@
 // Features:
 // - Simple response functions - Ok, Error, Failure, Success.
 // - Running server with registered recover, trace and other middlewares and using appx global context
+// - Independent server instances (NewServer) with own routes, middlewares and listener
 // - Reading request data by using echo.Context
+// - Typed handlers (Handle) with automatic binding, validation and response
+// - OpenAPI 3.1 document generation from typed handlers
+// - Rate limiting middleware with in-memory & redis stores
+// - Idempotency-Key middleware with stored responses replay
+// - HTTP response cache middleware with ETag & stale-while-revalidate support
+// - Bearer token authentication middleware with groups, permissions & policy guards
+// - Server-Sent Events, NDJSON & chunked streaming responses from iterators
+// - WebSocket hub with rooms, messages routing by type and redis pub/sub fan-out
 package echox
 
 import (
@@ -61,8 +70,11 @@ func Failure(ctx echo.Context, status int, err error) error {
 		convertedError = httpx.ErrorByStatusCode(status)
 	}
 
+	// copy error, so failure middlewares could not change package level errors
+	convertedError = errorx.Extend(convertedError)
+
 	// run failure middlewares
-	for _, m := range failureMiddlewares {
+	for _, m := range serverOf(ctx).failureMiddlewares {
 		m(ctx, status, convertedError)
 	}
 
@@ -0,0 +1,77 @@
+package echox
+
+import (
+	"github.com/boostgo/core/authx"
+
+	"github.com/labstack/echo/v4"
+)
+
+const HeaderAPIKey = "X-API-Key"
+
+// APIKeyOption modifies API key middleware settings
+type APIKeyOption func(options *apiKeyOptions)
+
+type apiKeyOptions struct {
+	header string
+	scopes []string
+}
+
+// WithAPIKeyHeader sets header with API key. By default, it is "X-API-Key"
+func WithAPIKeyHeader(header string) APIKeyOption {
+	return func(options *apiKeyOptions) {
+		options.header = header
+	}
+}
+
+// WithAPIKeyScopes sets scopes which key must have
+func WithAPIKeyScopes(scopes ...string) APIKeyOption {
+	return func(options *apiKeyOptions) {
+		options.scopes = append(options.scopes, scopes...)
+	}
+}
+
+// APIKeyMiddleware verifies API key from header and stores authx.APIKey as claims to the request context.
+//
+// Key scopes could be checked later by RequirePermissions. Returns authx.ErrNoToken if there is no key
+func APIKeyMiddleware(keys *authx.APIKeys, opts ...APIKeyOption) echo.MiddlewareFunc {
+	options := apiKeyOptions{
+		header: HeaderAPIKey,
+	}
+
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			plainKey := ctx.Request().Header.Get(options.header)
+			if plainKey == "" {
+				return Error(ctx, authx.ErrNoToken)
+			}
+
+			key, err := keys.Verify(Context(ctx), plainKey, options.scopes...)
+			if err != nil {
+				return Error(ctx, err)
+			}
+
+			SetContext(ctx, authx.SetClaims(Context(ctx), key))
+			return next(ctx)
+		}
+	}
+}
+
+// SignatureMiddleware verifies HMAC request signature (see authx.SignRequest) and stores authx.SignedRequest
+// as claims to the request context
+func SignatureMiddleware(verifier *authx.HMACVerifier) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			signed, err := verifier.Verify(Context(ctx), ctx.Request())
+			if err != nil {
+				return Error(ctx, err)
+			}
+
+			SetContext(ctx, authx.SetClaims(Context(ctx), signed))
+			return next(ctx)
+		}
+	}
+}
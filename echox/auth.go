@@ -0,0 +1,194 @@
+package echox
+
+import (
+	"context"
+	"strings"
+
+	"github.com/boostgo/core/authx"
+
+	"github.com/gorilla/websocket"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	bearerPrefix = "bearer "
+)
+
+// TokenParser parses token string to typed claims. authx.JwtParser implements it
+type TokenParser[T any] interface {
+	Parse(token string) (T, error)
+}
+
+// ContextTokenParser is TokenParser which uses request context, for example to check token revocation.
+// AuthMiddleware calls ParseContext if parser implements it
+type ContextTokenParser[T any] interface {
+	ParseContext(ctx context.Context, token string) (T, error)
+}
+
+// AuthOption modifies auth middleware settings
+type AuthOption func(options *authOptions)
+
+type authOptions struct {
+	header   string
+	cookie   string
+	query    string
+	optional bool
+}
+
+// WithAuthHeader sets header with "Bearer" token. By default, it is "Authorization"
+func WithAuthHeader(header string) AuthOption {
+	return func(options *authOptions) {
+		options.header = header
+	}
+}
+
+// WithAuthCookie sets cookie name which contains token if there is no token in header
+func WithAuthCookie(cookie string) AuthOption {
+	return func(options *authOptions) {
+		options.cookie = cookie
+	}
+}
+
+// WithAuthQuery sets query param name which contains token if there is no token in header or cookie.
+//
+// Query token is read only for websocket upgrade requests, because browsers could not set headers to them.
+// Tokens in query of other requests are ignored, so they do not leak to logs & browser history
+func WithAuthQuery(param string) AuthOption {
+	return func(options *authOptions) {
+		options.query = param
+	}
+}
+
+// WithAuthOptional allows requests without token. Requests with invalid token are still rejected
+func WithAuthOptional() AuthOption {
+	return func(options *authOptions) {
+		options.optional = true
+	}
+}
+
+// AuthMiddleware parses "Bearer" token from header (or cookie) and stores claims to the request context.
+//
+// Claims could be got by Claims function or by authx.Claims from typed handler context.
+//
+// Returns authx.ErrNoToken if there is no token
+func AuthMiddleware[T any](parser TokenParser[T], opts ...AuthOption) echo.MiddlewareFunc {
+	options := authOptions{
+		header: echo.HeaderAuthorization,
+	}
+
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			token := extractToken(ctx, options)
+			if token == "" {
+				if options.optional {
+					return next(ctx)
+				}
+
+				return Error(ctx, authx.ErrNoToken)
+			}
+
+			claims, err := parseToken(ctx, parser, token)
+			if err != nil {
+				return Error(ctx, err)
+			}
+
+			SetContext(ctx, authx.SetClaims(Context(ctx), claims))
+			return next(ctx)
+		}
+	}
+}
+
+func parseToken[T any](ctx echo.Context, parser TokenParser[T], token string) (T, error) {
+	if contextParser, ok := parser.(ContextTokenParser[T]); ok {
+		return contextParser.ParseContext(Context(ctx), token)
+	}
+
+	return parser.Parse(token)
+}
+
+// Claims returns typed claims stored by AuthMiddleware
+func Claims[T any](ctx echo.Context) (T, bool) {
+	return authx.Claims[T](Context(ctx))
+}
+
+// RequireGroups allows requests with claims which have at least one of provided groups.
+//
+// Must be used after AuthMiddleware
+func RequireGroups(groups ...authx.Group) echo.MiddlewareFunc {
+	return RequirePolicy(authx.InGroups(groups...))
+}
+
+// RequirePermissions allows requests with claims which have all provided permissions.
+//
+// Must be used after AuthMiddleware
+func RequirePermissions(permissions ...authx.Permission) echo.MiddlewareFunc {
+	return RequirePolicy(authx.HasPermissions(permissions...))
+}
+
+// RequirePolicy allows requests with claims which are allowed by provided policy.
+//
+// Policies could be combined by authx.AnyOf & authx.AllOf. Must be used after AuthMiddleware
+func RequirePolicy(policy authx.Policy) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			claims, ok := authx.ClaimsAny(Context(ctx))
+			if !ok {
+				return Error(ctx, authx.ErrNoToken)
+			}
+
+			if err := policy.Check(claims); err != nil {
+				return Error(ctx, err)
+			}
+
+			return next(ctx)
+		}
+	}
+}
+
+// RequireAccess allows requests which subject (created by token claims) could do action with resource.
+//
+// Resource is built by request, for example by path params. Must be used after AuthMiddleware
+func RequireAccess(
+	authorizer *authx.Authorizer,
+	action string,
+	resource func(ctx echo.Context) (authx.Resource, error),
+) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			target, err := resource(ctx)
+			if err != nil {
+				return Error(ctx, err)
+			}
+
+			if err = authorizer.AuthorizeClaims(Context(ctx), action, target); err != nil {
+				return Error(ctx, err)
+			}
+
+			return next(ctx)
+		}
+	}
+}
+
+func extractToken(ctx echo.Context, options authOptions) string {
+	if header := ctx.Request().Header.Get(options.header); header != "" {
+		if len(header) > len(bearerPrefix) && strings.EqualFold(header[:len(bearerPrefix)], bearerPrefix) {
+			return strings.TrimSpace(header[len(bearerPrefix):])
+		}
+	}
+
+	if options.cookie != "" {
+		if cookie, err := ctx.Cookie(options.cookie); err == nil && cookie.Value != "" {
+			return cookie.Value
+		}
+	}
+
+	if options.query != "" && websocket.IsWebSocketUpgrade(ctx.Request()) {
+		return ctx.QueryParam(options.query)
+	}
+
+	return ""
+}
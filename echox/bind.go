@@ -0,0 +1,71 @@
+package echox
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/boostgo/core/httpx"
+
+	"github.com/labstack/echo/v4"
+)
+
+// BindPath binds path params to export object by "param" tags (see httpx.Bind)
+func BindPath(ctx echo.Context, export any) error {
+	names := ctx.ParamNames()
+	values := ctx.ParamValues()
+
+	params := make(url.Values, len(names))
+	for i, name := range names {
+		if i < len(values) {
+			params.Set(name, values[i])
+		}
+	}
+
+	return httpx.Bind(params, export, httpx.WithBindTag("param"))
+}
+
+// BindQuery binds query params to export object by "query" tags (see httpx.Bind)
+func BindQuery(ctx echo.Context, export any) error {
+	return httpx.Bind(ctx.QueryParams(), export, httpx.WithBindTag("query"))
+}
+
+// BindForm binds url encoded or multipart form to export object by "form" tags (see httpx.Bind).
+//
+// Multipart files are bound to *multipart.FileHeader & []*multipart.FileHeader fields
+func BindForm(ctx echo.Context, export any) error {
+	form, err := ctx.FormParams()
+	if err != nil {
+		return newParseRequestBodyError(ctx, err)
+	}
+
+	opts := []httpx.BindOption{httpx.WithBindTag("form")}
+	if isMultipartForm(ctx) {
+		multipartForm, err := ctx.MultipartForm()
+		if err != nil {
+			return newParseRequestBodyError(ctx, err)
+		}
+
+		opts = append(opts, httpx.WithBindFiles(multipartForm.File))
+	}
+
+	return httpx.Bind(form, export, opts...)
+}
+
+// BindHeaders binds request headers to export object by "header" tags (see httpx.Bind)
+func BindHeaders(ctx echo.Context, export any) error {
+	return httpx.BindHeaders(ctx.Request().Header, export)
+}
+
+// BindCookies binds request cookies to export object by "cookie" tags (see httpx.Bind)
+func BindCookies(ctx echo.Context, export any) error {
+	return httpx.BindCookies(ctx.Request().Cookies(), export)
+}
+
+func isForm(ctx echo.Context) bool {
+	contentType := ctx.Request().Header.Get(echo.HeaderContentType)
+	return strings.HasPrefix(contentType, echo.MIMEApplicationForm) || isMultipartForm(ctx)
+}
+
+func isMultipartForm(ctx echo.Context) bool {
+	return strings.HasPrefix(ctx.Request().Header.Get(echo.HeaderContentType), echo.MIMEMultipartForm)
+}
@@ -0,0 +1,551 @@
+package echox
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/boostgo/core/errorx"
+	"github.com/boostgo/core/httpx"
+	"github.com/boostgo/core/log"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	HeaderCache        = "X-Cache"
+	HeaderCacheControl = "Cache-Control"
+	HeaderETag         = "ETag"
+	HeaderIfNoneMatch  = "If-None-Match"
+	HeaderAge          = "Age"
+
+	cacheHit   = "HIT"
+	cacheMiss  = "MISS"
+	cacheStale = "STALE"
+)
+
+// defaultCacheStatuses are success & permanent statuses which are cacheable by default.
+//
+// Failures like 404 are not cached by default, because they could be temporary for API
+var defaultCacheStatuses = []int{
+	http.StatusOK,
+	http.StatusNonAuthoritativeInfo,
+	http.StatusNoContent,
+	http.StatusMultipleChoices,
+	http.StatusMovedPermanently,
+	http.StatusPermanentRedirect,
+	http.StatusGone,
+}
+
+// headers which are not stored by cache middleware, because they belong to the concrete request.
+//
+// CORS headers & Vary are set for every request by CORS middleware (by request Origin), so they are not replaced by stored ones
+var cacheSkipHeaders = []string{
+	echo.HeaderContentLength,
+	echo.HeaderSetCookie,
+	echo.HeaderAccessControlAllowOrigin,
+	echo.HeaderAccessControlAllowCredentials,
+	echo.HeaderAccessControlExposeHeaders,
+	echo.HeaderVary,
+	"Date",
+	TraceKey,
+	"X-Request-ID",
+	HeaderRateLimitLimit,
+	HeaderRateLimitRemaining,
+	HeaderRateLimitReset,
+	HeaderCache,
+	HeaderAge,
+}
+
+// CacheKeyFunc builds response cache key by request
+type CacheKeyFunc func(ctx echo.Context) string
+
+// CacheOption modifies cache middleware settings
+type CacheOption func(options *cacheOptions)
+
+type cacheOptions struct {
+	keyFunc              CacheKeyFunc
+	queryParams          []string
+	headers              []string
+	statuses             []int
+	staleWhileRevalidate time.Duration
+}
+
+// WithCacheKey sets custom cache key func. Overrides WithCacheKeyQuery & WithCacheKeyHeaders.
+//
+// Custom key func is responsible for identity of the caller (user ID in the key),
+// so responses to requests with "Authorization" or "Cookie" headers are cached as any others
+func WithCacheKey(keyFunc CacheKeyFunc) CacheOption {
+	return func(options *cacheOptions) {
+		options.keyFunc = keyFunc
+	}
+}
+
+// WithCacheKeyQuery sets query params which are used in cache key. By default, all query params are used
+func WithCacheKeyQuery(params ...string) CacheOption {
+	return func(options *cacheOptions) {
+		options.queryParams = append(options.queryParams, params...)
+	}
+}
+
+// WithCacheKeyHeaders sets request headers which are used in cache key (for example, Accept-Language).
+//
+// Headers are also added to the response "Vary" header
+func WithCacheKeyHeaders(headers ...string) CacheOption {
+	return func(options *cacheOptions) {
+		for _, header := range headers {
+			options.headers = append(options.headers, http.CanonicalHeaderKey(header))
+		}
+	}
+}
+
+// WithCacheStatuses sets response statuses which could be cached
+func WithCacheStatuses(statuses ...int) CacheOption {
+	return func(options *cacheOptions) {
+		options.statuses = statuses
+	}
+}
+
+// WithCacheStaleWhileRevalidate sets time after response expiration while stale response is returned
+// and refreshed in background
+func WithCacheStaleWhileRevalidate(duration time.Duration) CacheOption {
+	return func(options *cacheOptions) {
+		options.staleWhileRevalidate = duration
+	}
+}
+
+type cacheMiddleware struct {
+	ttl         time.Duration
+	distributor httpx.CacheDistributor
+	options     cacheOptions
+	refreshing  sync.Map
+}
+
+// CacheMiddleware caches GET & HEAD responses for "ttl" time in provided distributor.
+//
+// Responses are stored with status, headers & body. Only responses with cacheable statuses are stored,
+// responses with "Cache-Control: no-store/no-cache/private" or "Set-Cookie" header are not stored
+// ("no-cache" responses still get ETag, so they could be revalidated).
+// Response "Cache-Control: max-age/s-maxage" overrides ttl.
+//
+// Request "Cache-Control: no-store" skips cache, "no-cache" skips reading from cache.
+//
+// Responses to requests with "Authorization" or "Cookie" headers are shared between all callers,
+// so they are stored & returned only if response has "Cache-Control: public/s-maxage" (RFC 9111 section 3.5).
+// Use WithCacheKey with caller identity in the key to cache such responses per caller.
+//
+// Every response gets ETag header, requests with matched "If-None-Match" get 304 status.
+//
+// Response is buffered till handler returns, so streaming routes (SSE, StreamNDJSON) should not use cache
+func CacheMiddleware(ttl time.Duration, distributor httpx.CacheDistributor, opts ...CacheOption) echo.MiddlewareFunc {
+	middleware := &cacheMiddleware{
+		ttl:         ttl,
+		distributor: distributor,
+		options: cacheOptions{
+			statuses: defaultCacheStatuses,
+		},
+	}
+
+	for _, opt := range opts {
+		opt(&middleware.options)
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			request := ctx.Request()
+			if request.Method != http.MethodGet && request.Method != http.MethodHead {
+				return next(ctx)
+			}
+
+			requestCacheControl := parseCacheControl(request.Header.Get(HeaderCacheControl))
+			if _, ok := requestCacheControl["no-store"]; ok {
+				return next(ctx)
+			}
+
+			key := middleware.key(ctx)
+			ctx.SetRequest(httpx.SetCacheKey(request, key))
+
+			if len(middleware.options.headers) > 0 {
+				ctx.Response().Header().Add(echo.HeaderVary, strings.Join(middleware.options.headers, ", "))
+			}
+
+			if _, ok := requestCacheControl["no-cache"]; !ok {
+				if cached, ok := middleware.load(ctx); ok && middleware.shareable(request, cached.Header) {
+					switch {
+					case cached.Fresh():
+						return writeCachedResponse(ctx, cached, cacheHit)
+					case cached.Age() <= cached.MaxAge+middleware.options.staleWhileRevalidate:
+						middleware.refresh(ctx, next, key)
+						return writeCachedResponse(ctx, cached, cacheStale)
+					}
+				}
+			}
+
+			ctx.Response().Header().Set(HeaderCache, cacheMiss)
+			return middleware.execute(ctx, next)
+		}
+	}
+}
+
+// execute calls handler with buffering response, stores response and writes it to the client
+func (middleware *cacheMiddleware) execute(ctx echo.Context, next echo.HandlerFunc) error {
+	response := ctx.Response()
+	original := response.Writer
+	writer := newBufferResponseWriter(original.Header())
+	response.Writer = writer
+
+	handlerErr := next(ctx)
+	response.Writer = original
+
+	// handler returned error without writing response
+	if !writer.written {
+		return handlerErr
+	}
+
+	cached := httpx.CachedResponse{
+		Status:   writer.status,
+		Header:   cacheHeader(response.Header()),
+		Body:     writer.body.Bytes(),
+		StoredAt: time.Now(),
+		MaxAge:   middleware.ttl,
+	}
+
+	cacheable := handlerErr == nil &&
+		middleware.cacheable(cached) &&
+		middleware.shareable(ctx.Request(), cached.Header)
+	if cacheable {
+		cached.ETag = response.Header().Get(HeaderETag)
+		if cached.ETag == "" {
+			cached.ETag = newETag(cached.Body)
+			response.Header().Set(HeaderETag, cached.ETag)
+		}
+
+		if maxAge, ok := responseMaxAge(response.Header()); ok {
+			cached.MaxAge = maxAge
+		}
+
+		// "no-cache" response is always stale and must be revalidated by handler (ETag only), so it is not stored
+		if _, noCache := parseCacheControl(response.Header().Get(HeaderCacheControl))["no-cache"]; !noCache {
+			middleware.store(ctx, cached)
+		}
+	}
+
+	if cacheable && matchETag(ctx.Request().Header.Get(HeaderIfNoneMatch), cached.ETag) {
+		original.WriteHeader(http.StatusNotModified)
+		return handlerErr
+	}
+
+	original.WriteHeader(writer.status)
+	if _, err := original.Write(cached.Body); err != nil {
+		return err
+	}
+
+	return handlerErr
+}
+
+// refresh calls handler in background to update stale response. Only one refresh per key runs at the same time
+func (middleware *cacheMiddleware) refresh(ctx echo.Context, next echo.HandlerFunc, key string) {
+	if _, running := middleware.refreshing.LoadOrStore(key, struct{}{}); running {
+		return
+	}
+
+	request := ctx.Request().Clone(context.WithoutCancel(Context(ctx)))
+	request.Header.Del(HeaderIfNoneMatch)
+
+	refreshCtx := ctx.Echo().NewContext(request, newBufferResponseWriter(make(http.Header)))
+	refreshCtx.SetPath(ctx.Path())
+	refreshCtx.SetParamNames(ctx.ParamNames()...)
+	refreshCtx.SetParamValues(ctx.ParamValues()...)
+	refreshCtx.Set(serverContextKey, serverOf(ctx))
+
+	go func() {
+		defer middleware.refreshing.Delete(key)
+
+		if err := errorx.Try(func() error {
+			return middleware.execute(refreshCtx, next)
+		}); err != nil {
+			log.
+				Error().
+				Ctx(request.Context()).
+				Err(err).
+				Str("key", key).
+				Msg("Refresh stale cache")
+		}
+	}()
+}
+
+func (middleware *cacheMiddleware) key(ctx echo.Context) string {
+	if middleware.options.keyFunc != nil {
+		return middleware.options.keyFunc(ctx)
+	}
+
+	request := ctx.Request()
+
+	query := request.URL.Query()
+	if len(middleware.options.queryParams) > 0 {
+		selected := make(url.Values, len(middleware.options.queryParams))
+		for _, param := range middleware.options.queryParams {
+			if values, ok := query[param]; ok {
+				selected[param] = values
+			}
+		}
+		query = selected
+	}
+
+	builder := strings.Builder{}
+	builder.WriteString(request.Method)
+	builder.WriteString(":")
+	builder.WriteString(request.URL.Path)
+
+	if encoded := query.Encode(); encoded != "" {
+		builder.WriteString("?")
+		builder.WriteString(encoded)
+	}
+
+	for _, header := range middleware.options.headers {
+		builder.WriteString("|")
+		builder.WriteString(header)
+		builder.WriteString("=")
+		builder.WriteString(request.Header.Get(header))
+	}
+
+	return builder.String()
+}
+
+func (middleware *cacheMiddleware) load(ctx echo.Context) (httpx.CachedResponse, bool) {
+	var cached httpx.CachedResponse
+
+	blob, ok, err := middleware.distributor.Get(Context(ctx), ctx.Request())
+	if err != nil {
+		if !errors.Is(err, errorx.ErrNotFound) {
+			log.
+				Error().
+				Ctx(Context(ctx)).
+				Err(err).
+				Msg("Get cache by HTTP distributor")
+		}
+
+		return cached, false
+	}
+
+	if !ok {
+		return cached, false
+	}
+
+	// responses stored as body only (by previous versions) are treated as JSON with 200 status
+	if err = json.Unmarshal(blob, &cached); err != nil || cached.Status == 0 {
+		return httpx.CachedResponse{
+			Status: http.StatusOK,
+			Header: http.Header{
+				echo.HeaderContentType: []string{httpx.ContentTypeJSON},
+			},
+			Body:     blob,
+			ETag:     newETag(blob),
+			StoredAt: time.Now(),
+			MaxAge:   middleware.ttl,
+		}, true
+	}
+
+	return cached, true
+}
+
+func (middleware *cacheMiddleware) store(ctx echo.Context, cached httpx.CachedResponse) {
+	// zero ttl means "never expire" for some distributors (redis), so such responses are not stored
+	ttl := cached.MaxAge + middleware.options.staleWhileRevalidate
+	if ttl <= 0 {
+		return
+	}
+
+	blob, err := json.Marshal(cached)
+	if err != nil {
+		log.
+			Error().
+			Ctx(Context(ctx)).
+			Err(err).
+			Msg("Marshal cached response")
+		return
+	}
+
+	if err = middleware.distributor.Set(
+		Context(ctx),
+		ctx.Request(),
+		blob,
+		ttl,
+	); err != nil {
+		log.
+			Error().
+			Ctx(Context(ctx)).
+			Err(err).
+			Msg("Set cache by HTTP distributor")
+	}
+}
+
+func (middleware *cacheMiddleware) cacheable(cached httpx.CachedResponse) bool {
+	if !slices.Contains(middleware.options.statuses, cached.Status) {
+		return false
+	}
+
+	if cached.Header.Get(echo.HeaderSetCookie) != "" {
+		return false
+	}
+
+	cacheControl := parseCacheControl(cached.Header.Get(HeaderCacheControl))
+	_, noStore := cacheControl["no-store"]
+	_, private := cacheControl["private"]
+	return !noStore && !private
+}
+
+// shareable checks if response could be stored for the request or stored response could be returned to it.
+//
+// Responses to requests with credentials are shared only if they are explicitly marked as shared
+// or cache key is built by custom func (which must contain caller identity)
+func (middleware *cacheMiddleware) shareable(request *http.Request, header http.Header) bool {
+	if middleware.options.keyFunc != nil {
+		return true
+	}
+
+	if request.Header.Get(echo.HeaderAuthorization) == "" && request.Header.Get(echo.HeaderCookie) == "" {
+		return true
+	}
+
+	cacheControl := parseCacheControl(header.Get(HeaderCacheControl))
+	_, public := cacheControl["public"]
+	_, sharedMaxAge := cacheControl["s-maxage"]
+	return public || sharedMaxAge
+}
+
+func writeCachedResponse(ctx echo.Context, cached httpx.CachedResponse, cacheStatus string) error {
+	header := ctx.Response().Header()
+	for name, values := range cached.Header {
+		header[name] = values
+	}
+
+	header.Set(HeaderCache, cacheStatus)
+	header.Set(HeaderAge, strconv.Itoa(int(cached.Age().Seconds())))
+	if cached.ETag != "" {
+		header.Set(HeaderETag, cached.ETag)
+	}
+
+	if matchETag(ctx.Request().Header.Get(HeaderIfNoneMatch), cached.ETag) {
+		return ctx.NoContent(http.StatusNotModified)
+	}
+
+	ctx.Response().WriteHeader(cached.Status)
+	if ctx.Request().Method == http.MethodHead {
+		return nil
+	}
+
+	_, err := ctx.Response().Write(cached.Body)
+	return err
+}
+
+func cacheHeader(header http.Header) http.Header {
+	stored := header.Clone()
+	for _, name := range cacheSkipHeaders {
+		stored.Del(name)
+	}
+
+	return stored
+}
+
+// parseCacheControl parses "Cache-Control" header directives: "max-age=60, no-cache" -> {max-age: 60, no-cache: ""}
+func parseCacheControl(header string) map[string]string {
+	directives := make(map[string]string)
+	for _, directive := range strings.Split(header, ",") {
+		directive = strings.TrimSpace(directive)
+		if directive == "" {
+			continue
+		}
+
+		name, value, _ := strings.Cut(directive, "=")
+		directives[strings.ToLower(name)] = strings.Trim(value, `"`)
+	}
+
+	return directives
+}
+
+// responseMaxAge returns response freshness time by "s-maxage" or "max-age" directives
+func responseMaxAge(header http.Header) (time.Duration, bool) {
+	cacheControl := parseCacheControl(header.Get(HeaderCacheControl))
+
+	for _, directive := range []string{"s-maxage", "max-age"} {
+		value, ok := cacheControl[directive]
+		if !ok {
+			continue
+		}
+
+		seconds, err := strconv.Atoi(value)
+		if err != nil || seconds < 0 {
+			continue
+		}
+
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	return 0, false
+}
+
+func newETag(body []byte) string {
+	hash := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(hash[:16]) + `"`
+}
+
+// matchETag checks "If-None-Match" header by weak comparison
+func matchETag(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" || etag == "" {
+		return false
+	}
+
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+
+	return false
+}
+
+// bufferResponseWriter keeps response status & body in memory
+type bufferResponseWriter struct {
+	header  http.Header
+	status  int
+	body    bytes.Buffer
+	written bool
+}
+
+func newBufferResponseWriter(header http.Header) *bufferResponseWriter {
+	return &bufferResponseWriter{
+		header: header,
+		status: http.StatusOK,
+	}
+}
+
+func (writer *bufferResponseWriter) Header() http.Header {
+	return writer.header
+}
+
+func (writer *bufferResponseWriter) Write(b []byte) (int, error) {
+	writer.written = true
+	return writer.body.Write(b)
+}
+
+func (writer *bufferResponseWriter) WriteHeader(statusCode int) {
+	writer.written = true
+	writer.status = statusCode
+}
+
+// Flush does nothing: response is kept in memory and is sent after handler returns,
+// so streaming responses (SSE, NDJSON) are not streamed through cache
+func (writer *bufferResponseWriter) Flush() {}
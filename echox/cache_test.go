@@ -0,0 +1,169 @@
+package echox
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/boostgo/core/httpx"
+
+	"github.com/labstack/echo/v4"
+)
+
+// memoryCacheDistributor is httpx.CacheDistributor for tests
+type memoryCacheDistributor struct {
+	responses sync.Map
+}
+
+func (distributor *memoryCacheDistributor) Set(_ context.Context, request *http.Request, body []byte, _ time.Duration) error {
+	distributor.responses.Store(httpx.CacheKey(request), body)
+	return nil
+}
+
+func (distributor *memoryCacheDistributor) Get(_ context.Context, request *http.Request) ([]byte, bool, error) {
+	body, ok := distributor.responses.Load(httpx.CacheKey(request))
+	if !ok {
+		return nil, false, nil
+	}
+
+	return body.([]byte), true, nil
+}
+
+// newCacheTestServer returns echo with cached "/data" route which responds with calls count & provided cache control
+func newCacheTestServer(cacheControl string, opts ...CacheOption) (*echo.Echo, *int) {
+	calls := 0
+	e := echo.New()
+	e.GET("/data", func(ctx echo.Context) error {
+		calls++
+		if cacheControl != "" {
+			ctx.Response().Header().Set(HeaderCacheControl, cacheControl)
+		}
+
+		ctx.Response().Header().Set(echo.HeaderAccessControlAllowOrigin, ctx.Request().Header.Get(echo.HeaderOrigin))
+		ctx.Response().Header().Add(echo.HeaderVary, echo.HeaderOrigin)
+		return ctx.String(http.StatusOK, "data")
+	}, CacheMiddleware(time.Minute, &memoryCacheDistributor{}, opts...))
+
+	return e, &calls
+}
+
+func serveCacheTest(e *echo.Echo, header http.Header) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(http.MethodGet, "/data", nil)
+	for name, values := range header {
+		request.Header[name] = values
+	}
+
+	recorder := httptest.NewRecorder()
+	e.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestCacheMiddleware(t *testing.T) {
+	e, calls := newCacheTestServer("")
+
+	first := serveCacheTest(e, nil)
+	if first.Header().Get(HeaderCache) != cacheMiss || first.Header().Get(HeaderETag) == "" {
+		t.Fatalf("unexpected first response headers: %v", first.Header())
+	}
+
+	second := serveCacheTest(e, nil)
+	if second.Header().Get(HeaderCache) != cacheHit || second.Body.String() != "data" || *calls != 1 {
+		t.Fatalf("expected cached response, got %v (calls %d)", second.Header(), *calls)
+	}
+
+	notModified := serveCacheTest(e, http.Header{HeaderIfNoneMatch: {first.Header().Get(HeaderETag)}})
+	if notModified.Code != http.StatusNotModified {
+		t.Errorf("expected 304 status, got %d", notModified.Code)
+	}
+
+	if noCache := serveCacheTest(e, http.Header{HeaderCacheControl: {"no-cache"}}); noCache.Header().Get(HeaderCache) != cacheMiss {
+		t.Errorf("expected request no-cache to skip reading cache, got %v", noCache.Header())
+	}
+}
+
+func TestCacheMiddlewareCredentials(t *testing.T) {
+	tests := []struct {
+		name         string
+		cacheControl string
+		opts         []CacheOption
+		shared       bool
+	}{
+		{name: "private by default"},
+		{name: "max-age", cacheControl: "max-age=60"},
+		{name: "public", cacheControl: "public, max-age=60", shared: true},
+		{name: "s-maxage", cacheControl: "s-maxage=60", shared: true},
+		{
+			name: "custom key",
+			opts: []CacheOption{WithCacheKey(func(ctx echo.Context) string {
+				return ctx.Request().Header.Get(echo.HeaderAuthorization) + ":" + ctx.Request().URL.Path
+			})},
+			shared: true,
+		},
+	}
+
+	credentials := []http.Header{
+		{echo.HeaderAuthorization: {"Bearer token"}},
+		{echo.HeaderCookie: {"session=1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, header := range credentials {
+				e, calls := newCacheTestServer(tt.cacheControl, tt.opts...)
+
+				serveCacheTest(e, header)
+				second := serveCacheTest(e, header)
+
+				if cached := second.Header().Get(HeaderCache) == cacheHit; cached != tt.shared {
+					t.Errorf("%v: expected cached %v, got headers %v (calls %d)", header, tt.shared, second.Header(), *calls)
+				}
+			}
+		})
+	}
+
+	t.Run("anonymous response is not returned to caller with credentials", func(t *testing.T) {
+		e, calls := newCacheTestServer("")
+
+		serveCacheTest(e, nil)
+		response := serveCacheTest(e, http.Header{echo.HeaderAuthorization: {"Bearer token"}})
+		if response.Header().Get(HeaderCache) != cacheMiss || *calls != 2 {
+			t.Errorf("expected handler call, got headers %v (calls %d)", response.Header(), *calls)
+		}
+
+		// response to caller with credentials does not replace anonymous one
+		if response = serveCacheTest(e, nil); response.Header().Get(HeaderCache) != cacheHit {
+			t.Errorf("expected cached anonymous response, got %v", response.Header())
+		}
+	})
+}
+
+func TestCacheMiddlewareCORSHeaders(t *testing.T) {
+	e, _ := newCacheTestServer("")
+
+	serveCacheTest(e, http.Header{echo.HeaderOrigin: {"https://first.example"}})
+
+	// CORS middleware sets headers of current request before cache middleware
+	e.Pre(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			ctx.Response().Header().Set(echo.HeaderAccessControlAllowOrigin, ctx.Request().Header.Get(echo.HeaderOrigin))
+			ctx.Response().Header().Add(echo.HeaderVary, echo.HeaderOrigin)
+			return next(ctx)
+		}
+	})
+
+	response := serveCacheTest(e, http.Header{echo.HeaderOrigin: {"https://second.example"}})
+	if response.Header().Get(HeaderCache) != cacheHit {
+		t.Fatalf("expected cached response, got %v", response.Header())
+	}
+
+	if origin := response.Header().Get(echo.HeaderAccessControlAllowOrigin); origin != "https://second.example" {
+		t.Errorf("expected origin of current request, got %q", origin)
+	}
+
+	if vary := response.Header().Values(echo.HeaderVary); len(vary) != 1 {
+		t.Errorf("expected single Vary header, got %v", vary)
+	}
+}
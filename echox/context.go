@@ -52,7 +52,7 @@ func Parse(ctx echo.Context, export any) error {
 		return newParseRequestBodyError(ctx, err)
 	}
 
-	if err := validator.Get().Struct(export); err != nil {
+	if err := validator.Get().StructCtx(Context(ctx), export); err != nil {
 		return err
 	}
 
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"net/http"
 
+	"github.com/boostgo/core/errorx"
 	"github.com/boostgo/core/httpx"
 
 	"github.com/labstack/echo/v4"
@@ -16,10 +17,15 @@ type httpErrorContext struct {
 }
 
 func newParseRequestBodyError(ctx echo.Context, err error) error {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return newRequestBodyTooLargeError(maxBytesErr.Limit)
+	}
+
 	var httpErr *echo.HTTPError
 	if errors.As(err, &httpErr) {
 		return httpx.ErrParseRequestBody.
-			SetError(err).
+			SetError(errorx.ErrBadRequest, err).
 			SetData(httpErrorContext{
 				Message:     err.Error(),
 				Accept:      Header(ctx, "Accept").String(),
@@ -27,7 +33,17 @@ func newParseRequestBodyError(ctx echo.Context, err error) error {
 			})
 	}
 
-	return httpx.ErrParseRequestBody.SetError(err)
+	return httpx.ErrParseRequestBody.SetError(errorx.ErrBadRequest, err)
+}
+
+type bodyLimitContext struct {
+	Limit int64 `json:"limit"`
+}
+
+func newRequestBodyTooLargeError(limit int64) error {
+	return httpx.ErrRequestBodyTooLarge.SetData(bodyLimitContext{
+		Limit: limit,
+	})
 }
 
 type routeNotFoundContext struct {
@@ -0,0 +1,233 @@
+package echox
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+
+	"github.com/boostgo/core/contextx"
+	"github.com/boostgo/core/defaults"
+	"github.com/boostgo/core/httpx"
+	"github.com/boostgo/core/validator"
+
+	"github.com/labstack/echo/v4"
+)
+
+// echoContextKey is key of echo.Context in context provided to typed handler function
+type echoContextKey struct{}
+
+// HandlerFunc is typed handler function.
+//
+// Request object is bound from path, query, header and body, response object is returned by Success or Failure
+type HandlerFunc[Req, Resp any] func(ctx context.Context, req Req) (Resp, error)
+
+// Empty could be used as request or response type if handler has no request or response body
+type Empty struct{}
+
+// HandlerMeta contains request & response types information collected by typed handler.
+//
+// Used for documentation generation
+type HandlerMeta struct {
+	Request     reflect.Type
+	Response    reflect.Type
+	Status      int
+	Raw         bool
+	Summary     string
+	Description string
+	Tags        []string
+}
+
+// TypedHandler is handler which knows its request & response types
+type TypedHandler interface {
+	Echo() echo.HandlerFunc
+	Meta() HandlerMeta
+}
+
+// HandlerOption modifies typed handler settings
+type HandlerOption func(meta *HandlerMeta)
+
+// WithStatus sets success response status code. By default, it is 200
+func WithStatus(status int) HandlerOption {
+	return func(meta *HandlerMeta) {
+		meta.Status = status
+	}
+}
+
+// WithRaw sets "raw" response mode for the handler (no SuccessResponse wrapper)
+func WithRaw() HandlerOption {
+	return func(meta *HandlerMeta) {
+		meta.Raw = true
+	}
+}
+
+// WithSummary sets handler summary for documentation
+func WithSummary(summary string) HandlerOption {
+	return func(meta *HandlerMeta) {
+		meta.Summary = summary
+	}
+}
+
+// WithDescription sets handler description for documentation
+func WithDescription(description string) HandlerOption {
+	return func(meta *HandlerMeta) {
+		meta.Description = description
+	}
+}
+
+// WithTags sets handler tags for documentation
+func WithTags(tags ...string) HandlerOption {
+	return func(meta *HandlerMeta) {
+		meta.Tags = append(meta.Tags, tags...)
+	}
+}
+
+// Handler is typed handler created by Handle function
+type Handler[Req, Resp any] struct {
+	fn   HandlerFunc[Req, Resp]
+	meta HandlerMeta
+}
+
+// Handle creates typed handler.
+//
+// Request object binds from path params ("param" tags), query params ("query" tags), headers ("header" tags),
+// cookies ("cookie" tags) and body ("json", "xml" or "form" tags). Params & forms are bound by httpx.Bind.
+// Then sets defaults ("default" tags) and runs validation ("validate" tags).
+//
+// Returned response object converts by Success function, returned error - by Error function.
+//
+// Use Handler.Echo to get echo.HandlerFunc or register handler by Endpoint to collect its metadata
+func Handle[Req, Resp any](fn HandlerFunc[Req, Resp], opts ...HandlerOption) *Handler[Req, Resp] {
+	meta := HandlerMeta{
+		Request:  reflect.TypeOf((*Req)(nil)).Elem(),
+		Response: reflect.TypeOf((*Resp)(nil)).Elem(),
+		Status:   http.StatusOK,
+	}
+
+	for _, opt := range opts {
+		opt(&meta)
+	}
+
+	return &Handler[Req, Resp]{
+		fn:   fn,
+		meta: meta,
+	}
+}
+
+// Meta returns collected handler metadata
+func (h *Handler[Req, Resp]) Meta() HandlerMeta {
+	return h.meta
+}
+
+// Echo returns echo.HandlerFunc representation of the handler
+func (h *Handler[Req, Resp]) Echo() echo.HandlerFunc {
+	return h.handle
+}
+
+func (h *Handler[Req, Resp]) handle(ctx echo.Context) error {
+	if h.meta.Raw {
+		Set(ctx, rawResponseKey, true)
+	}
+
+	var req Req
+	if err := bind(ctx, &req); err != nil {
+		return Error(ctx, err)
+	}
+
+	native := context.WithValue(Context(ctx), echoContextKey{}, ctx)
+	resp, err := h.fn(native, req)
+	if err != nil {
+		return Error(ctx, err)
+	}
+
+	if isEmpty(resp) {
+		return Success(ctx, h.meta.Status)
+	}
+
+	return Success(ctx, h.meta.Status, resp)
+}
+
+// EchoContext returns echo.Context from context provided to typed handler function
+func EchoContext(ctx context.Context) (echo.Context, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+
+	echoCtx, ok := ctx.Value(echoContextKey{}).(echo.Context)
+	return echoCtx, ok
+}
+
+// bind binds request path params, query params, headers, cookies & body to export object,
+// then sets defaults and runs validation
+func bind(ctx echo.Context, export any) error {
+	if err := contextx.Validate(Context(ctx)); err != nil {
+		return err
+	}
+
+	binders := []func(ctx echo.Context, export any) error{
+		BindPath,
+		BindQuery,
+		BindHeaders,
+		BindCookies,
+	}
+
+	if isForm(ctx) {
+		binders = append(binders, BindForm)
+	}
+
+	// field errors of all sources are returned together
+	fields := make(validator.FieldErrors, 0)
+	for _, binder := range binders {
+		err := binder(ctx, export)
+		if err == nil {
+			continue
+		}
+
+		bindFields, ok := validator.Fields(err)
+		if !ok {
+			return err
+		}
+
+		fields = append(fields, bindFields...)
+	}
+
+	if len(fields) > 0 {
+		return httpx.ErrBindParams.SetData(fields)
+	}
+
+	if !isForm(ctx) {
+		if err := (&echo.DefaultBinder{}).BindBody(ctx, export); err != nil {
+			return newParseRequestBodyError(ctx, err)
+		}
+	}
+
+	if !isStructPointer(export) {
+		return nil
+	}
+
+	if err := defaults.Set(export); err != nil {
+		return err
+	}
+
+	return validator.Get().StructCtx(Context(ctx), export)
+}
+
+func isStructPointer(object any) bool {
+	value := reflect.ValueOf(object)
+	return value.Kind() == reflect.Ptr && value.Elem().Kind() == reflect.Struct
+}
+
+// isEmpty returns true for nil (including typed nil pointers) and Empty responses.
+//
+// Nil slices & maps are not empty, they are encoded as JSON
+func isEmpty(object any) bool {
+	if object == nil {
+		return true
+	}
+
+	if _, ok := object.(Empty); ok {
+		return true
+	}
+
+	value := reflect.ValueOf(object)
+	return value.Kind() == reflect.Ptr && value.IsNil()
+}
@@ -0,0 +1,121 @@
+package echox
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+)
+
+type testHandlerResponse struct {
+	ID int `json:"id"`
+}
+
+func serveTypedHandler(handler TypedHandler) *httptest.ResponseRecorder {
+	e := echo.New()
+	e.GET("/", handler.Echo())
+
+	recorder := httptest.NewRecorder()
+	e.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+	return recorder
+}
+
+func TestHandleEmptyResponse(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler TypedHandler
+		json    bool
+		body    string
+	}{
+		{
+			name: "empty",
+			handler: Handle(func(context.Context, Empty) (Empty, error) {
+				return Empty{}, nil
+			}),
+		},
+		{
+			name: "nil pointer",
+			handler: Handle(func(context.Context, Empty) (*testHandlerResponse, error) {
+				return nil, nil
+			}),
+		},
+		{
+			name: "nil any",
+			handler: Handle(func(context.Context, Empty) (any, error) {
+				return nil, nil
+			}),
+		},
+		{
+			name: "nil slice",
+			handler: Handle(func(context.Context, Empty) ([]testHandlerResponse, error) {
+				return nil, nil
+			}, WithRaw()),
+			json: true,
+			body: "null",
+		},
+		{
+			name: "nil map",
+			handler: Handle(func(context.Context, Empty) (map[string]int, error) {
+				return nil, nil
+			}, WithRaw()),
+			json: true,
+			body: "null",
+		},
+		{
+			name: "empty slice",
+			handler: Handle(func(context.Context, Empty) ([]testHandlerResponse, error) {
+				return []testHandlerResponse{}, nil
+			}, WithRaw()),
+			json: true,
+			body: "[]",
+		},
+		{
+			name: "struct pointer",
+			handler: Handle(func(context.Context, Empty) (*testHandlerResponse, error) {
+				return &testHandlerResponse{ID: 1}, nil
+			}, WithRaw()),
+			json: true,
+			body: `{"id":1}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := serveTypedHandler(tt.handler)
+			if recorder.Code != http.StatusOK {
+				t.Fatalf("expected status 200, got %d", recorder.Code)
+			}
+
+			isJSON := strings.HasPrefix(recorder.Header().Get(echo.HeaderContentType), echo.MIMEApplicationJSON)
+			if isJSON != tt.json {
+				t.Errorf("expected JSON %v, got content type %q", tt.json, recorder.Header().Get(echo.HeaderContentType))
+			}
+
+			if body := strings.TrimSpace(recorder.Body.String()); body != tt.body {
+				t.Errorf("expected body %q, got %q", tt.body, body)
+			}
+		})
+	}
+}
+
+func TestEchoContext(t *testing.T) {
+	if _, ok := EchoContext(context.Background()); ok {
+		t.Error("expected no echo context")
+	}
+
+	recorder := serveTypedHandler(Handle(func(ctx context.Context, _ Empty) (string, error) {
+		echoCtx, ok := EchoContext(ctx)
+		if !ok {
+			return "", nil
+		}
+
+		return echoCtx.Request().Method, nil
+	}))
+
+	if recorder.Body.String() != http.MethodGet {
+		t.Errorf("expected echo context in handler context, got %q", recorder.Body.String())
+	}
+}
@@ -0,0 +1,307 @@
+package echox
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/boostgo/core/authx"
+	"github.com/boostgo/core/httpx"
+	"github.com/boostgo/core/log"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	HeaderIdempotencyKey      = "Idempotency-Key"
+	HeaderIdempotentReplayed  = "Idempotent-Replayed"
+	defaultIdempotencyPrefix  = "idempotency:"
+	defaultIdempotencyTTL     = time.Hour * 24
+	defaultIdempotencyLockTTL = time.Minute
+)
+
+// headers which are not stored by idempotency middleware, because they belong to the concrete request
+var idempotencySkipHeaders = []string{
+	echo.HeaderContentLength,
+	"Date",
+	TraceKey,
+	"X-Request-ID",
+	HeaderRateLimitLimit,
+	HeaderRateLimitRemaining,
+	HeaderRateLimitReset,
+}
+
+// IdempotencyRecord is stored state of the request with idempotency key.
+//
+// Record is not completed while the request is in progress
+type IdempotencyRecord struct {
+	BodyHash  string      `json:"body_hash"`
+	Completed bool        `json:"completed"`
+	Status    int         `json:"status,omitempty"`
+	Header    http.Header `json:"header,omitempty"`
+	Body      []byte      `json:"body,omitempty"`
+}
+
+// IdempotencyStore keeps idempotency records by keys
+type IdempotencyStore interface {
+	// Lock saves record only if key does not exist. Returns false if key already exists
+	Lock(ctx context.Context, key string, record IdempotencyRecord, ttl time.Duration) (bool, error)
+	// Get returns record by key. Returns false if key does not exist
+	Get(ctx context.Context, key string) (IdempotencyRecord, bool, error)
+	// Save saves record by key
+	Save(ctx context.Context, key string, record IdempotencyRecord, ttl time.Duration) error
+	// Unlock removes record by key
+	Unlock(ctx context.Context, key string) error
+}
+
+// IdempotencyKeyFunc returns scope of the idempotency key, so equal keys of different callers or routes do not collide
+type IdempotencyKeyFunc func(ctx echo.Context) string
+
+// IdempotencyOption modifies idempotency middleware settings
+type IdempotencyOption func(options *idempotencyOptions)
+
+type idempotencyOptions struct {
+	store    IdempotencyStore
+	ttl      time.Duration
+	lockTTL  time.Duration
+	prefix   string
+	required bool
+	keyFunc  IdempotencyKeyFunc
+}
+
+// WithIdempotencyStore sets records store. By default, in-memory store is used
+func WithIdempotencyStore(store IdempotencyStore) IdempotencyOption {
+	return func(options *idempotencyOptions) {
+		if store != nil {
+			options.store = store
+		}
+	}
+}
+
+// WithIdempotencyTTL sets how long completed responses are stored. By default, it is 24 hours
+func WithIdempotencyTTL(ttl time.Duration) IdempotencyOption {
+	return func(options *idempotencyOptions) {
+		if ttl > 0 {
+			options.ttl = ttl
+		}
+	}
+}
+
+// WithIdempotencyLockTTL sets how long key is locked by the request in progress. By default, it is 1 minute
+func WithIdempotencyLockTTL(ttl time.Duration) IdempotencyOption {
+	return func(options *idempotencyOptions) {
+		if ttl > 0 {
+			options.lockTTL = ttl
+		}
+	}
+}
+
+// WithIdempotencyPrefix sets prefix of all store keys
+func WithIdempotencyPrefix(prefix string) IdempotencyOption {
+	return func(options *idempotencyOptions) {
+		options.prefix = prefix
+	}
+}
+
+// WithIdempotencyKeyFunc sets scope of idempotency keys.
+//
+// By default, keys are scoped by caller (subject of authx claims or client IP) and route (method & path template)
+func WithIdempotencyKeyFunc(keyFunc IdempotencyKeyFunc) IdempotencyOption {
+	return func(options *idempotencyOptions) {
+		if keyFunc != nil {
+			options.keyFunc = keyFunc
+		}
+	}
+}
+
+// WithIdempotencyRequired rejects requests without Idempotency-Key header
+func WithIdempotencyRequired() IdempotencyOption {
+	return func(options *idempotencyOptions) {
+		options.required = true
+	}
+}
+
+// IdempotencyMiddleware makes requests with "Idempotency-Key" header idempotent.
+//
+// The first request locks the key, then its response (status, headers & body) is stored.
+// Retries with the same key get stored response with "Idempotent-Replayed: true" header.
+// Keys are scoped by caller & route (see WithIdempotencyKeyFunc).
+//
+// Returns error based on errorx.ErrConflict if the key is used with another request body
+// or the first request is still in progress.
+//
+// Responses with 5xx status are not stored, so such requests could be retried.
+//
+// GET, HEAD & OPTIONS requests are skipped
+func IdempotencyMiddleware(opts ...IdempotencyOption) echo.MiddlewareFunc {
+	options := idempotencyOptions{
+		ttl:     defaultIdempotencyTTL,
+		lockTTL: defaultIdempotencyLockTTL,
+		prefix:  defaultIdempotencyPrefix,
+		keyFunc: idempotencyScope,
+	}
+
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	if options.store == nil {
+		options.store = NewMemoryIdempotencyStore(0)
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			switch ctx.Request().Method {
+			case http.MethodGet, http.MethodHead, http.MethodOptions:
+				return next(ctx)
+			}
+
+			idempotencyKey := ctx.Request().Header.Get(HeaderIdempotencyKey)
+			if idempotencyKey == "" {
+				if options.required {
+					return Error(ctx, httpx.ErrIdempotencyKeyRequired)
+				}
+
+				return next(ctx)
+			}
+
+			bodyHash, err := hashRequest(ctx)
+			if err != nil {
+				return Error(ctx, newParseRequestBodyError(ctx, err))
+			}
+
+			key := options.prefix + options.keyFunc(ctx) + ":" + idempotencyKey
+
+			locked, err := options.store.Lock(Context(ctx), key, IdempotencyRecord{
+				BodyHash: bodyHash,
+			}, options.lockTTL)
+			if err != nil {
+				return Error(ctx, err)
+			}
+
+			if !locked {
+				return replayIdempotent(ctx, options.store, key, bodyHash)
+			}
+
+			// call handler with capturing response
+			response := ctx.Response()
+			var responseBuffer bytes.Buffer
+			originalWriter := response.Writer
+			response.Writer = httpx.NewCacheResponseWriter(originalWriter, io.MultiWriter(&responseBuffer, originalWriter))
+			defer func() {
+				response.Writer = originalWriter
+			}()
+
+			handlerErr := next(ctx)
+
+			// failed requests could be retried
+			if handlerErr != nil || response.Status >= http.StatusInternalServerError {
+				if err = options.store.Unlock(Context(ctx), key); err != nil {
+					log.
+						Error().
+						Ctx(Context(ctx)).
+						Err(err).
+						Str("key", idempotencyKey).
+						Msg("Unlock idempotency key")
+				}
+
+				return handlerErr
+			}
+
+			if err = options.store.Save(Context(ctx), key, IdempotencyRecord{
+				BodyHash:  bodyHash,
+				Completed: true,
+				Status:    response.Status,
+				Header:    idempotencyHeader(response.Header()),
+				Body:      responseBuffer.Bytes(),
+			}, options.ttl); err != nil {
+				log.
+					Error().
+					Ctx(Context(ctx)).
+					Err(err).
+					Str("key", idempotencyKey).
+					Msg("Save idempotency record")
+			}
+
+			return nil
+		}
+	}
+}
+
+func replayIdempotent(ctx echo.Context, store IdempotencyStore, key, bodyHash string) error {
+	record, ok, err := store.Get(Context(ctx), key)
+	if err != nil {
+		return Error(ctx, err)
+	}
+
+	// record expired between lock & get
+	if !ok {
+		return Error(ctx, httpx.ErrIdempotencyInProgress)
+	}
+
+	if record.BodyHash != bodyHash {
+		return Error(ctx, httpx.ErrIdempotencyKeyReused)
+	}
+
+	if !record.Completed {
+		return Error(ctx, httpx.ErrIdempotencyInProgress)
+	}
+
+	header := ctx.Response().Header()
+	for name, values := range record.Header {
+		header[name] = values
+	}
+	header.Set(HeaderIdempotentReplayed, strconv.FormatBool(true))
+
+	ctx.Response().WriteHeader(record.Status)
+	_, err = ctx.Response().Write(record.Body)
+	return err
+}
+
+// idempotencyScope returns caller (claims subject or client IP) & route of the request
+func idempotencyScope(ctx echo.Context) string {
+	caller := "ip:" + ctx.RealIP()
+	if claims, ok := authx.ClaimsAny(Context(ctx)); ok {
+		if subject := authx.NewSubject(claims); subject.ID != "" {
+			caller = "sub:" + subject.ID
+		}
+	}
+
+	return caller + "|route:" + ctx.Request().Method + ":" + ctx.Path()
+}
+
+// hashRequest returns hash of request method, path & body. Request body is restored after reading
+func hashRequest(ctx echo.Context) (string, error) {
+	request := ctx.Request()
+
+	var body []byte
+	if request.Body != nil {
+		var err error
+		body, err = io.ReadAll(request.Body)
+		if err != nil {
+			return "", err
+		}
+
+		request.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	hash := sha256.New()
+	hash.Write([]byte(request.Method))
+	hash.Write([]byte(request.URL.Path))
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func idempotencyHeader(header http.Header) http.Header {
+	stored := header.Clone()
+	for _, name := range idempotencySkipHeaders {
+		stored.Del(name)
+	}
+
+	return stored
+}
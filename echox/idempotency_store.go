@@ -0,0 +1,148 @@
+package echox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/boostgo/core/errorx"
+	"github.com/boostgo/core/orderedmap"
+	"github.com/boostgo/core/redis"
+)
+
+const defaultIdempotencyStoreCapacity = 100_000
+
+type idempotencyEntry struct {
+	record    IdempotencyRecord
+	expiresAt time.Time
+}
+
+type memoryIdempotencyStore struct {
+	records *orderedmap.OrderedMap[string, idempotencyEntry]
+	mx      sync.Mutex
+}
+
+// NewMemoryIdempotencyStore creates in-memory idempotency store.
+//
+// Store keeps up to "capacity" keys, the oldest keys are evicted. Zero capacity means 100000 keys
+func NewMemoryIdempotencyStore(capacity int) IdempotencyStore {
+	if capacity <= 0 {
+		capacity = defaultIdempotencyStoreCapacity
+	}
+
+	return &memoryIdempotencyStore{
+		records: orderedmap.NewOrderedMap[string, idempotencyEntry](
+			orderedmap.WithCapacity[string, idempotencyEntry](capacity),
+		),
+	}
+}
+
+func (store *memoryIdempotencyStore) Lock(_ context.Context, key string, record IdempotencyRecord, ttl time.Duration) (bool, error) {
+	store.mx.Lock()
+	defer store.mx.Unlock()
+
+	if _, ok := store.get(key); ok {
+		return false, nil
+	}
+
+	store.records.Set(key, idempotencyEntry{
+		record:    record,
+		expiresAt: time.Now().Add(ttl),
+	})
+	return true, nil
+}
+
+func (store *memoryIdempotencyStore) Get(_ context.Context, key string) (IdempotencyRecord, bool, error) {
+	store.mx.Lock()
+	defer store.mx.Unlock()
+
+	record, ok := store.get(key)
+	return record, ok, nil
+}
+
+func (store *memoryIdempotencyStore) Save(_ context.Context, key string, record IdempotencyRecord, ttl time.Duration) error {
+	store.mx.Lock()
+	defer store.mx.Unlock()
+
+	store.records.Set(key, idempotencyEntry{
+		record:    record,
+		expiresAt: time.Now().Add(ttl),
+	})
+	return nil
+}
+
+func (store *memoryIdempotencyStore) Unlock(_ context.Context, key string) error {
+	store.mx.Lock()
+	defer store.mx.Unlock()
+
+	store.records.Delete(key)
+	return nil
+}
+
+func (store *memoryIdempotencyStore) get(key string) (IdempotencyRecord, bool) {
+	entry, ok := store.records.Get(key)
+	if !ok {
+		return IdempotencyRecord{}, false
+	}
+
+	if time.Now().After(entry.expiresAt) {
+		store.records.Delete(key)
+		return IdempotencyRecord{}, false
+	}
+
+	return entry.record, true
+}
+
+type redisIdempotencyStore struct {
+	client redis.Client
+}
+
+// NewRedisIdempotencyStore creates distributed idempotency store. Keys are locked by SETNX
+func NewRedisIdempotencyStore(client redis.Client) IdempotencyStore {
+	return &redisIdempotencyStore{
+		client: client,
+	}
+}
+
+func (store *redisIdempotencyStore) Lock(ctx context.Context, key string, record IdempotencyRecord, ttl time.Duration) (bool, error) {
+	blob, err := json.Marshal(record)
+	if err != nil {
+		return false, err
+	}
+
+	return store.client.SetNX(ctx, key, blob, ttl)
+}
+
+func (store *redisIdempotencyStore) Get(ctx context.Context, key string) (IdempotencyRecord, bool, error) {
+	var record IdempotencyRecord
+
+	blob, err := store.client.GetBytes(ctx, key)
+	if err != nil {
+		if errors.Is(err, errorx.ErrNotFound) {
+			return record, false, nil
+		}
+
+		return record, false, err
+	}
+
+	if err = json.Unmarshal(blob, &record); err != nil {
+		return record, false, err
+	}
+
+	return record, true, nil
+}
+
+func (store *redisIdempotencyStore) Save(ctx context.Context, key string, record IdempotencyRecord, ttl time.Duration) error {
+	blob, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	return store.client.Set(ctx, key, blob, ttl)
+}
+
+func (store *redisIdempotencyStore) Unlock(ctx context.Context, key string) error {
+	return store.client.Delete(ctx, key)
+}
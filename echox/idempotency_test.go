@@ -0,0 +1,97 @@
+package echox
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/boostgo/core/authx"
+
+	"github.com/labstack/echo/v4"
+)
+
+// newIdempotencyTestServer returns echo with idempotent "/orders" & "/payments" routes.
+// Caller is set by "X-User" header, responses contain handler calls count
+func newIdempotencyTestServer(opts ...IdempotencyOption) *echo.Echo {
+	calls := 0
+	handler := func(ctx echo.Context) error {
+		calls++
+		return ctx.String(http.StatusCreated, strconv.Itoa(calls))
+	}
+
+	e := echo.New()
+	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			if user := ctx.Request().Header.Get("X-User"); user != "" {
+				SetContext(ctx, authx.SetClaims(Context(ctx), map[string]any{"sub": user}))
+			}
+
+			return next(ctx)
+		}
+	})
+	e.Use(IdempotencyMiddleware(opts...))
+	e.POST("/orders", handler)
+	e.POST("/payments", handler)
+	return e
+}
+
+func serveIdempotencyTest(e *echo.Echo, path, user, key string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(http.MethodPost, path, strings.NewReader(`{"amount":1}`))
+	request.Header.Set(HeaderIdempotencyKey, key)
+	if user != "" {
+		request.Header.Set("X-User", user)
+	}
+
+	recorder := httptest.NewRecorder()
+	e.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestIdempotencyMiddlewareScope(t *testing.T) {
+	e := newIdempotencyTestServer()
+
+	first := serveIdempotencyTest(e, "/orders", "alice", "key")
+	if first.Code != http.StatusCreated || first.Body.String() != "1" {
+		t.Fatalf("unexpected first response: %d %q", first.Code, first.Body.String())
+	}
+
+	replayed := serveIdempotencyTest(e, "/orders", "alice", "key")
+	if replayed.Body.String() != "1" || replayed.Header().Get(HeaderIdempotentReplayed) != "true" {
+		t.Fatalf("expected replayed response, got %q (%v)", replayed.Body.String(), replayed.Header())
+	}
+
+	tests := []struct {
+		name     string
+		path     string
+		user     string
+		expected string
+	}{
+		{name: "other caller", path: "/orders", user: "bob", expected: "2"},
+		{name: "other route", path: "/payments", user: "alice", expected: "3"},
+		{name: "anonymous caller", path: "/orders", expected: "4"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := serveIdempotencyTest(e, tt.path, tt.user, "key")
+			if response.Body.String() != tt.expected || response.Header().Get(HeaderIdempotentReplayed) != "" {
+				t.Errorf("expected new response %q, got %q (%v)", tt.expected, response.Body.String(), response.Header())
+			}
+		})
+	}
+}
+
+func TestIdempotencyMiddlewareKeyFunc(t *testing.T) {
+	// keys are shared by all callers
+	e := newIdempotencyTestServer(WithIdempotencyKeyFunc(func(ctx echo.Context) string {
+		return "global"
+	}))
+
+	serveIdempotencyTest(e, "/orders", "alice", "key")
+	response := serveIdempotencyTest(e, "/orders", "bob", "key")
+	if response.Body.String() != "1" || response.Header().Get(HeaderIdempotentReplayed) != "true" {
+		t.Errorf("expected replayed response, got %q (%v)", response.Body.String(), response.Header())
+	}
+}
@@ -1,16 +1,12 @@
 package echox
 
 import (
-	"bytes"
 	"context"
-	"errors"
-	"io"
 	"net/http"
 	"time"
 
 	"github.com/boostgo/core/convert"
 	"github.com/boostgo/core/errorx"
-	"github.com/boostgo/core/httpx"
 	"github.com/boostgo/core/log"
 
 	"github.com/labstack/echo/v4"
@@ -20,16 +16,8 @@ const (
 	rawResponseKey = "response-raw"
 )
 
-var (
-	_middlewares = make([]echo.MiddlewareFunc, 0)
-)
-
 func RegisterMiddleware(mid echo.MiddlewareFunc) {
-	if mid == nil {
-		return
-	}
-
-	_middlewares = append(_middlewares, mid)
+	_server.Use(mid)
 }
 
 func RecoverMiddleware() echo.MiddlewareFunc {
@@ -74,62 +62,38 @@ func TimeoutMiddleware(duration time.Duration) echo.MiddlewareFunc {
 	}
 }
 
-// RawMiddleware if middleware set, all responses by this middleware will be returned in "raw" way (no successOutput object)
-func RawMiddleware() echo.MiddlewareFunc {
-	return func(next echo.HandlerFunc) echo.HandlerFunc {
-		return func(ctx echo.Context) error {
-			localCtx := Context(ctx)
-			localCtx = context.WithValue(localCtx, rawResponseKey, true)
-			ctx.SetRequest(ctx.Request().WithContext(localCtx))
-			return next(ctx)
-		}
-	}
-}
-
-func CacheMiddleware(ttl time.Duration, distributor httpx.CacheDistributor) echo.MiddlewareFunc {
+// BodyLimitMiddleware rejects requests with body bigger than provided limit (in bytes).
+//
+// Requests with known Content-Length are rejected before reading, other bodies are limited while reading
+func BodyLimitMiddleware(limit int64) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(ctx echo.Context) error {
-			// try load response from cache
-			responseBody, cacheOk, err := distributor.Get(Context(ctx), ctx.Request())
-			if err != nil {
-				cacheOk = false
-
-				if !errors.Is(err, errorx.ErrNotFound) {
-					log.
-						Error().
-						Ctx(Context(ctx)).
-						Err(err).
-						Msg("Get cache by HTTP distributor")
-				}
+			if limit <= 0 {
+				return next(ctx)
 			}
 
-			// return cached response
-			if cacheOk {
-				return SuccessRaw(ctx, http.StatusOK, responseBody, httpx.ContentTypeJSON)
+			request := ctx.Request()
+			if request.ContentLength > limit {
+				return Error(ctx, newRequestBodyTooLargeError(limit))
 			}
 
-			// call handler method to generate response
-			response := ctx.Response()
-			var responseBuffer bytes.Buffer
-			mw := io.MultiWriter(&responseBuffer, response.Writer)
-			response.Writer = httpx.NewCacheResponseWriter(response.Writer, mw)
-
-			if err = next(ctx); err != nil {
-				return err
+			if request.Body != nil && request.Body != http.NoBody {
+				request.Body = http.MaxBytesReader(ctx.Response(), request.Body, limit)
 			}
 
-			responseBody = responseBuffer.Bytes()
-
-			// set response to cache
-			if err = distributor.Set(Context(ctx), ctx.Request(), responseBody, ttl); err != nil {
-				log.
-					Error().
-					Ctx(Context(ctx)).
-					Err(err).
-					Msg("Set cache by HTTP distributor")
-			}
+			return next(ctx)
+		}
+	}
+}
 
-			return nil
+// RawMiddleware if middleware set, all responses by this middleware will be returned in "raw" way (no successOutput object)
+func RawMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			localCtx := Context(ctx)
+			localCtx = context.WithValue(localCtx, rawResponseKey, true)
+			ctx.SetRequest(ctx.Request().WithContext(localCtx))
+			return next(ctx)
 		}
 	}
 }
@@ -0,0 +1,379 @@
+package echox
+
+import (
+	_ "embed"
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/boostgo/core/httpx"
+
+	"github.com/labstack/echo/v4"
+	swaggerFiles "github.com/swaggo/files/v2"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	openAPIVersion = "3.1.0"
+
+	openAPIJsonPath = "/openapi.json"
+	openAPIYamlPath = "/openapi.yaml"
+	openAPIAssets   = "/assets"
+)
+
+//go:embed openapi.html
+var openAPIPage string
+
+var openAPIPageTemplate = template.Must(template.New("openapi").Parse(openAPIPage))
+
+// OpenAPIInfo contains general API information of OpenAPI document
+type OpenAPIInfo struct {
+	Title       string   `json:"title" yaml:"title" default:"API"`
+	Version     string   `json:"version" yaml:"version" default:"1.0.0"`
+	Description string   `json:"description" yaml:"description"`
+	Servers     []string `json:"servers" yaml:"servers"`
+}
+
+// OpenAPIDocument is OpenAPI 3.1 document
+type OpenAPIDocument struct {
+	OpenAPI    string                           `json:"openapi"`
+	Info       OpenAPIDocumentInfo              `json:"info"`
+	Servers    []OpenAPIServer                  `json:"servers,omitempty"`
+	Paths      map[string]map[string]*Operation `json:"paths"`
+	Components OpenAPIComponents                `json:"components"`
+}
+
+type OpenAPIDocumentInfo struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+type OpenAPIServer struct {
+	URL string `json:"url"`
+}
+
+type OpenAPIComponents struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+// Operation is OpenAPI operation object
+type Operation struct {
+	OperationID string               `json:"operationId"`
+	Summary     string               `json:"summary,omitempty"`
+	Description string               `json:"description,omitempty"`
+	Tags        []string             `json:"tags,omitempty"`
+	Parameters  []*Parameter         `json:"parameters,omitempty"`
+	RequestBody *RequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*Response `json:"responses"`
+}
+
+// Parameter is OpenAPI parameter object
+type Parameter struct {
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required,omitempty"`
+	Schema   *Schema `json:"schema"`
+}
+
+// RequestBody is OpenAPI request body object
+type RequestBody struct {
+	Required bool                  `json:"required"`
+	Content  map[string]*MediaType `json:"content"`
+}
+
+// Response is OpenAPI response object
+type Response struct {
+	Description string                `json:"description"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+// MediaType is OpenAPI media type object
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// OpenAPI registers OpenAPI document routes to the default server.
+//
+// See Server.OpenAPI
+func OpenAPI(path string, info OpenAPIInfo) {
+	_server.OpenAPI(path, info)
+}
+
+// OpenAPI registers OpenAPI document routes:
+//
+//	path - UI page
+//	path + "/openapi.json" - document in JSON
+//	path + "/openapi.yaml" - document in YAML
+//	path + "/assets/*" - Swagger UI files (bundled, no CDN is used)
+//
+// Document is built from routes registered by Endpoint (typed handlers) on the first request
+func (s *Server) OpenAPI(path string, info OpenAPIInfo) {
+	path = strings.TrimSuffix(path, "/")
+
+	var (
+		once     sync.Once
+		jsonBlob []byte
+		yamlBlob []byte
+		buildErr error
+	)
+
+	build := func() error {
+		once.Do(func() {
+			jsonBlob, buildErr = json.Marshal(s.OpenAPIDocument(info))
+			if buildErr != nil {
+				return
+			}
+
+			yamlBlob, buildErr = jsonToYAML(jsonBlob)
+		})
+
+		return buildErr
+	}
+
+	s.GET(path+openAPIJsonPath, func(ctx echo.Context) error {
+		if err := build(); err != nil {
+			return Error(ctx, err)
+		}
+
+		return SuccessRaw(ctx, http.StatusOK, jsonBlob, httpx.ContentTypeJSON)
+	})
+
+	s.GET(path+openAPIYamlPath, func(ctx echo.Context) error {
+		if err := build(); err != nil {
+			return Error(ctx, err)
+		}
+
+		return SuccessRaw(ctx, http.StatusOK, yamlBlob, "application/yaml")
+	})
+
+	page := func(ctx echo.Context) error {
+		ctx.Response().Header().Set(echo.HeaderContentType, echo.MIMETextHTMLCharsetUTF8)
+		ctx.Response().WriteHeader(http.StatusOK)
+		return openAPIPageTemplate.Execute(ctx.Response(), map[string]string{
+			"Title":  info.Title,
+			"URL":    path + openAPIJsonPath,
+			"Assets": path + openAPIAssets,
+		})
+	}
+
+	assets := http.StripPrefix(path+openAPIAssets, http.FileServer(http.FS(swaggerFiles.FS)))
+	s.GET(path+openAPIAssets+"/*", echo.WrapHandler(assets))
+
+	s.GET(path, page)
+	s.GET(path+"/", page)
+}
+
+// OpenAPIDocument builds OpenAPI document from routes registered by Endpoint (typed handlers)
+func (s *Server) OpenAPIDocument(info OpenAPIInfo) *OpenAPIDocument {
+	registry := newSchemaRegistry()
+
+	// common schemas
+	registry.Schema(reflect.TypeOf(httpx.SuccessResponse{}))
+	registry.Schema(reflect.TypeOf(httpx.FailureResponse{}))
+	registry.Register(reflect.TypeOf(PageParams{}), registry.ParametersSchema(reflect.TypeOf(PageParams{}), tagQuery))
+	registry.Register(reflect.TypeOf(SortByParams{}), registry.ParametersSchema(reflect.TypeOf(SortByParams{}), tagQuery))
+	registry.Register(reflect.TypeOf(FilterParams{}), registry.ParametersSchema(reflect.TypeOf(FilterParams{}), tagQuery))
+	registry.Register(reflect.TypeOf(CursorParams{}), registry.ParametersSchema(reflect.TypeOf(CursorParams{}), tagQuery))
+
+	document := &OpenAPIDocument{
+		OpenAPI: openAPIVersion,
+		Info: OpenAPIDocumentInfo{
+			Title:       info.Title,
+			Version:     info.Version,
+			Description: info.Description,
+		},
+		Paths: make(map[string]map[string]*Operation),
+	}
+
+	if document.Info.Title == "" {
+		document.Info.Title = "API"
+	}
+
+	if document.Info.Version == "" {
+		document.Info.Version = "1.0.0"
+	}
+
+	for _, server := range info.Servers {
+		document.Servers = append(document.Servers, OpenAPIServer{URL: server})
+	}
+
+	addRoute := func(basePath string, r route) {
+		if r.Meta == nil {
+			return
+		}
+
+		path := openAPIPath(basePath + r.Path)
+		if _, ok := document.Paths[path]; !ok {
+			document.Paths[path] = make(map[string]*Operation)
+		}
+
+		document.Paths[path][strings.ToLower(r.Method)] = newOperation(registry, r.Method, basePath+r.Path, *r.Meta)
+	}
+
+	for _, r := range s.routes {
+		addRoute("", r)
+	}
+
+	for _, g := range s.groups {
+		for _, r := range g.routes {
+			addRoute(g.basePath, r)
+		}
+	}
+
+	document.Components.Schemas = registry.schemas
+	return document
+}
+
+func newOperation(registry *schemaRegistry, method, path string, meta HandlerMeta) *Operation {
+	operation := &Operation{
+		OperationID: operationID(method, path),
+		Summary:     meta.Summary,
+		Description: meta.Description,
+		Tags:        meta.Tags,
+		Parameters:  registry.Parameters(meta.Request),
+		Responses:   make(map[string]*Response),
+	}
+
+	// path variables which are not described by request type
+	for _, segment := range strings.Split(path, "/") {
+		if !strings.HasPrefix(segment, ":") {
+			continue
+		}
+
+		name := strings.TrimPrefix(segment, ":")
+		described := false
+		for _, parameter := range operation.Parameters {
+			if parameter.In == "path" && parameter.Name == name {
+				described = true
+				break
+			}
+		}
+
+		if !described {
+			operation.Parameters = append(operation.Parameters, &Parameter{
+				Name:     name,
+				In:       "path",
+				Required: true,
+				Schema:   &Schema{Type: "string"},
+			})
+		}
+	}
+
+	if method != http.MethodGet && method != http.MethodHead && registry.HasBody(meta.Request) {
+		operation.RequestBody = &RequestBody{
+			Required: true,
+			Content: map[string]*MediaType{
+				httpx.ContentTypeJSON: {Schema: registry.Schema(meta.Request)},
+			},
+		}
+	}
+
+	operation.Responses[strconv.Itoa(meta.Status)] = newSuccessResponse(registry, meta)
+	operation.Responses["default"] = &Response{
+		Description: "Failure",
+		Content: map[string]*MediaType{
+			httpx.ContentTypeJSON: {Schema: registry.Schema(reflect.TypeOf(httpx.FailureResponse{}))},
+		},
+	}
+
+	return operation
+}
+
+func newSuccessResponse(registry *schemaRegistry, meta HandlerMeta) *Response {
+	response := &Response{
+		Description: http.StatusText(meta.Status),
+	}
+
+	responseType := meta.Response
+	if responseType == reflect.TypeOf(Empty{}) {
+		return response
+	}
+
+	for responseType.Kind() == reflect.Ptr {
+		responseType = responseType.Elem()
+	}
+
+	// primitives are returned as plain text
+	if responseType.Kind() != reflect.Interface && isPrimitive(reflect.Zero(responseType).Interface()) {
+		response.Content = map[string]*MediaType{
+			httpx.ContentTypeText: {Schema: registry.Schema(responseType)},
+		}
+		return response
+	}
+
+	schema := registry.Schema(responseType)
+	if !meta.Raw {
+		schema = &Schema{
+			AllOf: []*Schema{
+				registry.Schema(reflect.TypeOf(httpx.SuccessResponse{})),
+				{
+					Type: "object",
+					Properties: map[string]*Schema{
+						"body": schema,
+					},
+				},
+			},
+		}
+	}
+
+	response.Content = map[string]*MediaType{
+		httpx.ContentTypeJSON: {Schema: schema},
+	}
+	return response
+}
+
+// openAPIPath converts echo path to OpenAPI path: "/users/:id" -> "/users/{id}"
+func openAPIPath(path string) string {
+	segments := strings.Split(path, "/")
+	for idx, segment := range segments {
+		switch {
+		case strings.HasPrefix(segment, ":"):
+			segments[idx] = "{" + strings.TrimPrefix(segment, ":") + "}"
+		case segment == "*":
+			segments[idx] = "{wildcard}"
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
+
+// operationID builds operation id by method & path: "GET", "/users/:id" -> "get_users_id"
+func operationID(method, path string) string {
+	builder := strings.Builder{}
+	builder.WriteString(strings.ToLower(method))
+
+	for _, segment := range strings.Split(path, "/") {
+		segment = strings.Trim(segment, ":*{}")
+		if segment == "" {
+			continue
+		}
+
+		builder.WriteString("_")
+		builder.WriteString(segment)
+	}
+
+	return builder.String()
+}
+
+// jsonToYAML converts JSON document to YAML with keeping keys order
+func jsonToYAML(blob []byte) ([]byte, error) {
+	var node yaml.Node
+	if err := yaml.Unmarshal(blob, &node); err != nil {
+		return nil, err
+	}
+
+	resetYAMLStyle(&node)
+	return yaml.Marshal(&node)
+}
+
+func resetYAMLStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		resetYAMLStyle(child)
+	}
+}
@@ -0,0 +1,573 @@
+package echox
+
+import (
+	"encoding"
+	"encoding/json"
+	"mime/multipart"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Schema is OpenAPI schema object
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	AllOf                []*Schema          `json:"allOf,omitempty"`
+	Enum                 []any              `json:"enum,omitempty"`
+	Default              any                `json:"default,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	ExclusiveMinimum     *float64           `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum     *float64           `json:"exclusiveMaximum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+}
+
+const (
+	schemaRefPrefix = "#/components/schemas/"
+
+	tagParam  = "param"
+	tagQuery  = "query"
+	tagHeader = "header"
+	tagCookie = "cookie"
+)
+
+var (
+	timeType            = reflect.TypeOf(time.Time{})
+	uuidType            = reflect.TypeOf(uuid.UUID{})
+	rawMessageType      = reflect.TypeOf(json.RawMessage{})
+	fileHeaderType      = reflect.TypeOf(multipart.FileHeader{})
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	jsonMarshalerType   = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	schemaPackagePath   = regexp.MustCompile(`[\w.\-]+/`)
+	schemaNameForbidden = regexp.MustCompile(`[^a-zA-Z0-9.\-_]+`)
+)
+
+// parameterLocations is mapping between binding tags & OpenAPI parameter locations
+var parameterLocations = map[string]string{
+	tagParam:  "path",
+	tagQuery:  "query",
+	tagHeader: "header",
+	tagCookie: "cookie",
+}
+
+// schemaRegistry builds schemas from Go types and collects named schemas as components
+type schemaRegistry struct {
+	schemas map[string]*Schema
+	types   map[string]reflect.Type
+}
+
+func newSchemaRegistry() *schemaRegistry {
+	return &schemaRegistry{
+		schemas: make(map[string]*Schema),
+		types:   make(map[string]reflect.Type),
+	}
+}
+
+// Schema returns schema of provided type. Named struct types are registered as components and returned as reference
+func (r *schemaRegistry) Schema(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if schema, ok := knownTypeSchema(t); ok {
+		return schema
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		if t.Name() == "" {
+			return r.structSchema(t)
+		}
+
+		name := schemaName(t)
+		if _, ok := r.types[name]; !ok {
+			r.types[name] = t
+			// placeholder protects from infinite recursion on recursive types
+			r.schemas[name] = &Schema{}
+			*r.schemas[name] = *r.structSchema(t)
+		}
+
+		return &Schema{Ref: schemaRefPrefix + name}
+	case reflect.Slice, reflect.Array:
+		return &Schema{
+			Type:  "array",
+			Items: r.Schema(t.Elem()),
+		}
+	case reflect.Map:
+		return &Schema{
+			Type:                 "object",
+			AdditionalProperties: r.Schema(t.Elem()),
+		}
+	default:
+		return kindSchema(t.Kind())
+	}
+}
+
+// Register registers provided type as component with provided schema
+func (r *schemaRegistry) Register(t reflect.Type, schema *Schema) {
+	name := schemaName(t)
+	r.types[name] = t
+	r.schemas[name] = schema
+}
+
+func (r *schemaRegistry) structSchema(t reflect.Type) *Schema {
+	schema := &Schema{
+		Type:       "object",
+		Properties: make(map[string]*Schema),
+	}
+
+	eachField(t, func(field reflect.StructField) {
+		if isParameterOnly(field) {
+			return
+		}
+
+		name := jsonFieldName(field)
+		if name == "" {
+			return
+		}
+
+		schema.Properties[name] = r.fieldSchema(field)
+
+		if isRequired(field) {
+			schema.Required = append(schema.Required, name)
+		}
+	})
+
+	return schema
+}
+
+// ParametersSchema returns object schema which describes fields with provided binding tag
+func (r *schemaRegistry) ParametersSchema(t reflect.Type, tag string) *Schema {
+	schema := &Schema{
+		Type:       "object",
+		Properties: make(map[string]*Schema),
+	}
+
+	for _, parameter := range r.Parameters(t) {
+		if parameter.In != parameterLocations[tag] {
+			continue
+		}
+
+		schema.Properties[parameter.Name] = parameter.Schema
+		if parameter.Required {
+			schema.Required = append(schema.Required, parameter.Name)
+		}
+	}
+
+	return schema
+}
+
+// Parameters returns path, query, header & cookie parameters described by provided type fields
+func (r *schemaRegistry) Parameters(t reflect.Type) []*Parameter {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	parameters := make([]*Parameter, 0)
+	eachField(t, func(field reflect.StructField) {
+		for _, tag := range []string{tagParam, tagQuery, tagHeader, tagCookie} {
+			name := field.Tag.Get(tag)
+			if name == "" {
+				continue
+			}
+
+			in := parameterLocations[tag]
+			parameters = append(parameters, &Parameter{
+				Name:     name,
+				In:       in,
+				Required: in == "path" || isRequired(field),
+				Schema:   r.fieldSchema(field),
+			})
+			return
+		}
+
+		// echo binder goes deeper into untagged structures
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+
+		if fieldType.Kind() == reflect.Struct && !isKnownType(fieldType) && field.Tag.Get("json") == "" {
+			parameters = append(parameters, r.Parameters(fieldType)...)
+		}
+	})
+
+	return parameters
+}
+
+// HasBody returns true if provided type describes any request body field
+func (r *schemaRegistry) HasBody(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t.Kind() != reflect.Struct || isKnownType(t) {
+		return true
+	}
+
+	if t == reflect.TypeOf(Empty{}) {
+		return false
+	}
+
+	hasBody := false
+	eachField(t, func(field reflect.StructField) {
+		if isParameterOnly(field) {
+			return
+		}
+
+		if jsonFieldName(field) != "" {
+			hasBody = true
+		}
+	})
+
+	return hasBody
+}
+
+// fieldSchema returns field schema with applied "validate" & "default" tags
+func (r *schemaRegistry) fieldSchema(field reflect.StructField) *Schema {
+	schema := r.Schema(field.Type)
+
+	validations := field.Tag.Get("validate")
+	defaultValue, hasDefault := field.Tag.Lookup("default")
+
+	if validations == "" && !hasDefault {
+		return schema
+	}
+
+	// references can not contain siblings, so wrap them
+	if schema.Ref != "" {
+		schema = &Schema{AllOf: []*Schema{schema}}
+	} else {
+		copied := *schema
+		schema = &copied
+	}
+
+	fieldType := field.Type
+	for fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+
+	applyValidations(schema, fieldType, validations)
+
+	if hasDefault {
+		schema.Default = parseSchemaValue(fieldType.Kind(), defaultValue)
+	}
+
+	return schema
+}
+
+// applyValidations maps go-playground validator tags to schema constraints
+func applyValidations(schema *Schema, t reflect.Type, validations string) {
+	if validations == "" {
+		return
+	}
+
+	rules := strings.Split(validations, ",")
+	for idx, rule := range rules {
+		name, param, _ := strings.Cut(rule, "=")
+
+		switch name {
+		case "dive":
+			// rules after "dive" describe collection items
+			if schema.Items != nil && (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) {
+				items := *schema.Items
+				applyValidations(&items, t.Elem(), strings.Join(rules[idx+1:], ","))
+				schema.Items = &items
+			}
+			return
+		case "min":
+			applyBound(schema, t, param, true, false)
+		case "max":
+			applyBound(schema, t, param, false, false)
+		case "gte":
+			applyBound(schema, t, param, true, false)
+		case "lte":
+			applyBound(schema, t, param, false, false)
+		case "gt":
+			applyBound(schema, t, param, true, true)
+		case "lt":
+			applyBound(schema, t, param, false, true)
+		case "len":
+			applyBound(schema, t, param, true, false)
+			applyBound(schema, t, param, false, false)
+		case "oneof":
+			for _, value := range strings.Fields(param) {
+				schema.Enum = append(schema.Enum, parseSchemaValue(t.Kind(), strings.Trim(value, "'")))
+			}
+		case "email":
+			schema.Format = "email"
+		case "url", "uri", "http_url":
+			schema.Format = "uri"
+		case "uuid", "uuid4", "uuid_rfc4122", "uuid4_rfc4122":
+			schema.Format = "uuid"
+		case "ip":
+			schema.Format = "ip"
+		case "ipv4":
+			schema.Format = "ipv4"
+		case "ipv6":
+			schema.Format = "ipv6"
+		case "hostname":
+			schema.Format = "hostname"
+		case "alpha":
+			schema.Pattern = "^[a-zA-Z]+$"
+		case "alphanum":
+			schema.Pattern = "^[a-zA-Z0-9]+$"
+		case "numeric":
+			schema.Pattern = "^[-+]?[0-9]+(?:\\.[0-9]+)?$"
+		case "number":
+			schema.Pattern = "^[0-9]+$"
+		case "e164":
+			schema.Pattern = "^\\+[1-9]?[0-9]{7,14}$"
+		}
+	}
+}
+
+// applyBound sets minimum or maximum constraint depending on type kind
+func applyBound(schema *Schema, t reflect.Type, param string, lower, exclusive bool) {
+	switch t.Kind() {
+	case reflect.String:
+		length, err := strconv.Atoi(param)
+		if err != nil {
+			return
+		}
+
+		if exclusive {
+			if lower {
+				length++
+			} else {
+				length--
+			}
+		}
+
+		if lower {
+			schema.MinLength = &length
+		} else {
+			schema.MaxLength = &length
+		}
+	case reflect.Slice, reflect.Array, reflect.Map:
+		count, err := strconv.Atoi(param)
+		if err != nil {
+			return
+		}
+
+		if exclusive {
+			if lower {
+				count++
+			} else {
+				count--
+			}
+		}
+
+		if lower {
+			schema.MinItems = &count
+		} else {
+			schema.MaxItems = &count
+		}
+	default:
+		value, err := strconv.ParseFloat(param, 64)
+		if err != nil {
+			return
+		}
+
+		switch {
+		case lower && exclusive:
+			schema.ExclusiveMinimum = &value
+		case lower:
+			schema.Minimum = &value
+		case exclusive:
+			schema.ExclusiveMaximum = &value
+		default:
+			schema.Maximum = &value
+		}
+	}
+}
+
+// parseSchemaValue converts tag value to typed value by provided kind
+func parseSchemaValue(kind reflect.Kind, value string) any {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if parsed, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return parsed
+		}
+	case reflect.Float32, reflect.Float64:
+		if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+			return parsed
+		}
+	case reflect.Bool:
+		if parsed, err := strconv.ParseBool(value); err == nil {
+			return parsed
+		}
+	default:
+	}
+
+	return value
+}
+
+func knownTypeSchema(t reflect.Type) (*Schema, bool) {
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}, true
+	case t == uuidType:
+		return &Schema{Type: "string", Format: "uuid"}, true
+	case t == rawMessageType:
+		return &Schema{}, true
+	case t == fileHeaderType:
+		return &Schema{Type: "string", Format: "binary"}, true
+	case t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8:
+		return &Schema{Type: "string", Format: "byte"}, true
+	case t.Kind() == reflect.Interface:
+		return &Schema{}, true
+	case t.Kind() == reflect.Struct && reflect.PointerTo(t).Implements(textMarshalerType):
+		return &Schema{Type: "string"}, true
+	case t.Kind() == reflect.Struct && reflect.PointerTo(t).Implements(jsonMarshalerType):
+		return &Schema{}, true
+	default:
+		return nil, false
+	}
+}
+
+func isKnownType(t reflect.Type) bool {
+	_, ok := knownTypeSchema(t)
+	return ok
+}
+
+func kindSchema(kind reflect.Kind) *Schema {
+	switch kind {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int8, reflect.Int16, reflect.Int32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int, reflect.Int64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		minimum := float64(0)
+		return &Schema{Type: "integer", Format: "int32", Minimum: &minimum}
+	case reflect.Uint, reflect.Uint64:
+		minimum := float64(0)
+		return &Schema{Type: "integer", Format: "int64", Minimum: &minimum}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	default:
+		return &Schema{}
+	}
+}
+
+// eachField iterates over exported struct fields with flattening embedded structures
+func eachField(t reflect.Type, fn func(field reflect.StructField)) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		if field.Anonymous && field.Tag.Get("json") == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+
+			if embedded.Kind() == reflect.Struct {
+				eachField(embedded, fn)
+				continue
+			}
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+
+		fn(field)
+	}
+}
+
+// isParameterOnly returns true if field binds only from path, query or headers
+func isParameterOnly(field reflect.StructField) bool {
+	if _, ok := field.Tag.Lookup("json"); ok {
+		return false
+	}
+
+	for tag := range parameterLocations {
+		if field.Tag.Get(tag) != "" {
+			return true
+		}
+	}
+
+	// untagged structure which contains only parameters
+	fieldType := field.Type
+	if fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+
+	if fieldType.Kind() != reflect.Struct || isKnownType(fieldType) || fieldType.NumField() == 0 {
+		return false
+	}
+
+	parameterOnly := true
+	eachField(fieldType, func(inner reflect.StructField) {
+		if !isParameterOnly(inner) {
+			parameterOnly = false
+		}
+	})
+
+	return parameterOnly
+}
+
+func jsonFieldName(field reflect.StructField) string {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return ""
+	}
+
+	name, _, _ := strings.Cut(tag, ",")
+	if name == "" {
+		return field.Name
+	}
+
+	return name
+}
+
+func isRequired(field reflect.StructField) bool {
+	for _, rule := range strings.Split(field.Tag.Get("validate"), ",") {
+		if rule == "required" {
+			return true
+		}
+
+		if rule == "dive" {
+			break
+		}
+	}
+
+	return false
+}
+
+// schemaName returns component name of provided type, like "echox.PageParams"
+func schemaName(t reflect.Type) string {
+	name := schemaPackagePath.ReplaceAllString(t.Name(), "")
+
+	if pkgPath := t.PkgPath(); pkgPath != "" {
+		name = pkgPath[strings.LastIndex(pkgPath, "/")+1:] + "." + name
+	}
+
+	return strings.Trim(schemaNameForbidden.ReplaceAllString(name, "_"), "_")
+}
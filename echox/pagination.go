@@ -17,3 +17,19 @@ func (p PageParams) Pagination() pagex.Pagination {
 func (p PageParams) MaxPages(count int64) int64 {
 	return pagex.MaxPages(p.Size, count)
 }
+
+// CursorParams are keyset pagination query params. Cursor is empty for the first page
+type CursorParams struct {
+	Cursor string `query:"cursor" form:"cursor"`
+	Size   int64  `query:"page-size" form:"page-size" default:"20"`
+}
+
+// Decode converts cursor token by keyset
+func (p CursorParams) Decode(keyset *pagex.Keyset) (pagex.Cursor, error) {
+	return keyset.Decode(p.Cursor)
+}
+
+// Limit returns query limit (see pagex.CursorLimit)
+func (p CursorParams) Limit() int64 {
+	return pagex.CursorLimit(p.Size)
+}
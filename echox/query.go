@@ -1,6 +1,7 @@
 package echox
 
 import (
+	"github.com/boostgo/core/filterx"
 	"github.com/boostgo/core/sorts"
 )
 
@@ -15,3 +16,14 @@ func (s SortByParams) SortBy() sorts.Params {
 		Asc:   s.Asc,
 	}
 }
+
+// FilterParams are filters & sorts query params, like "?filter=status:eq:active,amount:gte:100&sort=-created_at,name"
+type FilterParams struct {
+	Filter string `json:"filter" query:"filter" form:"filter"`
+	Sort   string `json:"sort" query:"sort" form:"sort"`
+}
+
+// Spec parses filters & sorts by schema (allowlist of resource fields)
+func (f FilterParams) Spec(schema *filterx.Schema) (filterx.Spec, error) {
+	return schema.Parse(f.Filter, f.Sort)
+}
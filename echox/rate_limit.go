@@ -0,0 +1,314 @@
+package echox
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/boostgo/core/httpx"
+	"github.com/boostgo/core/log"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	HeaderRateLimitLimit     = "X-RateLimit-Limit"
+	HeaderRateLimitRemaining = "X-RateLimit-Remaining"
+	HeaderRateLimitReset     = "X-RateLimit-Reset"
+	HeaderRetryAfter         = "Retry-After"
+
+	defaultRateLimitPrefix = "rate_limit:"
+)
+
+// RateLimitAlgorithm is algorithm of counting requests
+type RateLimitAlgorithm int
+
+const (
+	// TokenBucket refills bucket by Limit tokens per Window. Allows bursts up to Burst requests
+	TokenBucket RateLimitAlgorithm = iota
+	// SlidingWindow allows Limit requests per Window, counted by weighted current & previous windows
+	SlidingWindow
+)
+
+// RateLimitRule describes rate limit settings
+type RateLimitRule struct {
+	Algorithm RateLimitAlgorithm
+	Limit     int
+	Window    time.Duration
+	// Burst is bucket capacity for TokenBucket algorithm. If zero, Limit is used
+	Burst int
+}
+
+// RateLimitResult is result of taking one request from the limit
+type RateLimitResult struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	RetryAfter time.Duration
+	ResetAfter time.Duration
+}
+
+// RateLimitStore keeps rate limit states by keys
+type RateLimitStore interface {
+	Take(ctx context.Context, key string, rule RateLimitRule) (RateLimitResult, error)
+}
+
+// RateLimitKeyFunc returns key of the request rate limit. Requests with empty key are not limited
+type RateLimitKeyFunc func(ctx echo.Context) (string, error)
+
+// RateLimitByIP returns key func which limits requests by client IP
+func RateLimitByIP() RateLimitKeyFunc {
+	return func(ctx echo.Context) (string, error) {
+		return "ip:" + ctx.RealIP(), nil
+	}
+}
+
+// RateLimitByHeader returns key func which limits requests by header value (API key, user ID, etc.)
+func RateLimitByHeader(name string) RateLimitKeyFunc {
+	return func(ctx echo.Context) (string, error) {
+		value := ctx.Request().Header.Get(name)
+		if value == "" {
+			return "", nil
+		}
+
+		return "header:" + name + ":" + value, nil
+	}
+}
+
+// RateLimitByRoute returns key func which limits requests by route (method & path template)
+func RateLimitByRoute() RateLimitKeyFunc {
+	return func(ctx echo.Context) (string, error) {
+		return "route:" + ctx.Request().Method + ":" + ctx.Path(), nil
+	}
+}
+
+// RateLimitByKeys returns key func which joins keys of all provided key funcs.
+//
+// For example, RateLimitByKeys(RateLimitByRoute(), RateLimitByIP()) limits every route for every IP
+func RateLimitByKeys(funcs ...RateLimitKeyFunc) RateLimitKeyFunc {
+	return func(ctx echo.Context) (string, error) {
+		keys := make([]string, 0, len(funcs))
+		for _, fn := range funcs {
+			key, err := fn(ctx)
+			if err != nil {
+				return "", err
+			}
+
+			if key == "" {
+				return "", nil
+			}
+
+			keys = append(keys, key)
+		}
+
+		return strings.Join(keys, "|"), nil
+	}
+}
+
+// RateLimitOption modifies rate limit middleware settings
+type RateLimitOption func(options *rateLimitOptions)
+
+type rateLimitOptions struct {
+	rule     RateLimitRule
+	keyFunc  RateLimitKeyFunc
+	store    RateLimitStore
+	prefix   string
+	failOpen bool
+}
+
+// WithRateLimitAlgorithm sets rate limit algorithm. By default, it is TokenBucket
+func WithRateLimitAlgorithm(algorithm RateLimitAlgorithm) RateLimitOption {
+	return func(options *rateLimitOptions) {
+		options.rule.Algorithm = algorithm
+	}
+}
+
+// WithRateLimitBurst sets bucket capacity for TokenBucket algorithm
+func WithRateLimitBurst(burst int) RateLimitOption {
+	return func(options *rateLimitOptions) {
+		options.rule.Burst = burst
+	}
+}
+
+// WithRateLimitKey sets request key func. By default, requests are limited by client IP
+func WithRateLimitKey(keyFunc RateLimitKeyFunc) RateLimitOption {
+	return func(options *rateLimitOptions) {
+		if keyFunc != nil {
+			options.keyFunc = keyFunc
+		}
+	}
+}
+
+// WithRateLimitStore sets rate limit states store. By default, in-memory store is used
+func WithRateLimitStore(store RateLimitStore) RateLimitOption {
+	return func(options *rateLimitOptions) {
+		if store != nil {
+			options.store = store
+		}
+	}
+}
+
+// WithRateLimitPrefix sets prefix of all store keys. Useful if several middlewares use the same store
+func WithRateLimitPrefix(prefix string) RateLimitOption {
+	return func(options *rateLimitOptions) {
+		options.prefix = prefix
+	}
+}
+
+// WithRateLimitFailClosed rejects requests if store returned error. By default, such requests are allowed
+func WithRateLimitFailClosed() RateLimitOption {
+	return func(options *rateLimitOptions) {
+		options.failOpen = false
+	}
+}
+
+// RateLimitMiddleware limits requests count to "limit" per "window".
+//
+// Every response contains X-RateLimit-Limit, X-RateLimit-Remaining and X-RateLimit-Reset headers.
+// Rejected requests get Retry-After header and error based on errorx.ErrTooManyRequests
+func RateLimitMiddleware(limit int, window time.Duration, opts ...RateLimitOption) echo.MiddlewareFunc {
+	options := rateLimitOptions{
+		rule: RateLimitRule{
+			Algorithm: TokenBucket,
+			Limit:     limit,
+			Window:    window,
+		},
+		keyFunc:  RateLimitByIP(),
+		prefix:   defaultRateLimitPrefix,
+		failOpen: true,
+	}
+
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	if options.store == nil {
+		options.store = NewMemoryRateLimitStore(0)
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			if options.rule.Limit <= 0 || options.rule.Window <= 0 {
+				return next(ctx)
+			}
+
+			key, err := options.keyFunc(ctx)
+			if err != nil {
+				return Error(ctx, err)
+			}
+
+			if key == "" {
+				return next(ctx)
+			}
+
+			result, err := options.store.Take(Context(ctx), options.prefix+key, options.rule)
+			if err != nil {
+				log.
+					Error().
+					Ctx(Context(ctx)).
+					Err(err).
+					Str("key", key).
+					Msg("Take rate limit")
+
+				if options.failOpen {
+					return next(ctx)
+				}
+
+				return Error(ctx, err)
+			}
+
+			header := ctx.Response().Header()
+			header.Set(HeaderRateLimitLimit, strconv.Itoa(result.Limit))
+			header.Set(HeaderRateLimitRemaining, strconv.Itoa(result.Remaining))
+			header.Set(HeaderRateLimitReset, strconv.Itoa(ceilSeconds(result.ResetAfter)))
+
+			if !result.Allowed {
+				header.Set(HeaderRetryAfter, strconv.Itoa(ceilSeconds(result.RetryAfter)))
+				return Failure(ctx, http.StatusTooManyRequests, newRateLimitExceededError(result))
+			}
+
+			return next(ctx)
+		}
+	}
+}
+
+type rateLimitContext struct {
+	Limit      int `json:"limit"`
+	RetryAfter int `json:"retry_after"`
+}
+
+func newRateLimitExceededError(result RateLimitResult) error {
+	return httpx.ErrRateLimitExceeded.SetData(rateLimitContext{
+		Limit:      result.Limit,
+		RetryAfter: ceilSeconds(result.RetryAfter),
+	})
+}
+
+// tokenBucketResult builds result by tokens left in the bucket after taking
+func tokenBucketResult(rule RateLimitRule, tokens float64, allowed bool) RateLimitResult {
+	capacity := rule.capacity()
+	perToken := rule.Window / time.Duration(rule.Limit)
+
+	result := RateLimitResult{
+		Allowed:    allowed,
+		Limit:      capacity,
+		Remaining:  int(math.Floor(tokens)),
+		ResetAfter: time.Duration((float64(capacity) - tokens) * float64(perToken)),
+	}
+
+	if !allowed {
+		result.RetryAfter = time.Duration((1 - tokens) * float64(perToken))
+	}
+
+	return result
+}
+
+// slidingWindowResult builds result by previous & current windows counters.
+//
+// elapsed is time passed from the current window start
+func slidingWindowResult(rule RateLimitRule, previous, current float64, elapsed time.Duration, allowed bool) RateLimitResult {
+	weight := 1 - float64(elapsed)/float64(rule.Window)
+	estimated := previous*weight + current
+	left := rule.Window - elapsed
+
+	result := RateLimitResult{
+		Allowed:    allowed,
+		Limit:      rule.Limit,
+		Remaining:  max(0, rule.Limit-int(math.Ceil(estimated))),
+		ResetAfter: left,
+	}
+
+	if allowed {
+		return result
+	}
+
+	// wait till previous window weight goes down enough, otherwise till the next window
+	result.RetryAfter = left
+	if previous > 0 && current < float64(rule.Limit) {
+		wait := time.Duration((estimated - float64(rule.Limit) + 1) / previous * float64(rule.Window))
+		if wait < left {
+			result.RetryAfter = wait
+		}
+	}
+
+	return result
+}
+
+func (rule RateLimitRule) capacity() int {
+	if rule.Burst > 0 {
+		return rule.Burst
+	}
+
+	return rule.Limit
+}
+
+func ceilSeconds(duration time.Duration) int {
+	if duration <= 0 {
+		return 0
+	}
+
+	return int(math.Ceil(duration.Seconds()))
+}
@@ -0,0 +1,251 @@
+package echox
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/boostgo/core/convert"
+	"github.com/boostgo/core/errorx"
+	"github.com/boostgo/core/orderedmap"
+	"github.com/boostgo/core/redis"
+)
+
+const defaultRateLimitStoreCapacity = 100_000
+
+var (
+	ErrRateLimitStoreResult = errorx.New("rate_limit.store_result")
+)
+
+type rateLimitState struct {
+	// token bucket
+	tokens  float64
+	updated time.Time
+
+	// sliding window
+	window   int64
+	current  float64
+	previous float64
+}
+
+type memoryRateLimitStore struct {
+	states *orderedmap.OrderedMap[string, *rateLimitState]
+	mx     sync.Mutex
+}
+
+// NewMemoryRateLimitStore creates in-memory rate limit store.
+//
+// Store keeps up to "capacity" keys, the least recently used keys are evicted. Zero capacity means 100000 keys
+func NewMemoryRateLimitStore(capacity int) RateLimitStore {
+	if capacity <= 0 {
+		capacity = defaultRateLimitStoreCapacity
+	}
+
+	return &memoryRateLimitStore{
+		states: orderedmap.NewOrderedMap[string, *rateLimitState](
+			orderedmap.WithCapacity[string, *rateLimitState](capacity),
+		),
+	}
+}
+
+func (store *memoryRateLimitStore) Take(_ context.Context, key string, rule RateLimitRule) (RateLimitResult, error) {
+	store.mx.Lock()
+	defer store.mx.Unlock()
+
+	now := time.Now()
+
+	state, ok := store.states.GetAndTouch(key)
+	if !ok {
+		state = &rateLimitState{
+			tokens:  float64(rule.capacity()),
+			updated: now,
+			window:  now.UnixNano() / int64(rule.Window),
+		}
+		store.states.Set(key, state)
+	}
+
+	switch rule.Algorithm {
+	case SlidingWindow:
+		return store.slidingWindow(state, rule, now), nil
+	default:
+		return store.tokenBucket(state, rule, now), nil
+	}
+}
+
+func (store *memoryRateLimitStore) tokenBucket(state *rateLimitState, rule RateLimitRule, now time.Time) RateLimitResult {
+	rate := float64(rule.Limit) / float64(rule.Window)
+	state.tokens = math.Min(float64(rule.capacity()), state.tokens+float64(now.Sub(state.updated))*rate)
+	state.updated = now
+
+	allowed := state.tokens >= 1
+	if allowed {
+		state.tokens--
+	}
+
+	return tokenBucketResult(rule, state.tokens, allowed)
+}
+
+func (store *memoryRateLimitStore) slidingWindow(state *rateLimitState, rule RateLimitRule, now time.Time) RateLimitResult {
+	window := now.UnixNano() / int64(rule.Window)
+	switch {
+	case window == state.window+1:
+		state.previous = state.current
+		state.current = 0
+	case window > state.window+1:
+		state.previous = 0
+		state.current = 0
+	}
+	state.window = window
+
+	elapsed := time.Duration(now.UnixNano() % int64(rule.Window))
+	weight := 1 - float64(elapsed)/float64(rule.Window)
+
+	allowed := state.previous*weight+state.current+1 <= float64(rule.Limit)
+	if allowed {
+		state.current++
+	}
+
+	return slidingWindowResult(rule, state.previous, state.current, elapsed, allowed)
+}
+
+// tokenBucketScript keeps bucket in hash: tokens & last update time (in microseconds by redis clock).
+//
+// Returns: allowed (0/1), tokens left
+const tokenBucketScript = `
+local capacity = tonumber(ARGV[1])
+local rate = tonumber(ARGV[2])
+local ttl = tonumber(ARGV[3])
+
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000000 + tonumber(time[2])
+
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'updated')
+local tokens = tonumber(state[1])
+local updated = tonumber(state[2])
+if tokens == nil or updated == nil then
+	tokens = capacity
+	updated = now
+end
+
+tokens = math.min(capacity, tokens + math.max(0, now - updated) * rate)
+
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'updated', tostring(now))
+redis.call('PEXPIRE', KEYS[1], ttl)
+
+return {allowed, tostring(tokens)}
+`
+
+// slidingWindowScript keeps windows counters in hash by window number (window size in microseconds).
+//
+// Returns: allowed (0/1), previous window counter, current window counter, elapsed time from window start in microseconds
+const slidingWindowScript = `
+local limit = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000000 + tonumber(time[2])
+
+local current = math.floor(now / window)
+local elapsed = now - current * window
+
+local counters = redis.call('HMGET', KEYS[1], tostring(current), tostring(current - 1))
+local currentCount = tonumber(counters[1]) or 0
+local previousCount = tonumber(counters[2]) or 0
+
+local allowed = 0
+if previousCount * (1 - elapsed / window) + currentCount + 1 <= limit then
+	allowed = 1
+	currentCount = redis.call('HINCRBY', KEYS[1], tostring(current), 1)
+	redis.call('HDEL', KEYS[1], tostring(current - 2))
+	redis.call('PEXPIRE', KEYS[1], math.ceil(window * 2 / 1000))
+end
+
+return {allowed, previousCount, currentCount, elapsed}
+`
+
+type redisRateLimitStore struct {
+	client redis.Client
+}
+
+// NewRedisRateLimitStore creates distributed rate limit store based on redis Lua scripts.
+//
+// Time is taken from redis server, so all app instances share the same clock
+func NewRedisRateLimitStore(client redis.Client) RateLimitStore {
+	return &redisRateLimitStore{
+		client: client,
+	}
+}
+
+func (store *redisRateLimitStore) Take(ctx context.Context, key string, rule RateLimitRule) (RateLimitResult, error) {
+	switch rule.Algorithm {
+	case SlidingWindow:
+		return store.slidingWindow(ctx, key, rule)
+	default:
+		return store.tokenBucket(ctx, key, rule)
+	}
+}
+
+func (store *redisRateLimitStore) tokenBucket(ctx context.Context, key string, rule RateLimitRule) (RateLimitResult, error) {
+	// tokens per microsecond
+	rate := float64(rule.Limit) / float64(rule.Window.Microseconds())
+	// bucket fully refills after this time, so state could be removed
+	ttl := time.Duration(float64(rule.Window) * float64(rule.capacity()) / float64(rule.Limit))
+
+	reply, err := store.client.Eval(
+		ctx,
+		tokenBucketScript,
+		[]string{key},
+		rule.capacity(),
+		strconv.FormatFloat(rate, 'f', -1, 64),
+		max(ttl.Milliseconds(), 1),
+	)
+	if err != nil {
+		return RateLimitResult{}, err
+	}
+
+	values, ok := reply.([]any)
+	if !ok || len(values) != 2 {
+		return RateLimitResult{}, ErrRateLimitStoreResult.AddParam("key", key)
+	}
+
+	tokens, err := strconv.ParseFloat(convert.String(values[1]), 64)
+	if err != nil {
+		return RateLimitResult{}, ErrRateLimitStoreResult.SetError(err).AddParam("key", key)
+	}
+
+	return tokenBucketResult(rule, tokens, convert.Int(values[0]) == 1), nil
+}
+
+func (store *redisRateLimitStore) slidingWindow(ctx context.Context, key string, rule RateLimitRule) (RateLimitResult, error) {
+	reply, err := store.client.Eval(
+		ctx,
+		slidingWindowScript,
+		[]string{key},
+		rule.Limit,
+		rule.Window.Microseconds(),
+	)
+	if err != nil {
+		return RateLimitResult{}, err
+	}
+
+	values, ok := reply.([]any)
+	if !ok || len(values) != 4 {
+		return RateLimitResult{}, ErrRateLimitStoreResult.AddParam("key", key)
+	}
+
+	return slidingWindowResult(
+		rule,
+		float64(convert.Int(values[1])),
+		float64(convert.Int(values[2])),
+		time.Duration(convert.Int(values[3]))*time.Microsecond,
+		convert.Int(values[0]) == 1,
+	), nil
+}
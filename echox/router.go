@@ -11,12 +11,9 @@ type route struct {
 	Path        string
 	Handler     echo.HandlerFunc
 	Middlewares []echo.MiddlewareFunc
+	Meta        *HandlerMeta
 }
 
-var (
-	_routes = make([]route, 0)
-)
-
 type Router interface {
 	Any(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) []*echo.Route
 	POST(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
@@ -31,12 +28,12 @@ type Router interface {
 }
 
 func RegisterRoute(method, path string, handlerFunc echo.HandlerFunc, m ...echo.MiddlewareFunc) {
-	_routes = append(_routes, route{
-		Method:      method,
-		Path:        path,
-		Handler:     handlerFunc,
-		Middlewares: m,
-	})
+	_server.Register(method, path, handlerFunc, m...)
+}
+
+// Endpoint registers typed handler to the default server with collecting its metadata
+func Endpoint(method, path string, h TypedHandler, m ...echo.MiddlewareFunc) {
+	_server.Endpoint(method, path, h, m...)
 }
 
 func GET(path string, handlerFunc echo.HandlerFunc, m ...echo.MiddlewareFunc) {
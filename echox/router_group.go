@@ -12,7 +12,6 @@ const (
 )
 
 var (
-	_groups  = make([]*RouterGroup, 0)
 	_methods = []string{
 		http.MethodConnect,
 		http.MethodDelete,
@@ -29,19 +28,14 @@ var (
 )
 
 type RouterGroup struct {
+	server      *Server
 	basePath    string
 	routes      []route
 	middlewares []echo.MiddlewareFunc
 }
 
 func Group(basePath string, m ...echo.MiddlewareFunc) *RouterGroup {
-	g := &RouterGroup{
-		basePath:    basePath,
-		routes:      make([]route, 0),
-		middlewares: m,
-	}
-	_groups = append(_groups, g)
-	return g
+	return _server.Group(basePath, m...)
 }
 
 func (g *RouterGroup) Any(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) {
@@ -132,7 +126,16 @@ func (g *RouterGroup) Use(m ...echo.MiddlewareFunc) {
 }
 
 func (g *RouterGroup) Group(prefix string, m ...echo.MiddlewareFunc) *RouterGroup {
-	return Group(g.basePath+prefix, append(g.middlewares, m...)...)
+	server := g.server
+	if server == nil {
+		server = _server
+	}
+
+	middlewares := make([]echo.MiddlewareFunc, 0, len(g.middlewares)+len(m))
+	middlewares = append(middlewares, g.middlewares...)
+	middlewares = append(middlewares, m...)
+
+	return server.Group(g.basePath+prefix, middlewares...)
 }
 
 func (g *RouterGroup) Register(method, url string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) {
@@ -143,3 +146,15 @@ func (g *RouterGroup) Register(method, url string, h echo.HandlerFunc, m ...echo
 		Middlewares: m,
 	})
 }
+
+// Endpoint registers typed handler with collecting its metadata
+func (g *RouterGroup) Endpoint(method, url string, h TypedHandler, m ...echo.MiddlewareFunc) {
+	meta := h.Meta()
+	g.routes = append(g.routes, route{
+		Method:      method,
+		Path:        url,
+		Handler:     h.Echo(),
+		Middlewares: m,
+		Meta:        &meta,
+	})
+}
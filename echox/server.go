@@ -1,13 +1,17 @@
 package echox
 
 import (
+	"context"
 	"errors"
+	"net"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/boostgo/core/appx"
 	"github.com/boostgo/core/configx"
 	"github.com/boostgo/core/convert"
+	"github.com/boostgo/core/defaults"
 	"github.com/boostgo/core/httpx"
 	"github.com/boostgo/core/log"
 	"github.com/boostgo/core/trace"
@@ -17,19 +21,328 @@ import (
 	"github.com/labstack/echo/v4/middleware"
 )
 
-func run(address string) error {
+const (
+	serverContextKey = "echox-server"
+)
+
+var (
+	_server = MustServer(Options{})
+)
+
+// Server is HTTP server which owns its own routes, groups, middlewares and listener.
+//
+// Routes, groups and middlewares must be registered before calling Handler, Start or Run.
+//
+// Package level functions (GET, POST, Group, RegisterMiddleware, Run, etc.) work with default server
+type Server struct {
+	options            Options
+	bodyLimit          int64
+	routes             []route
+	groups             []*RouterGroup
+	middlewares        []echo.MiddlewareFunc
+	failureMiddlewares []FailureMiddleware
+
+	handler   *echo.Echo
+	buildOnce sync.Once
+}
+
+// NewServer creates new Server with provided options.
+//
+// Empty options fields are filled by "default" tags.
+// Returns error if options are invalid (for example, body limit could not be parsed)
+func NewServer(options Options) (*Server, error) {
+	if err := defaults.Set(&options); err != nil {
+		return nil, httpx.ErrServerOptions.SetError(err)
+	}
+
+	bodyLimit, err := options.bodyLimit()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Server{
+		options:            options,
+		bodyLimit:          bodyLimit,
+		routes:             make([]route, 0),
+		groups:             make([]*RouterGroup, 0),
+		middlewares:        make([]echo.MiddlewareFunc, 0),
+		failureMiddlewares: make([]FailureMiddleware, 0),
+	}, nil
+}
+
+// MustServer calls NewServer and panics on error
+func MustServer(options Options) *Server {
+	server, err := NewServer(options)
+	if err != nil {
+		panic(err)
+	}
+
+	return server
+}
+
+// Default returns default server which is used by package level functions
+func Default() *Server {
+	return _server
+}
+
+// Options returns server options
+func (s *Server) Options() Options {
+	return s.options
+}
+
+// SetAddress sets address the server will listen to
+func (s *Server) SetAddress(address string) *Server {
+	s.options.Address = address
+	return s
+}
+
+// Use registers middlewares for all server routes
+func (s *Server) Use(m ...echo.MiddlewareFunc) {
+	for _, mid := range m {
+		if mid == nil {
+			continue
+		}
+
+		s.middlewares = append(s.middlewares, mid)
+	}
+}
+
+// RegisterFailureMiddleware registers middleware which calls on every failure response of the server
+func (s *Server) RegisterFailureMiddleware(m FailureMiddleware) {
+	if m == nil {
+		return
+	}
+
+	s.failureMiddlewares = append(s.failureMiddlewares, m)
+}
+
+// Register registers new route with provided method
+func (s *Server) Register(method, path string, handlerFunc echo.HandlerFunc, m ...echo.MiddlewareFunc) {
+	s.routes = append(s.routes, route{
+		Method:      method,
+		Path:        path,
+		Handler:     handlerFunc,
+		Middlewares: m,
+	})
+}
+
+// Endpoint registers typed handler with collecting its metadata
+func (s *Server) Endpoint(method, path string, h TypedHandler, m ...echo.MiddlewareFunc) {
+	meta := h.Meta()
+	s.routes = append(s.routes, route{
+		Method:      method,
+		Path:        path,
+		Handler:     h.Echo(),
+		Middlewares: m,
+		Meta:        &meta,
+	})
+}
+
+func (s *Server) GET(path string, handlerFunc echo.HandlerFunc, m ...echo.MiddlewareFunc) {
+	s.Register(http.MethodGet, path, handlerFunc, m...)
+}
+
+func (s *Server) POST(path string, handlerFunc echo.HandlerFunc, m ...echo.MiddlewareFunc) {
+	s.Register(http.MethodPost, path, handlerFunc, m...)
+}
+
+func (s *Server) PUT(path string, handlerFunc echo.HandlerFunc, m ...echo.MiddlewareFunc) {
+	s.Register(http.MethodPut, path, handlerFunc, m...)
+}
+
+func (s *Server) PATCH(path string, handlerFunc echo.HandlerFunc, m ...echo.MiddlewareFunc) {
+	s.Register(http.MethodPatch, path, handlerFunc, m...)
+}
+
+func (s *Server) DELETE(path string, handlerFunc echo.HandlerFunc, m ...echo.MiddlewareFunc) {
+	s.Register(http.MethodDelete, path, handlerFunc, m...)
+}
+
+// Group creates new RouterGroup attached to the server
+func (s *Server) Group(basePath string, m ...echo.MiddlewareFunc) *RouterGroup {
+	g := &RouterGroup{
+		server:      s,
+		basePath:    basePath,
+		routes:      make([]route, 0),
+		middlewares: m,
+	}
+	s.groups = append(s.groups, g)
+	return g
+}
+
+// Handler builds server handler with all registered routes and middlewares.
+//
+// Handler is built only once, so routes registered after the first call will be ignored.
+//
+// Could be used with httptest package for testing handlers
+func (s *Server) Handler() http.Handler {
+	return s.build()
+}
+
+// Start starts listening server address. Blocks till the server is closed.
+//
+// Returns nil if server was closed by Shutdown
+func (s *Server) Start() error {
+	handler := s.prepare()
+
+	if err := handler.Start(s.options.Address); err != nil {
+		return s.startError(err)
+	}
+
+	return nil
+}
+
+// Serve starts serving provided listener. Blocks till the server is closed.
+//
+// Returns nil if server was closed by Shutdown
+func (s *Server) Serve(listener net.Listener) error {
+	handler := s.prepare()
+	handler.Listener = listener
+
+	if err := handler.Start(""); err != nil {
+		return s.startError(err)
+	}
+
+	return nil
+}
+
+// Shutdown gracefully stops the server
+func (s *Server) Shutdown(ctx context.Context) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if timeout := s.options.ShutdownTimeout.Duration(); timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(context.WithoutCancel(ctx), timeout)
+		defer cancel()
+	}
+
+	return s.build().Shutdown(ctx)
+}
+
+// RunAsync starts the server in new goroutine.
+//
+// If server start failed, app shutdown will be called
+func (s *Server) RunAsync() {
+	go func() {
+		if err := s.Start(); err != nil {
+			log.
+				Error().
+				Err(err).
+				Str("address", s.options.Address).
+				Msg("Run server")
+
+			// if server run failed - call app shutdown
+			appx.Cancel()
+		}
+	}()
+}
+
+// Run starts the server and waits till the end of app lifetime
+func (s *Server) Run(waitTime ...time.Duration) {
+	// run server in new goroutine
+	s.RunAsync()
+
+	// add app graceful shutdown log
+	appx.GracefulLog(func() {
+		log.
+			Info().
+			Msg("Graceful shutdown...")
+	})
+
+	// wait till the end of app lifetime
+	appx.Wait(waitTime...)
+}
+
+// prepare builds handler, registers teardown and prints routes before start
+func (s *Server) prepare() *echo.Echo {
+	handler := s.build()
+
+	// add server shutdown teardown func
+	appx.Tear(func() error {
+		return s.Shutdown(appx.Context())
+	})
+
+	// print all registered routes (only in dev mode)
+	if !s.options.NoRoutePrint && !configx.Production() && !configx.GetBool("NO_ROUTE_PRINT") {
+		log.
+			Info().
+			Int("routes_count", len(handler.Routes())).
+			Msg("Registered routes")
+
+		for idx, r := range handler.Routes() {
+			log.
+				Info().
+				Str("method", r.Method).
+				Str("path", r.Path).
+				Str("name", r.Name).
+				Msg(convert.StringFromInt(idx+1) + ". Registered route")
+		}
+	}
+
+	return handler
+}
+
+func (s *Server) startError(err error) error {
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+
+	return httpx.ErrStartServer.SetError(err)
+}
+
+func (s *Server) build() *echo.Echo {
+	s.buildOnce.Do(func() {
+		s.handler = s.newHandler()
+	})
+
+	return s.handler
+}
+
+func (s *Server) newHandler() *echo.Echo {
 	handler := echo.New()
 
+	// set server timeouts
+	handler.Server.ReadTimeout = s.options.ReadTimeout.Duration()
+	handler.Server.ReadHeaderTimeout = s.options.ReadHeaderTimeout.Duration()
+	handler.Server.WriteTimeout = s.options.WriteTimeout.Duration()
+	handler.Server.IdleTimeout = s.options.IdleTimeout.Duration()
+
+	// extract real IP only from trusted proxies
+	handler.IPExtractor = s.options.ipExtractor()
+
+	// attach server to every request
+	handler.Pre(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			ctx.Set(serverContextKey, s)
+			return next(ctx)
+		}
+	})
+
 	// add CORS middleware
-	handler.Use(middleware.CORSWithConfig(middleware.CORSConfig{
-		AllowOrigins:     []string{"*"},
-		AllowHeaders:     []string{"Content-Type", "Authorization", "X-Auth-Token"},
-		AllowCredentials: true,
-	}))
+	if !s.options.CORS.Disabled {
+		handler.Use(middleware.CORSWithConfig(s.options.CORS.config()))
+	}
+
+	// add secure headers middleware
+	if !s.options.Security.Disabled {
+		handler.Use(middleware.SecureWithConfig(s.options.Security.config()))
+	}
 
 	// add recover middleware
 	handler.Use(RecoverMiddleware())
 
+	// add body limit middleware
+	if s.bodyLimit > 0 {
+		handler.Use(BodyLimitMiddleware(s.bodyLimit))
+	}
+
+	// add response compression middleware
+	if s.options.Gzip.Enabled {
+		handler.Use(middleware.GzipWithConfig(s.options.Gzip.config()))
+	}
+
 	// register not found route
 	handler.RouteNotFound("*", func(ctx echo.Context) error {
 		return Error(ctx, newRouteNotFoundError(ctx.Request()))
@@ -49,78 +362,38 @@ func run(address string) error {
 	}
 
 	// set middlewares
-	for _, mid := range _middlewares {
+	for _, mid := range s.middlewares {
 		handler.Use(mid)
 	}
 
 	// set routes
-	for _, r := range _routes {
+	for _, r := range s.routes {
 		handler.Add(r.Method, r.Path, r.Handler, r.Middlewares...)
 	}
 
 	// set groups
-	for _, g := range _groups {
+	for _, g := range s.groups {
 		group := handler.Group(g.basePath, g.middlewares...)
 		for _, r := range g.routes {
 			group.Add(r.Method, r.Path, r.Handler, r.Middlewares...)
 		}
 	}
 
-	// add server shutdown teardown func
-	appx.Tear(func() error {
-		return handler.Shutdown(appx.Context())
-	})
-
-	// print all registered routes (only in dev mode)
-	if !configx.Production() && !configx.GetBool("NO_ROUTE_PRINT") {
-		log.
-			Info().
-			Int("routes_count", len(handler.Routes())).
-			Msg("Registered routes")
-
-		for idx, r := range handler.Routes() {
-			log.
-				Info().
-				Str("method", r.Method).
-				Str("path", r.Path).
-				Str("name", r.Name).
-				Msg(convert.StringFromInt(idx+1) + ". Registered route")
-		}
-	}
-
-	// start server
-	if err := handler.Start(address); err != nil {
-		if errors.Is(err, http.ErrServerClosed) {
-			return nil
-		}
+	return handler
+}
 
-		return httpx.ErrStartServer.SetError(err)
+// serverOf returns server which handles provided request or default server
+func serverOf(ctx echo.Context) *Server {
+	if s, ok := ctx.Get(serverContextKey).(*Server); ok && s != nil {
+		return s
 	}
 
-	return nil
+	return _server
 }
 
+// Run runs default server on provided address and waits till the end of app lifetime
 func Run(address string, waitTime ...time.Duration) {
-	// run server in new goroutine
-	go func() {
-		if err := run(address); err != nil {
-			log.
-				Error().
-				Err(err).
-				Msg("Run server")
-
-			// if server run failed - call app shutdown
-			appx.Cancel()
-		}
-	}()
-
-	// add app graceful shutdown log
-	appx.GracefulLog(func() {
-		log.
-			Info().
-			Msg("Graceful shutdown...")
-	})
-
-	// wait till the end of app lifetime
-	appx.Wait(waitTime...)
+	_server.
+		SetAddress(address).
+		Run(waitTime...)
 }
@@ -0,0 +1,204 @@
+package echox
+
+import (
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/boostgo/core/httpx"
+	"github.com/boostgo/core/log"
+	"github.com/boostgo/core/timex"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"github.com/labstack/gommon/bytes"
+)
+
+// Options contains settings of the Server.
+//
+// Could be loaded by configx package. All timeouts are optional. Zero timeout means no timeout
+type Options struct {
+	Address           string         `json:"address" yaml:"address"`
+	ReadTimeout       timex.Duration `json:"read_timeout" yaml:"readTimeout"`
+	ReadHeaderTimeout timex.Duration `json:"read_header_timeout" yaml:"readHeaderTimeout"`
+	WriteTimeout      timex.Duration `json:"write_timeout" yaml:"writeTimeout"`
+	IdleTimeout       timex.Duration `json:"idle_timeout" yaml:"idleTimeout"`
+	ShutdownTimeout   timex.Duration `json:"shutdown_timeout" yaml:"shutdownTimeout"`
+	NoRoutePrint      bool           `json:"no_route_print" yaml:"noRoutePrint"`
+
+	// BodyLimit is maximum request body size, like "2M", "512K" or "1G". Empty means no limit
+	BodyLimit string `json:"body_limit" yaml:"bodyLimit"`
+
+	// TrustedProxies is list of proxy IPs or CIDRs which are allowed to provide client IP by "X-Forwarded-For" header.
+	//
+	// If empty, client IP is taken from the connection
+	TrustedProxies []string `json:"trusted_proxies" yaml:"trustedProxies"`
+
+	CORS     CORSOptions     `json:"cors" yaml:"cors"`
+	Security SecurityOptions `json:"security" yaml:"security"`
+	Gzip     GzipOptions     `json:"gzip" yaml:"gzip"`
+}
+
+// CORSOptions describes CORS middleware settings of the Server.
+//
+// If AllowOrigins is empty, all origins are allowed without credentials.
+//
+// Wildcard origin can not be used together with AllowCredentials, in this case credentials are turned off
+type CORSOptions struct {
+	Disabled         bool     `json:"disabled" yaml:"disabled"`
+	AllowOrigins     []string `json:"allow_origins" yaml:"allowOrigins"`
+	AllowMethods     []string `json:"allow_methods" yaml:"allowMethods"`
+	AllowHeaders     []string `json:"allow_headers" yaml:"allowHeaders"`
+	ExposeHeaders    []string `json:"expose_headers" yaml:"exposeHeaders"`
+	AllowCredentials bool     `json:"allow_credentials" yaml:"allowCredentials"`
+	MaxAge           int      `json:"max_age" yaml:"maxAge"`
+}
+
+// SecurityOptions describes security response headers.
+//
+// HSTS header is sent only if HSTSMaxAge is set. Empty header value means header will not be sent
+type SecurityOptions struct {
+	Disabled              bool   `json:"disabled" yaml:"disabled"`
+	HSTSMaxAge            int    `json:"hsts_max_age" yaml:"hstsMaxAge"`
+	HSTSExcludeSubdomains bool   `json:"hsts_exclude_subdomains" yaml:"hstsExcludeSubdomains"`
+	HSTSPreload           bool   `json:"hsts_preload" yaml:"hstsPreload"`
+	ContentSecurityPolicy string `json:"content_security_policy" yaml:"contentSecurityPolicy"`
+	CSPReportOnly         bool   `json:"csp_report_only" yaml:"cspReportOnly"`
+	XFrameOptions         string `json:"x_frame_options" yaml:"xFrameOptions" default:"SAMEORIGIN"`
+	ContentTypeNosniff    string `json:"content_type_nosniff" yaml:"contentTypeNosniff" default:"nosniff"`
+	XSSProtection         string `json:"xss_protection" yaml:"xssProtection" default:"0"`
+	ReferrerPolicy        string `json:"referrer_policy" yaml:"referrerPolicy" default:"strict-origin-when-cross-origin"`
+}
+
+// GzipOptions describes response compression settings
+type GzipOptions struct {
+	Enabled   bool `json:"enabled" yaml:"enabled"`
+	Level     int  `json:"level" yaml:"level" default:"-1"`
+	MinLength int  `json:"min_length" yaml:"minLength" default:"1024"`
+}
+
+var (
+	defaultAllowHeaders = []string{
+		echo.HeaderContentType,
+		echo.HeaderAuthorization,
+		"X-Auth-Token",
+	}
+	defaultAllowMethods = []string{
+		http.MethodGet,
+		http.MethodHead,
+		http.MethodPut,
+		http.MethodPatch,
+		http.MethodPost,
+		http.MethodDelete,
+	}
+)
+
+func (o CORSOptions) config() middleware.CORSConfig {
+	config := middleware.CORSConfig{
+		AllowOrigins:     o.AllowOrigins,
+		AllowMethods:     o.AllowMethods,
+		AllowHeaders:     o.AllowHeaders,
+		ExposeHeaders:    o.ExposeHeaders,
+		AllowCredentials: o.AllowCredentials,
+		MaxAge:           o.MaxAge,
+	}
+
+	if len(config.AllowOrigins) == 0 {
+		config.AllowOrigins = []string{"*"}
+	}
+
+	if len(config.AllowMethods) == 0 {
+		config.AllowMethods = defaultAllowMethods
+	}
+
+	if len(config.AllowHeaders) == 0 {
+		config.AllowHeaders = defaultAllowHeaders
+	}
+
+	// browsers reject wildcard origin with credentials
+	if config.AllowCredentials && slices.Contains(config.AllowOrigins, "*") {
+		log.
+			Warn().
+			Strs("allow_origins", config.AllowOrigins).
+			Msg("CORS wildcard origin can not be used with credentials. Credentials are turned off")
+
+		config.AllowCredentials = false
+	}
+
+	return config
+}
+
+func (o SecurityOptions) config() middleware.SecureConfig {
+	return middleware.SecureConfig{
+		XSSProtection:         o.XSSProtection,
+		ContentTypeNosniff:    o.ContentTypeNosniff,
+		XFrameOptions:         o.XFrameOptions,
+		HSTSMaxAge:            o.HSTSMaxAge,
+		HSTSExcludeSubdomains: o.HSTSExcludeSubdomains,
+		HSTSPreloadEnabled:    o.HSTSPreload,
+		ContentSecurityPolicy: o.ContentSecurityPolicy,
+		CSPReportOnly:         o.CSPReportOnly,
+		ReferrerPolicy:        o.ReferrerPolicy,
+	}
+}
+
+func (o GzipOptions) config() middleware.GzipConfig {
+	return middleware.GzipConfig{
+		Level:     o.Level,
+		MinLength: o.MinLength,
+	}
+}
+
+// bodyLimit returns parsed body limit in bytes. Returns 0 if limit is not set and error if limit is invalid
+func (o Options) bodyLimit() (int64, error) {
+	if o.BodyLimit == "" {
+		return 0, nil
+	}
+
+	limit, err := bytes.Parse(o.BodyLimit)
+	if err != nil {
+		return 0, httpx.ErrServerOptions.
+			SetError(err).
+			AddParam("body_limit", o.BodyLimit)
+	}
+
+	return limit, nil
+}
+
+// ipExtractor returns real IP extractor which trusts only provided proxies
+func (o Options) ipExtractor() echo.IPExtractor {
+	if len(o.TrustedProxies) == 0 {
+		return echo.ExtractIPDirect()
+	}
+
+	trustOptions := []echo.TrustOption{
+		echo.TrustLoopback(false),
+		echo.TrustLinkLocal(false),
+		echo.TrustPrivateNet(false),
+	}
+
+	for _, proxy := range o.TrustedProxies {
+		if !strings.Contains(proxy, "/") {
+			if ip := net.ParseIP(proxy); ip != nil && ip.To4() != nil {
+				proxy += "/32"
+			} else {
+				proxy += "/128"
+			}
+		}
+
+		_, ipRange, err := net.ParseCIDR(proxy)
+		if err != nil {
+			log.
+				Error().
+				Err(err).
+				Str("proxy", proxy).
+				Msg("Parse trusted proxy")
+			continue
+		}
+
+		trustOptions = append(trustOptions, echo.TrustIPRange(ipRange))
+	}
+
+	return echo.ExtractIPFromXFFHeader(trustOptions...)
+}
@@ -0,0 +1,76 @@
+package echox
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/boostgo/core/httpx"
+
+	"github.com/labstack/echo/v4"
+)
+
+func TestNewServerBodyLimit(t *testing.T) {
+	tests := []struct {
+		name  string
+		limit string
+		valid bool
+	}{
+		{name: "no limit", limit: "", valid: true},
+		{name: "kilobytes", limit: "1K", valid: true},
+		{name: "megabytes", limit: "10MB", valid: true},
+		{name: "with space", limit: "10 MB", valid: true},
+		{name: "unit word", limit: "10 megabytes"},
+		{name: "unknown unit", limit: "10X"},
+		{name: "not a number", limit: "big"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewServer(Options{BodyLimit: tt.limit})
+			if tt.valid && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !tt.valid && !errors.Is(err, httpx.ErrServerOptions) {
+				t.Fatalf("expected server options error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestMustServerPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic on invalid body limit")
+		}
+	}()
+
+	MustServer(Options{BodyLimit: "10 megabytes"})
+}
+
+func TestServerBodyLimit(t *testing.T) {
+	server := MustServer(Options{BodyLimit: "1K"})
+	server.POST("/upload", func(ctx echo.Context) error {
+		if _, err := io.ReadAll(ctx.Request().Body); err != nil {
+			return Error(ctx, newParseRequestBodyError(ctx, err))
+		}
+
+		return ctx.NoContent(http.StatusNoContent)
+	})
+
+	for body, expectedStatus := range map[string]int{
+		strings.Repeat("a", 512):  http.StatusNoContent,
+		strings.Repeat("a", 2048): http.StatusRequestEntityTooLarge,
+	} {
+		recorder := httptest.NewRecorder()
+		server.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/upload", strings.NewReader(body)))
+
+		if recorder.Code != expectedStatus {
+			t.Errorf("body of %d bytes: expected status %d, got %d", len(body), expectedStatus, recorder.Code)
+		}
+	}
+}
@@ -0,0 +1,245 @@
+package echox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/boostgo/core/appx"
+	"github.com/boostgo/core/httpx"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	HeaderLastEventID = "Last-Event-ID"
+
+	defaultSSEHeartbeat = time.Second * 15
+)
+
+// SSEEvent is one Server-Sent Event.
+//
+// Data is written as is if it is string or []byte, other values are converted to JSON
+type SSEEvent struct {
+	ID    string
+	Event string
+	Data  any
+	// Retry is reconnection time hint for client
+	Retry time.Duration
+}
+
+// SSEOption modifies SSE stream settings
+type SSEOption func(options *sseOptions)
+
+type sseOptions struct {
+	heartbeat time.Duration
+	retry     time.Duration
+}
+
+// WithSSEHeartbeat sets interval of heartbeat comments which keep connection alive. By default, it is 15 seconds.
+//
+// Zero or negative interval turns heartbeats off
+func WithSSEHeartbeat(interval time.Duration) SSEOption {
+	return func(options *sseOptions) {
+		options.heartbeat = interval
+	}
+}
+
+// WithSSERetry sets reconnection time hint which is sent to client on stream start
+func WithSSERetry(retry time.Duration) SSEOption {
+	return func(options *sseOptions) {
+		options.retry = retry
+	}
+}
+
+// SSEStream writes events to client
+type SSEStream struct {
+	ctx         context.Context
+	response    *echo.Response
+	lastEventID string
+	mx          sync.Mutex
+}
+
+// Context returns stream context. It is done when client disconnects or app is stopping (appx.Context)
+func (stream *SSEStream) Context() context.Context {
+	return stream.ctx
+}
+
+// Done returns channel which is closed when stream must be stopped
+func (stream *SSEStream) Done() <-chan struct{} {
+	return stream.ctx.Done()
+}
+
+// LastEventID returns "Last-Event-ID" header sent by reconnected client. Could be used to resume stream
+func (stream *SSEStream) LastEventID() string {
+	return stream.lastEventID
+}
+
+// Send writes event and flushes it to client. Returns context error if stream is stopped
+func (stream *SSEStream) Send(event SSEEvent) error {
+	if err := stream.ctx.Err(); err != nil {
+		return err
+	}
+
+	blob, err := event.encode()
+	if err != nil {
+		return err
+	}
+
+	return stream.write(blob)
+}
+
+// Comment writes comment line which is ignored by clients
+func (stream *SSEStream) Comment(comment string) error {
+	if err := stream.ctx.Err(); err != nil {
+		return err
+	}
+
+	return stream.write([]byte(": " + comment + "\n\n"))
+}
+
+func (stream *SSEStream) write(blob []byte) error {
+	stream.mx.Lock()
+	defer stream.mx.Unlock()
+
+	if _, err := stream.response.Write(blob); err != nil {
+		return err
+	}
+
+	stream.response.Flush()
+	return nil
+}
+
+// SSE starts Server-Sent Events stream and calls handler which sends events.
+//
+// Stream is stopped when handler returns, client disconnects or app is stopping (appx.Context).
+// Handler should stop sending when stream.Done() is closed. Context errors are not returned as errors
+func SSE(ctx echo.Context, handler func(stream *SSEStream) error, opts ...SSEOption) error {
+	options := sseOptions{
+		heartbeat: defaultSSEHeartbeat,
+	}
+
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	streamCtx, cancel := streamContext(ctx)
+	defer cancel()
+
+	header := ctx.Response().Header()
+	header.Set(echo.HeaderContentType, httpx.ContentTypeEventStream)
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Connection", "keep-alive")
+	header.Set("X-Accel-Buffering", "no")
+	ctx.Response().WriteHeader(http.StatusOK)
+
+	stream := &SSEStream{
+		ctx:         streamCtx,
+		response:    ctx.Response(),
+		lastEventID: ctx.Request().Header.Get(HeaderLastEventID),
+	}
+
+	if options.retry > 0 {
+		if err := stream.write([]byte("retry: " + strconv.FormatInt(options.retry.Milliseconds(), 10) + "\n\n")); err != nil {
+			return nil
+		}
+	} else {
+		stream.response.Flush()
+	}
+
+	// heartbeat goroutine is stopped & waited before SSE returns, so it never writes to finished response
+	if options.heartbeat > 0 {
+		var heartbeat sync.WaitGroup
+		heartbeat.Add(1)
+		go func() {
+			defer heartbeat.Done()
+			stream.heartbeat(options.heartbeat)
+		}()
+		defer func() {
+			cancel()
+			heartbeat.Wait()
+		}()
+	}
+
+	if err := handler(stream); err != nil && streamCtx.Err() == nil {
+		return err
+	}
+
+	return nil
+}
+
+func (stream *SSEStream) heartbeat(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stream.ctx.Done():
+			return
+		case <-ticker.C:
+			if err := stream.Comment("heartbeat"); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (event SSEEvent) encode() ([]byte, error) {
+	var data string
+	switch value := event.Data.(type) {
+	case nil:
+	case string:
+		data = value
+	case []byte:
+		data = string(value)
+	default:
+		blob, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+
+		data = string(blob)
+	}
+
+	var buffer bytes.Buffer
+	if event.ID != "" {
+		buffer.WriteString("id: " + sseLine(event.ID) + "\n")
+	}
+
+	if event.Event != "" {
+		buffer.WriteString("event: " + sseLine(event.Event) + "\n")
+	}
+
+	if event.Retry > 0 {
+		buffer.WriteString("retry: " + strconv.FormatInt(event.Retry.Milliseconds(), 10) + "\n")
+	}
+
+	// every line of multiline data must have own "data:" prefix
+	for _, line := range strings.Split(data, "\n") {
+		buffer.WriteString("data: " + strings.TrimSuffix(line, "\r") + "\n")
+	}
+
+	buffer.WriteString("\n")
+	return buffer.Bytes(), nil
+}
+
+// sseLine removes line breaks which could break event fields
+func sseLine(value string) string {
+	return strings.NewReplacer("\r", "", "\n", "").Replace(value)
+}
+
+// streamContext returns request context which is also canceled when app is stopping
+func streamContext(ctx echo.Context) (context.Context, context.CancelFunc) {
+	native, cancel := context.WithCancel(Context(ctx))
+	stop := context.AfterFunc(appx.Context(), cancel)
+
+	return native, func() {
+		stop()
+		cancel()
+	}
+}
@@ -0,0 +1,187 @@
+package echox
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/boostgo/core/httpx"
+
+	"github.com/labstack/echo/v4"
+)
+
+func TestSSEEventEncode(t *testing.T) {
+	tests := []struct {
+		name     string
+		event    SSEEvent
+		expected string
+	}{
+		{
+			name:     "string data",
+			event:    SSEEvent{Data: "hello"},
+			expected: "data: hello\n\n",
+		},
+		{
+			name:     "all fields",
+			event:    SSEEvent{ID: "1", Event: "message", Data: []byte("hello"), Retry: time.Second},
+			expected: "id: 1\nevent: message\nretry: 1000\ndata: hello\n\n",
+		},
+		{
+			name:     "json data",
+			event:    SSEEvent{Data: map[string]int{"id": 1}},
+			expected: "data: {\"id\":1}\n\n",
+		},
+		{
+			name:     "multiline data",
+			event:    SSEEvent{Data: "first\r\nsecond"},
+			expected: "data: first\ndata: second\n\n",
+		},
+		{
+			name:     "line breaks in fields",
+			event:    SSEEvent{ID: "1\n2", Event: "a\r\nb"},
+			expected: "id: 12\nevent: ab\ndata: \n\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blob, err := tt.event.encode()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if string(blob) != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, string(blob))
+			}
+		})
+	}
+}
+
+func TestSSE(t *testing.T) {
+	var eventsBeforeHandler []string
+	recorder := newFlushRecorder()
+
+	e := echo.New()
+	e.GET("/events", func(ctx echo.Context) error {
+		return SSE(ctx, func(stream *SSEStream) error {
+			// headers are committed & flushed before handler is called
+			eventsBeforeHandler = recorder.Events()
+
+			if stream.LastEventID() != "7" {
+				t.Errorf("unexpected last event id: %q", stream.LastEventID())
+			}
+
+			return stream.Send(SSEEvent{ID: "8", Data: "hello"})
+		}, WithSSEHeartbeat(0), WithSSERetry(3*time.Second))
+	})
+
+	request := httptest.NewRequest(http.MethodGet, "/events", nil)
+	request.Header.Set(HeaderLastEventID, "7")
+	e.ServeHTTP(recorder, request)
+
+	if recorder.Header().Get(echo.HeaderContentType) != httpx.ContentTypeEventStream || recorder.Header().Get("Cache-Control") != "no-cache" {
+		t.Errorf("unexpected headers: %v", recorder.Header())
+	}
+
+	if expected := "header write flush"; strings.Join(eventsBeforeHandler, " ") != expected {
+		t.Errorf("expected events %q before handler, got %v", expected, eventsBeforeHandler)
+	}
+
+	if expected := "header write flush write flush"; strings.Join(recorder.Events(), " ") != expected {
+		t.Errorf("expected events %q, got %v", expected, recorder.Events())
+	}
+
+	if body := recorder.Body.String(); body != "retry: 3000\n\nid: 8\ndata: hello\n\n" {
+		t.Errorf("unexpected body: %q", body)
+	}
+}
+
+func TestSSEHeartbeatStopped(t *testing.T) {
+	recorder := newFlushRecorder()
+	heartbeats := func() int {
+		return strings.Count(recorder.Body.String(), ": heartbeat\n\n")
+	}
+
+	e := echo.New()
+	e.GET("/events", func(ctx echo.Context) error {
+		return SSE(ctx, func(stream *SSEStream) error {
+			// wait for some heartbeats, they are written under stream lock, so events are read safely
+			for len(recorder.Events()) < 7 {
+				time.Sleep(time.Millisecond)
+			}
+
+			return nil
+		}, WithSSEHeartbeat(time.Millisecond))
+	})
+
+	e.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/events", nil))
+
+	written := heartbeats()
+	if written < 3 {
+		t.Fatalf("expected heartbeats, got %d", written)
+	}
+
+	// heartbeat goroutine is stopped before SSE returns, so nothing is written to finished response
+	time.Sleep(20 * time.Millisecond)
+	if heartbeats() != written {
+		t.Errorf("expected no heartbeats after stream is finished, got %d more", heartbeats()-written)
+	}
+}
+
+func TestSSEClientDisconnect(t *testing.T) {
+	stopped := make(chan error, 1)
+
+	e := echo.New()
+	e.GET("/events", func(ctx echo.Context) error {
+		return SSE(ctx, func(stream *SSEStream) error {
+			if err := stream.Send(SSEEvent{Data: "hello"}); err != nil {
+				return err
+			}
+
+			<-stream.Done()
+			stopped <- stream.Send(SSEEvent{Data: "late"})
+			return stream.Context().Err()
+		}, WithSSEHeartbeat(5*time.Millisecond))
+	})
+
+	server := httptest.NewServer(e)
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/events", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer response.Body.Close()
+
+	// event & heartbeat are flushed to client while handler is still running
+	reader := bufio.NewReader(response.Body)
+	for _, expected := range []string{"data: hello\n", "\n", ": heartbeat\n"} {
+		line, err := reader.ReadString('\n')
+		if err != nil || line != expected {
+			t.Fatalf("expected line %q, got %q (%v)", expected, line, err)
+		}
+	}
+
+	cancel()
+
+	select {
+	case err = <-stopped:
+		if err == nil {
+			t.Error("expected send error after client disconnect")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("stream is not stopped after client disconnect")
+	}
+}
@@ -5,5 +5,9 @@ import (
 )
 
 func Swagger(path string) {
-	GET(path, echoSwagger.WrapHandler)
+	_server.Swagger(path)
+}
+
+func (s *Server) Swagger(path string) {
+	s.GET(path, echoSwagger.WrapHandler)
 }
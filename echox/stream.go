@@ -0,0 +1,146 @@
+package echox
+
+import (
+	"encoding/json"
+	"iter"
+	"mime"
+	"net/http"
+
+	"github.com/boostgo/core/httpx"
+
+	"github.com/labstack/echo/v4"
+)
+
+const defaultStreamFlushEvery = 100
+
+// StreamOption modifies streaming response settings
+type StreamOption func(options *streamOptions)
+
+type streamOptions struct {
+	flushEvery int
+	status     int
+	fileName   string
+}
+
+// WithStreamFlushEvery sets how many items are written before flush. By default, it is 100
+func WithStreamFlushEvery(items int) StreamOption {
+	return func(options *streamOptions) {
+		if items > 0 {
+			options.flushEvery = items
+		}
+	}
+}
+
+// WithStreamStatus sets response status. By default, it is 200
+func WithStreamStatus(status int) StreamOption {
+	return func(options *streamOptions) {
+		options.status = status
+	}
+}
+
+// WithStreamAttachment sets "Content-Disposition" header, so stream is downloaded as file
+func WithStreamAttachment(fileName string) StreamOption {
+	return func(options *streamOptions) {
+		options.fileName = fileName
+	}
+}
+
+// StreamNDJSON writes items from iterator as newline delimited JSON without loading all items into memory.
+//
+// If iterator returns error before the first item, error response is returned.
+// After the first item response is already sent, so stream is just stopped and error is returned for logging.
+// Stream is stopped when client disconnects or app is stopping (appx.Context)
+func StreamNDJSON[T any](ctx echo.Context, items iter.Seq2[T, error], opts ...StreamOption) error {
+	return streamItems(ctx, httpx.ContentTypeNDJSON, items, func(response *echo.Response, item T) error {
+		blob, err := json.Marshal(item)
+		if err != nil {
+			return err
+		}
+
+		if _, err = response.Write(blob); err != nil {
+			return err
+		}
+
+		_, err = response.Write([]byte{'\n'})
+		return err
+	}, opts...)
+}
+
+// StreamChunks writes raw chunks from iterator (for example CSV rows) using chunked transfer encoding.
+//
+// Errors are handled like in StreamNDJSON
+func StreamChunks(ctx echo.Context, contentType string, chunks iter.Seq2[[]byte, error], opts ...StreamOption) error {
+	return streamItems(ctx, contentType, chunks, func(response *echo.Response, chunk []byte) error {
+		_, err := response.Write(chunk)
+		return err
+	}, opts...)
+}
+
+func streamItems[T any](
+	ctx echo.Context,
+	contentType string,
+	items iter.Seq2[T, error],
+	write func(response *echo.Response, item T) error,
+	opts ...StreamOption,
+) error {
+	options := streamOptions{
+		flushEvery: defaultStreamFlushEvery,
+		status:     http.StatusOK,
+	}
+
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	streamCtx, cancel := streamContext(ctx)
+	defer cancel()
+
+	response := ctx.Response()
+	writeHeader := func() {
+		response.Header().Set(echo.HeaderContentType, contentType)
+		if options.fileName != "" {
+			// file name is quoted & encoded, so it could not break header
+			response.Header().Set(echo.HeaderContentDisposition, mime.FormatMediaType("attachment", map[string]string{
+				"filename": options.fileName,
+			}))
+		}
+
+		response.WriteHeader(options.status)
+	}
+
+	written := 0
+
+	for item, err := range items {
+		if err != nil {
+			if !response.Committed {
+				return Error(ctx, err)
+			}
+
+			return err
+		}
+
+		if streamCtx.Err() != nil {
+			return nil
+		}
+
+		if !response.Committed {
+			writeHeader()
+		}
+
+		if err = write(response, item); err != nil {
+			return err
+		}
+
+		written++
+		if written%options.flushEvery == 0 {
+			response.Flush()
+		}
+	}
+
+	if !response.Committed {
+		writeHeader()
+	}
+
+	response.Flush()
+	return nil
+}
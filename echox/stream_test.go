@@ -0,0 +1,179 @@
+package echox
+
+import (
+	"errors"
+	"iter"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/boostgo/core/errorx"
+	"github.com/boostgo/core/httpx"
+
+	"github.com/labstack/echo/v4"
+)
+
+// flushRecorder records order of header, write & flush calls
+type flushRecorder struct {
+	*httptest.ResponseRecorder
+	events []string
+	mx     sync.Mutex
+}
+
+func newFlushRecorder() *flushRecorder {
+	return &flushRecorder{
+		ResponseRecorder: httptest.NewRecorder(),
+	}
+}
+
+func (recorder *flushRecorder) WriteHeader(statusCode int) {
+	recorder.record("header")
+	recorder.ResponseRecorder.WriteHeader(statusCode)
+}
+
+func (recorder *flushRecorder) Write(b []byte) (int, error) {
+	recorder.record("write")
+	return recorder.ResponseRecorder.Write(b)
+}
+
+func (recorder *flushRecorder) Flush() {
+	recorder.record("flush")
+	recorder.ResponseRecorder.Flush()
+}
+
+func (recorder *flushRecorder) record(event string) {
+	recorder.mx.Lock()
+	defer recorder.mx.Unlock()
+
+	recorder.events = append(recorder.events, event)
+}
+
+func (recorder *flushRecorder) Events() []string {
+	recorder.mx.Lock()
+	defer recorder.mx.Unlock()
+
+	return append([]string(nil), recorder.events...)
+}
+
+// seq returns iterator of provided items, which returns err after items (if err is set)
+func seq[T any](err error, items ...T) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for _, item := range items {
+			if !yield(item, nil) {
+				return
+			}
+		}
+
+		if err != nil {
+			var empty T
+			yield(empty, err)
+		}
+	}
+}
+
+func serveStream(handler echo.HandlerFunc, middlewares ...echo.MiddlewareFunc) *flushRecorder {
+	e := echo.New()
+	e.GET("/stream", handler, middlewares...)
+
+	recorder := newFlushRecorder()
+	e.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/stream", nil))
+	return recorder
+}
+
+func TestStreamNDJSON(t *testing.T) {
+	type item struct {
+		ID int `json:"id"`
+	}
+
+	recorder := serveStream(func(ctx echo.Context) error {
+		return StreamNDJSON(ctx, seq[item](nil, item{ID: 1}, item{ID: 2}, item{ID: 3}), WithStreamFlushEvery(2))
+	})
+
+	if recorder.Code != http.StatusOK || recorder.Header().Get(echo.HeaderContentType) != httpx.ContentTypeNDJSON {
+		t.Fatalf("unexpected response: %d %v", recorder.Code, recorder.Header())
+	}
+
+	if body := recorder.Body.String(); body != "{\"id\":1}\n{\"id\":2}\n{\"id\":3}\n" {
+		t.Errorf("unexpected body: %q", body)
+	}
+
+	// header is written before the first item, every 2 items are flushed & the rest is flushed at the end
+	expected := "header write write write write flush write write flush"
+	if events := strings.Join(recorder.Events(), " "); events != expected {
+		t.Errorf("expected events %q, got %q", expected, events)
+	}
+}
+
+func TestStreamChunks(t *testing.T) {
+	recorder := serveStream(func(ctx echo.Context) error {
+		return StreamChunks(ctx, "text/csv", seq[[]byte](nil, []byte("id\n"), []byte("1\n")),
+			WithStreamStatus(http.StatusAccepted),
+			WithStreamAttachment(`report "1".csv`),
+		)
+	})
+
+	if recorder.Code != http.StatusAccepted || recorder.Body.String() != "id\n1\n" {
+		t.Fatalf("unexpected response: %d %q", recorder.Code, recorder.Body.String())
+	}
+
+	if disposition := recorder.Header().Get(echo.HeaderContentDisposition); disposition != `attachment; filename="report \"1\".csv"` {
+		t.Errorf("unexpected content disposition: %q", disposition)
+	}
+}
+
+func TestStreamEmpty(t *testing.T) {
+	recorder := serveStream(func(ctx echo.Context) error {
+		return StreamChunks(ctx, "text/csv", seq[[]byte](nil))
+	})
+
+	if recorder.Code != http.StatusOK || recorder.Header().Get(echo.HeaderContentType) != "text/csv" {
+		t.Fatalf("unexpected response: %d %v", recorder.Code, recorder.Header())
+	}
+
+	if events := strings.Join(recorder.Events(), " "); events != "header flush" {
+		t.Errorf("expected header & flush, got %q", events)
+	}
+}
+
+func TestStreamError(t *testing.T) {
+	t.Run("before first item", func(t *testing.T) {
+		recorder := serveStream(func(ctx echo.Context) error {
+			return StreamChunks(ctx, "text/csv", seq[[]byte](errorx.ErrNotFound))
+		})
+
+		// response is not committed yet, so error response is returned
+		if recorder.Code != http.StatusNotFound || recorder.Header().Get(echo.HeaderContentType) == "text/csv" {
+			t.Errorf("expected error response, got %d %v", recorder.Code, recorder.Header())
+		}
+	})
+
+	t.Run("after first item", func(t *testing.T) {
+		var streamErr error
+		recorder := serveStream(func(ctx echo.Context) error {
+			streamErr = StreamChunks(ctx, "text/csv", seq[[]byte](errorx.ErrNotFound, []byte("id\n")))
+			return nil
+		})
+
+		if !errors.Is(streamErr, errorx.ErrNotFound) {
+			t.Errorf("expected stream error, got %v", streamErr)
+		}
+
+		if recorder.Code != http.StatusOK || recorder.Body.String() != "id\n" {
+			t.Errorf("expected stopped stream, got %d %q", recorder.Code, recorder.Body.String())
+		}
+	})
+}
+
+func TestStreamCacheMiddleware(t *testing.T) {
+	// cache buffers response, so flushes of the stream must not panic
+	recorder := serveStream(func(ctx echo.Context) error {
+		return StreamChunks(ctx, "text/csv", seq[[]byte](nil, []byte("id\n"), []byte("1\n")), WithStreamFlushEvery(1))
+	}, CacheMiddleware(time.Minute, &memoryCacheDistributor{}))
+
+	if recorder.Code != http.StatusOK || recorder.Body.String() != "id\n1\n" {
+		t.Errorf("unexpected response: %d %q", recorder.Code, recorder.Body.String())
+	}
+}
@@ -0,0 +1,248 @@
+package echox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/boostgo/core/errorx"
+	"github.com/boostgo/core/httpx"
+	"github.com/boostgo/core/trace"
+
+	"github.com/gorilla/websocket"
+)
+
+const WSMessageTypeError = "error"
+
+var (
+	ErrWSSendQueueFull      = errorx.New("websocket.send_queue_full").SetError(errorx.ErrTooManyRequests)
+	ErrWSConnectionClosed   = errorx.New("websocket.connection_closed")
+	ErrWSHubClosed          = errorx.New("websocket.hub_closed").SetError(errorx.ErrServiceUnavailable)
+	ErrWSInvalidMessage     = errorx.New("websocket.invalid_message").SetError(errorx.ErrBadRequest)
+	ErrWSUnknownMessageType = errorx.New("websocket.unknown_message_type").SetError(errorx.ErrBadRequest)
+)
+
+// WSMessage is JSON message of websocket connection. Messages are routed to handlers by Type
+type WSMessage struct {
+	Type    string          `json:"type"`
+	Room    string          `json:"room,omitempty"`
+	TraceID string          `json:"trace_id,omitempty"`
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+// NewWSMessage creates message with data converted to JSON
+func NewWSMessage(messageType string, data any) (WSMessage, error) {
+	message := WSMessage{
+		Type: messageType,
+	}
+
+	if data == nil {
+		return message, nil
+	}
+
+	blob, err := json.Marshal(data)
+	if err != nil {
+		return message, err
+	}
+
+	message.Data = blob
+	return message, nil
+}
+
+// Parse converts message data to export object
+func (message WSMessage) Parse(export any) error {
+	if err := json.Unmarshal(message.Data, export); err != nil {
+		return ErrWSInvalidMessage.SetError(errorx.ErrBadRequest, err)
+	}
+
+	return nil
+}
+
+// WSHandler handles message of provided type.
+//
+// Context contains claims of authenticated connection and trace id of the message.
+// Returned error is sent to client as "error" message
+type WSHandler func(ctx context.Context, conn *WSConn, message WSMessage) error
+
+// WSConn is websocket connection registered in WSHub
+type WSConn struct {
+	id     string
+	hub    *WSHub
+	conn   *websocket.Conn
+	ctx    context.Context
+	cancel context.CancelFunc
+	send   chan []byte
+	done   chan struct{}
+	rooms  map[string]struct{}
+
+	closeCode int
+	closeOnce sync.Once
+}
+
+// ID returns unique connection id
+func (conn *WSConn) ID() string {
+	return conn.id
+}
+
+// Context returns connection context. It contains claims of authenticated connection (see AuthMiddleware)
+// and is canceled when connection is closed
+func (conn *WSConn) Context() context.Context {
+	return conn.ctx
+}
+
+// Send puts message to connection send queue.
+//
+// If queue is full (client is too slow), connection is closed and ErrWSSendQueueFull is returned
+func (conn *WSConn) Send(message WSMessage) error {
+	blob, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	return conn.enqueue(blob)
+}
+
+// Reply sends message with trace id from context (handler context)
+func (conn *WSConn) Reply(ctx context.Context, messageType string, data any) error {
+	message, err := NewWSMessage(messageType, data)
+	if err != nil {
+		return err
+	}
+
+	message.TraceID = trace.Get(ctx)
+	return conn.Send(message)
+}
+
+// Join adds connection to room
+func (conn *WSConn) Join(room string) {
+	conn.hub.join(conn, room)
+}
+
+// Leave removes connection from room
+func (conn *WSConn) Leave(room string) {
+	conn.hub.leave(conn, room)
+}
+
+// Close closes connection with "normal closure" code
+func (conn *WSConn) Close() {
+	conn.close(websocket.CloseNormalClosure)
+}
+
+func (conn *WSConn) enqueue(blob []byte) error {
+	select {
+	case <-conn.done:
+		return ErrWSConnectionClosed
+	default:
+	}
+
+	select {
+	case conn.send <- blob:
+		return nil
+	default:
+		conn.close(websocket.CloseTryAgainLater)
+		return ErrWSSendQueueFull.AddParam("connection", conn.id)
+	}
+}
+
+func (conn *WSConn) close(code int) {
+	conn.closeOnce.Do(func() {
+		conn.closeCode = code
+		conn.cancel()
+		close(conn.done)
+		conn.hub.unregister(conn)
+	})
+}
+
+// readPump reads messages and calls handlers. Messages of one connection are handled one by one
+func (conn *WSConn) readPump() {
+	defer conn.close(websocket.CloseNormalClosure)
+
+	options := conn.hub.options
+	conn.conn.SetReadLimit(options.maxMessageSize)
+	_ = conn.conn.SetReadDeadline(time.Now().Add(options.pongWait()))
+	conn.conn.SetPongHandler(func(string) error {
+		return conn.conn.SetReadDeadline(time.Now().Add(options.pongWait()))
+	})
+
+	for {
+		_, blob, err := conn.conn.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		var message WSMessage
+		if err = json.Unmarshal(blob, &message); err != nil {
+			conn.sendError(trace.SetID(conn.ctx, trace.Generate(conn.ctx)), ErrWSInvalidMessage.SetError(errorx.ErrBadRequest, err))
+			continue
+		}
+
+		conn.handle(message)
+	}
+}
+
+func (conn *WSConn) handle(message WSMessage) {
+	if message.TraceID == "" {
+		message.TraceID = trace.Generate(conn.ctx)
+	}
+	ctx := trace.SetID(conn.ctx, message.TraceID)
+
+	handler, ok := conn.hub.handler(message.Type)
+	if !ok {
+		conn.sendError(ctx, ErrWSUnknownMessageType.AddParam("type", message.Type))
+		return
+	}
+
+	if err := errorx.Try(func() error {
+		return handler(ctx, conn, message)
+	}); err != nil {
+		conn.sendError(ctx, err)
+	}
+}
+
+func (conn *WSConn) sendError(ctx context.Context, err error) {
+	status := httpx.StatusCodeByError(err)
+
+	var convertedError *errorx.Error
+	if !errors.As(err, &convertedError) {
+		convertedError = httpx.ErrorByStatusCode(status)
+	}
+
+	_ = conn.Reply(ctx, WSMessageTypeError, httpx.NewFailureResponse(convertedError, status, trace.Get(ctx)))
+}
+
+// writePump writes queued messages & pings. Closes connection when it is done
+func (conn *WSConn) writePump() {
+	options := conn.hub.options
+	ticker := time.NewTicker(options.pingInterval)
+	defer func() {
+		ticker.Stop()
+		_ = conn.conn.Close()
+		conn.hub.wg.Done()
+	}()
+
+	for {
+		select {
+		case blob := <-conn.send:
+			_ = conn.conn.SetWriteDeadline(time.Now().Add(options.writeTimeout))
+			if err := conn.conn.WriteMessage(websocket.TextMessage, blob); err != nil {
+				conn.close(websocket.CloseAbnormalClosure)
+				return
+			}
+		case <-ticker.C:
+			_ = conn.conn.SetWriteDeadline(time.Now().Add(options.writeTimeout))
+			if err := conn.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				conn.close(websocket.CloseAbnormalClosure)
+				return
+			}
+		case <-conn.done:
+			_ = conn.conn.WriteControl(
+				websocket.CloseMessage,
+				websocket.FormatCloseMessage(conn.closeCode, ""),
+				time.Now().Add(options.writeTimeout),
+			)
+			return
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package echox
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/boostgo/core/redis"
+)
+
+const defaultWSRedisChannel = "echox:websocket"
+
+// WSEnvelope is broadcast message sent between hubs
+type WSEnvelope struct {
+	HubID   string    `json:"hub_id"`
+	Room    string    `json:"room,omitempty"`
+	Message WSMessage `json:"message"`
+}
+
+// WSBroker broadcasts messages between hubs of several app instances
+type WSBroker interface {
+	Publish(ctx context.Context, envelope WSEnvelope) error
+	// Subscribe calls receive for every published envelope till context is done
+	Subscribe(ctx context.Context, receive func(envelope WSEnvelope)) error
+}
+
+type redisWSBroker struct {
+	client  redis.Client
+	channel string
+}
+
+// NewRedisWSBroker creates broker based on redis pub/sub
+func NewRedisWSBroker(client redis.Client, channel ...string) WSBroker {
+	broker := &redisWSBroker{
+		client:  client,
+		channel: defaultWSRedisChannel,
+	}
+
+	if len(channel) > 0 && channel[0] != "" {
+		broker.channel = channel[0]
+	}
+
+	return broker
+}
+
+func (broker *redisWSBroker) Publish(ctx context.Context, envelope WSEnvelope) error {
+	client, err := broker.client.Client(ctx)
+	if err != nil {
+		return err
+	}
+
+	blob, err := json.Marshal(envelope)
+	if err != nil {
+		return err
+	}
+
+	return client.Publish(ctx, broker.channel, blob).Err()
+}
+
+func (broker *redisWSBroker) Subscribe(ctx context.Context, receive func(envelope WSEnvelope)) error {
+	client, err := broker.client.Client(ctx)
+	if err != nil {
+		return err
+	}
+
+	subscription := client.Subscribe(ctx, broker.channel)
+	defer subscription.Close()
+
+	// wait subscription confirmation, so connection errors are returned
+	if _, err = subscription.Receive(ctx); err != nil {
+		return err
+	}
+
+	messages := subscription.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case message, ok := <-messages:
+			if !ok {
+				return nil
+			}
+
+			var envelope WSEnvelope
+			if err = json.Unmarshal([]byte(message.Payload), &envelope); err != nil {
+				continue
+			}
+
+			receive(envelope)
+		}
+	}
+}
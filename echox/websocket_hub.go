@@ -0,0 +1,441 @@
+package echox
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/boostgo/core/appx"
+	"github.com/boostgo/core/authx"
+	"github.com/boostgo/core/log"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	defaultWSSendQueue      = 256
+	defaultWSPingInterval   = time.Second * 30
+	defaultWSWriteTimeout   = time.Second * 10
+	defaultWSMaxMessageSize = 1 << 20
+	defaultWSBrokerRetry    = time.Second
+)
+
+// WSOption modifies WSHub settings
+type WSOption func(options *wsOptions)
+
+type wsOptions struct {
+	sendQueue      int
+	pingInterval   time.Duration
+	writeTimeout   time.Duration
+	maxMessageSize int64
+	checkOrigin    func(request *http.Request) bool
+	broker         WSBroker
+	onConnect      func(conn *WSConn)
+	onDisconnect   func(conn *WSConn)
+}
+
+// pongWait is time to wait pong after ping
+func (options wsOptions) pongWait() time.Duration {
+	return options.pingInterval + options.pingInterval/2
+}
+
+// WithWSSendQueue sets per-connection send queue size. If queue is full, slow connection is closed. By default, it is 256
+func WithWSSendQueue(size int) WSOption {
+	return func(options *wsOptions) {
+		if size > 0 {
+			options.sendQueue = size
+		}
+	}
+}
+
+// WithWSPingInterval sets ping interval. Connection is closed if there is no pong in 1.5 intervals. By default, it is 30 seconds
+func WithWSPingInterval(interval time.Duration) WSOption {
+	return func(options *wsOptions) {
+		if interval > 0 {
+			options.pingInterval = interval
+		}
+	}
+}
+
+// WithWSWriteTimeout sets timeout of writing one message. By default, it is 10 seconds
+func WithWSWriteTimeout(timeout time.Duration) WSOption {
+	return func(options *wsOptions) {
+		if timeout > 0 {
+			options.writeTimeout = timeout
+		}
+	}
+}
+
+// WithWSMaxMessageSize sets max size of incoming message in bytes. By default, it is 1MB
+func WithWSMaxMessageSize(size int64) WSOption {
+	return func(options *wsOptions) {
+		if size > 0 {
+			options.maxMessageSize = size
+		}
+	}
+}
+
+// WithWSCheckOrigin sets origin check of upgrade request. By default, only same origin is allowed
+func WithWSCheckOrigin(checkOrigin func(request *http.Request) bool) WSOption {
+	return func(options *wsOptions) {
+		options.checkOrigin = checkOrigin
+	}
+}
+
+// WithWSBroker sets broker for broadcasting messages between hubs of several app instances
+func WithWSBroker(broker WSBroker) WSOption {
+	return func(options *wsOptions) {
+		options.broker = broker
+	}
+}
+
+// WithWSOnConnect sets function which is called on new connection
+func WithWSOnConnect(onConnect func(conn *WSConn)) WSOption {
+	return func(options *wsOptions) {
+		options.onConnect = onConnect
+	}
+}
+
+// WithWSOnDisconnect sets function which is called when connection is closed
+func WithWSOnDisconnect(onDisconnect func(conn *WSConn)) WSOption {
+	return func(options *wsOptions) {
+		options.onDisconnect = onDisconnect
+	}
+}
+
+// WSHub keeps websocket connections, routes incoming messages by type and broadcasts messages to rooms.
+//
+// Hub is closed (all connections are closed with "going away" code) on app shutdown (appx)
+type WSHub struct {
+	id       string
+	options  wsOptions
+	upgrader websocket.Upgrader
+	ctx      context.Context
+	cancel   context.CancelFunc
+
+	handlers    map[string]WSHandler
+	connections map[string]*WSConn
+	rooms       map[string]map[string]*WSConn
+	closed      bool
+	mx          sync.RWMutex
+	wg          sync.WaitGroup
+}
+
+// NewWSHub creates websocket hub.
+//
+// Hub handler must be registered as GET route, authentication could be done by AuthMiddleware before it
+// (browsers could not set headers to websocket requests, so use WithAuthQuery or WithAuthCookie).
+//
+//	hub := echox.NewWSHub()
+//	hub.On("chat.join", func(ctx context.Context, conn *echox.WSConn, message echox.WSMessage) error {
+//		conn.Join(message.Room)
+//		return nil
+//	})
+//	router.GET("/ws", hub.Handler(), echox.AuthMiddleware(parser, echox.WithAuthQuery("access_token")))
+func NewWSHub(opts ...WSOption) *WSHub {
+	options := wsOptions{
+		sendQueue:      defaultWSSendQueue,
+		pingInterval:   defaultWSPingInterval,
+		writeTimeout:   defaultWSWriteTimeout,
+		maxMessageSize: defaultWSMaxMessageSize,
+	}
+
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	ctx, cancel := context.WithCancel(appx.Context())
+	hub := &WSHub{
+		id:      uuid.NewString(),
+		options: options,
+		upgrader: websocket.Upgrader{
+			CheckOrigin: options.checkOrigin,
+		},
+		ctx:         ctx,
+		cancel:      cancel,
+		handlers:    make(map[string]WSHandler),
+		connections: make(map[string]*WSConn),
+		rooms:       make(map[string]map[string]*WSConn),
+	}
+
+	appx.Tear(func() error {
+		hub.Close()
+		return nil
+	})
+
+	if options.broker != nil {
+		go hub.subscribe()
+	}
+
+	return hub
+}
+
+// On registers handler of messages with provided type
+func (hub *WSHub) On(messageType string, handler WSHandler) *WSHub {
+	hub.mx.Lock()
+	defer hub.mx.Unlock()
+
+	hub.handlers[messageType] = handler
+	return hub
+}
+
+// Handler returns echo handler which upgrades request to websocket connection and serves it
+func (hub *WSHub) Handler() echo.HandlerFunc {
+	return func(ctx echo.Context) error {
+		if hub.isClosed() {
+			return Error(ctx, ErrWSHubClosed)
+		}
+
+		ws, err := hub.upgrader.Upgrade(ctx.Response(), ctx.Request(), nil)
+		if err != nil {
+			// upgrader already wrote error response
+			return nil
+		}
+
+		// connection context is not request context: it lives till connection is closed,
+		// contains claims and gets own trace id for every message
+		connCtx, cancel := context.WithCancel(hub.ctx)
+		if claims, ok := authx.ClaimsAny(Context(ctx)); ok {
+			connCtx = authx.SetClaims(connCtx, claims)
+		}
+
+		conn := &WSConn{
+			id:        uuid.NewString(),
+			hub:       hub,
+			conn:      ws,
+			ctx:       connCtx,
+			cancel:    cancel,
+			send:      make(chan []byte, hub.options.sendQueue),
+			done:      make(chan struct{}),
+			rooms:     make(map[string]struct{}),
+			closeCode: websocket.CloseNormalClosure,
+		}
+
+		if !hub.register(conn) {
+			cancel()
+			_ = ws.WriteControl(
+				websocket.CloseMessage,
+				websocket.FormatCloseMessage(websocket.CloseGoingAway, ""),
+				time.Now().Add(hub.options.writeTimeout),
+			)
+			return ws.Close()
+		}
+
+		if hub.options.onConnect != nil {
+			hub.options.onConnect(conn)
+		}
+
+		go conn.writePump()
+		conn.readPump()
+		return nil
+	}
+}
+
+// Count returns count of connections
+func (hub *WSHub) Count() int {
+	hub.mx.RLock()
+	defer hub.mx.RUnlock()
+
+	return len(hub.connections)
+}
+
+// Connection returns connection by id
+func (hub *WSHub) Connection(id string) (*WSConn, bool) {
+	hub.mx.RLock()
+	defer hub.mx.RUnlock()
+
+	conn, ok := hub.connections[id]
+	return conn, ok
+}
+
+// Broadcast sends message to all connections of all hubs (if broker is set)
+func (hub *WSHub) Broadcast(ctx context.Context, message WSMessage) error {
+	return hub.BroadcastRoom(ctx, "", message)
+}
+
+// BroadcastRoom sends message to all connections of the room in all hubs (if broker is set). Empty room means all connections
+func (hub *WSHub) BroadcastRoom(ctx context.Context, room string, message WSMessage) error {
+	if room != "" {
+		message.Room = room
+	}
+
+	blob, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	hub.deliver(room, blob)
+
+	if hub.options.broker == nil {
+		return nil
+	}
+
+	return hub.options.broker.Publish(ctx, WSEnvelope{
+		HubID:   hub.id,
+		Room:    room,
+		Message: message,
+	})
+}
+
+// Close closes all connections with "going away" code and stops broker subscription.
+//
+// Waits till all connections are closed
+func (hub *WSHub) Close() {
+	hub.mx.Lock()
+	if hub.closed {
+		hub.mx.Unlock()
+		return
+	}
+
+	hub.closed = true
+	connections := make([]*WSConn, 0, len(hub.connections))
+	for _, conn := range hub.connections {
+		connections = append(connections, conn)
+	}
+	hub.mx.Unlock()
+
+	for _, conn := range connections {
+		conn.close(websocket.CloseGoingAway)
+	}
+
+	hub.cancel()
+	hub.wg.Wait()
+}
+
+// deliver sends message to local connections of the room (or all connections if room is empty)
+func (hub *WSHub) deliver(room string, blob []byte) {
+	hub.mx.RLock()
+	connections := make([]*WSConn, 0)
+	if room == "" {
+		for _, conn := range hub.connections {
+			connections = append(connections, conn)
+		}
+	} else {
+		for _, conn := range hub.rooms[room] {
+			connections = append(connections, conn)
+		}
+	}
+	hub.mx.RUnlock()
+
+	for _, conn := range connections {
+		// slow connections are closed by enqueue
+		_ = conn.enqueue(blob)
+	}
+}
+
+func (hub *WSHub) subscribe() {
+	for hub.ctx.Err() == nil {
+		err := hub.options.broker.Subscribe(hub.ctx, func(envelope WSEnvelope) {
+			// messages of this hub are already delivered
+			if envelope.HubID == hub.id {
+				return
+			}
+
+			blob, err := json.Marshal(envelope.Message)
+			if err != nil {
+				return
+			}
+
+			hub.deliver(envelope.Room, blob)
+		})
+		if err == nil || hub.ctx.Err() != nil {
+			return
+		}
+
+		log.
+			Error().
+			Ctx(hub.ctx).
+			Err(err).
+			Msg("WebSocket hub broker subscription")
+
+		select {
+		case <-hub.ctx.Done():
+			return
+		case <-time.After(defaultWSBrokerRetry):
+		}
+	}
+}
+
+func (hub *WSHub) handler(messageType string) (WSHandler, bool) {
+	hub.mx.RLock()
+	defer hub.mx.RUnlock()
+
+	handler, ok := hub.handlers[messageType]
+	return handler, ok
+}
+
+func (hub *WSHub) isClosed() bool {
+	hub.mx.RLock()
+	defer hub.mx.RUnlock()
+
+	return hub.closed
+}
+
+func (hub *WSHub) register(conn *WSConn) bool {
+	hub.mx.Lock()
+	defer hub.mx.Unlock()
+
+	if hub.closed {
+		return false
+	}
+
+	hub.connections[conn.id] = conn
+	hub.wg.Add(1)
+	return true
+}
+
+func (hub *WSHub) unregister(conn *WSConn) {
+	hub.mx.Lock()
+	delete(hub.connections, conn.id)
+	for room := range conn.rooms {
+		hub.removeFromRoom(conn, room)
+	}
+	hub.mx.Unlock()
+
+	if hub.options.onDisconnect != nil {
+		hub.options.onDisconnect(conn)
+	}
+}
+
+func (hub *WSHub) join(conn *WSConn, room string) {
+	hub.mx.Lock()
+	defer hub.mx.Unlock()
+
+	if _, ok := hub.connections[conn.id]; !ok {
+		return
+	}
+
+	members, ok := hub.rooms[room]
+	if !ok {
+		members = make(map[string]*WSConn)
+		hub.rooms[room] = members
+	}
+
+	members[conn.id] = conn
+	conn.rooms[room] = struct{}{}
+}
+
+func (hub *WSHub) leave(conn *WSConn, room string) {
+	hub.mx.Lock()
+	defer hub.mx.Unlock()
+
+	hub.removeFromRoom(conn, room)
+}
+
+func (hub *WSHub) removeFromRoom(conn *WSConn, room string) {
+	delete(conn.rooms, room)
+
+	members, ok := hub.rooms[room]
+	if !ok {
+		return
+	}
+
+	delete(members, conn.id)
+	if len(members) == 0 {
+		delete(hub.rooms, room)
+	}
+}
@@ -0,0 +1,243 @@
+package echox
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"github.com/labstack/echo/v4"
+)
+
+// newWSTestServer starts server with hub route & returns its websocket URL
+func newWSTestServer(t *testing.T, hub *WSHub) (*httptest.Server, string) {
+	t.Helper()
+
+	e := echo.New()
+	e.GET("/ws", hub.Handler())
+
+	server := httptest.NewServer(e)
+	t.Cleanup(func() {
+		hub.Close()
+		server.Close()
+	})
+
+	return server, "ws" + strings.TrimPrefix(server.URL, "http") + "/ws"
+}
+
+func dialWS(t *testing.T, url string) *websocket.Conn {
+	t.Helper()
+
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("unexpected dial error: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	return conn
+}
+
+func readWSMessage(t *testing.T, conn *websocket.Conn) WSMessage {
+	t.Helper()
+
+	_ = conn.SetReadDeadline(time.Now().Add(time.Second))
+
+	var message WSMessage
+	if err := conn.ReadJSON(&message); err != nil {
+		t.Fatalf("unexpected read error: %v", err)
+	}
+
+	return message
+}
+
+func waitWS(t *testing.T, condition func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition is not met in time")
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestWSHubMessages(t *testing.T) {
+	hub := NewWSHub()
+	hub.On("echo", func(ctx context.Context, conn *WSConn, message WSMessage) error {
+		var text string
+		if err := message.Parse(&text); err != nil {
+			return err
+		}
+
+		return conn.Reply(ctx, "echo", strings.ToUpper(text))
+	})
+
+	_, url := newWSTestServer(t, hub)
+	conn := dialWS(t, url)
+
+	if err := conn.WriteJSON(WSMessage{Type: "echo", TraceID: "trace-1", Data: []byte(`"hello"`)}); err != nil {
+		t.Fatalf("unexpected write error: %v", err)
+	}
+
+	reply := readWSMessage(t, conn)
+	if reply.Type != "echo" || reply.TraceID != "trace-1" || string(reply.Data) != `"HELLO"` {
+		t.Errorf("unexpected reply: %+v", reply)
+	}
+
+	// handler, unknown type & invalid message errors are sent as "error" messages
+	for _, message := range []string{
+		`{"type":"echo","data":1}`,
+		`{"type":"unknown"}`,
+		`not json`,
+	} {
+		if err := conn.WriteMessage(websocket.TextMessage, []byte(message)); err != nil {
+			t.Fatalf("unexpected write error: %v", err)
+		}
+
+		reply = readWSMessage(t, conn)
+		if reply.Type != WSMessageTypeError || reply.TraceID == "" || !strings.Contains(string(reply.Data), `"status_code":400`) {
+			t.Errorf("unexpected reply to %s: %+v %s", message, reply, reply.Data)
+		}
+	}
+}
+
+func TestWSHubRooms(t *testing.T) {
+	joined := make(chan struct{}, 2)
+	hub := NewWSHub()
+	hub.On("join", func(ctx context.Context, conn *WSConn, message WSMessage) error {
+		conn.Join(message.Room)
+		joined <- struct{}{}
+		return nil
+	})
+
+	_, url := newWSTestServer(t, hub)
+	member := dialWS(t, url)
+	other := dialWS(t, url)
+
+	for conn, room := range map[*websocket.Conn]string{member: "news", other: "sport"} {
+		if err := conn.WriteJSON(WSMessage{Type: "join", Room: room}); err != nil {
+			t.Fatalf("unexpected write error: %v", err)
+		}
+		<-joined
+	}
+
+	message, err := NewWSMessage("news.created", map[string]int{"id": 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err = hub.BroadcastRoom(context.Background(), "news", message); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if received := readWSMessage(t, member); received.Type != "news.created" || received.Room != "news" {
+		t.Errorf("unexpected room message: %+v", received)
+	}
+
+	// broadcast without room reaches all connections, so "sport" member gets only it
+	if err = hub.Broadcast(context.Background(), WSMessage{Type: "all"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if received := readWSMessage(t, other); received.Type != "all" {
+		t.Errorf("expected broadcast message, got %+v", received)
+	}
+}
+
+func TestWSConnSendQueueFull(t *testing.T) {
+	var disconnected *WSConn
+	hub := NewWSHub(WithWSSendQueue(1), WithWSOnDisconnect(func(conn *WSConn) {
+		disconnected = conn
+	}))
+	defer hub.Close()
+
+	// connection without pumps: queued messages are not sent, like to a slow client
+	ctx, cancel := context.WithCancel(context.Background())
+	conn := &WSConn{
+		id:     uuid.NewString(),
+		hub:    hub,
+		ctx:    ctx,
+		cancel: cancel,
+		send:   make(chan []byte, 1),
+		done:   make(chan struct{}),
+		rooms:  make(map[string]struct{}),
+	}
+	hub.connections[conn.id] = conn
+	hub.join(conn, "news")
+
+	if err := conn.Send(WSMessage{Type: "first"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := conn.Send(WSMessage{Type: "second"}); !errors.Is(err, ErrWSSendQueueFull) {
+		t.Fatalf("expected send queue full error, got %v", err)
+	}
+
+	// slow connection is closed & removed from hub
+	if conn.closeCode != websocket.CloseTryAgainLater || ctx.Err() == nil || disconnected != conn {
+		t.Errorf("expected closed connection, got code %d", conn.closeCode)
+	}
+
+	if _, ok := hub.Connection(conn.id); ok || len(hub.rooms) != 0 {
+		t.Error("expected connection to be removed from hub")
+	}
+
+	if err := conn.Send(WSMessage{Type: "third"}); !errors.Is(err, ErrWSConnectionClosed) {
+		t.Errorf("expected connection closed error, got %v", err)
+	}
+}
+
+func TestWSHubClose(t *testing.T) {
+	hub := NewWSHub()
+	server, url := newWSTestServer(t, hub)
+
+	clients := []*websocket.Conn{dialWS(t, url), dialWS(t, url)}
+	waitWS(t, func() bool {
+		return hub.Count() == len(clients)
+	})
+
+	// hub is closed by app teardown on shutdown
+	closed := make(chan struct{})
+	go func() {
+		hub.Close()
+		close(closed)
+	}()
+
+	for _, client := range clients {
+		_ = client.SetReadDeadline(time.Now().Add(time.Second))
+		_, _, err := client.ReadMessage()
+		if !websocket.IsCloseError(err, websocket.CloseGoingAway) {
+			t.Errorf("expected going away close, got %v", err)
+		}
+	}
+
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Fatal("hub close does not wait for connections")
+	}
+
+	if hub.Count() != 0 {
+		t.Errorf("expected no connections, got %d", hub.Count())
+	}
+
+	// new connections are rejected
+	response, err := http.Get(server.URL + "/ws")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_ = response.Body.Close()
+
+	if response.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("expected status 503, got %d", response.StatusCode)
+	}
+}
@@ -39,28 +39,44 @@ func New(message string) *Error {
 
 // Extend copies provided err to the new one.
 //
+// Message, locale message, inner error, data and params are copied, so every setter (SetError, SetData, AddParam,
+// SetParams, SetLocaleMessage) returns a copy which keeps values set by previous calls:
+//
+//	ErrNotFound.AddParam("id", id).SetError(err) // keeps "id" param
+//
+// Params slice is copied too, so params added to the copy are not visible in provided error. Data is copied by value,
+// so data objects (like maps or slices) are shared between copies.
+//
+// Errors marked by NoCopy are not copied: setters change them in place.
+//
 // Inner errors sets inside new error as one inner error.
 //
 // If inner errors contains only 1 error it will be 1 error, if errors more than 1, it will be "Join error"
 func Extend(err error) *Error {
 	var extended *Error
-	var message string
-	var inner error
-
-	if errors.As(err, &extended) {
-		if extended.noCopy {
-			return extended
+	if !errors.As(err, &extended) {
+		return &Error{
+			message: err.Error(),
 		}
+	}
 
-		message = extended.message
-		inner = extended.inner
-	} else {
-		message = err.Error()
+	if extended.noCopy {
+		return extended
+	}
+
+	// params are copied, so appending to the new error does not change provided one
+	var params []Parameter
+	if len(extended.params) > 0 {
+		params = make([]Parameter, len(extended.params))
+		copy(params, extended.params)
 	}
 
 	return &Error{
-		message: message,
-		inner:   inner,
+		message:       extended.message,
+		localeMessage: extended.localeMessage,
+		inner:         extended.inner,
+		data:          extended.data,
+		params:        params,
 	}
 }
 
@@ -166,7 +182,7 @@ func (e *Error) Message() string {
 	return e.message
 }
 
-// SetLocaleMessage sets locale message.
+// SetLocaleMessage sets locale message to the copy of the error (see Extend).
 func (e *Error) SetLocaleMessage(message string) *Error {
 	target := Extend(e)
 	target.localeMessage = message
@@ -178,7 +194,7 @@ func (e *Error) LocaleMessage() string {
 	return e.localeMessage
 }
 
-// SetError sets inner error.
+// SetError sets inner error to the copy of the error (see Extend).
 //
 // If inner errors more than 1 it will be "join error", if error is 1 it will be provided by itself
 func (e *Error) SetError(err ...error) *Error {
@@ -202,7 +218,7 @@ func (e *Error) Inner() error {
 	return e.inner
 }
 
-// SetData sets context data (any type).
+// SetData sets context data (any type) to the copy of the error (see Extend).
 func (e *Error) SetData(data any) *Error {
 	if data == nil {
 		return e
@@ -219,7 +235,7 @@ func (e *Error) Data() any {
 	return e.data
 }
 
-// AddParam append new key-value param
+// AddParam append new key-value param to the copy of the error (see Extend)
 func (e *Error) AddParam(key string, value any) *Error {
 	target := Extend(e)
 	target.params = append(target.params, Parameter{
@@ -229,13 +245,14 @@ func (e *Error) AddParam(key string, value any) *Error {
 	return target
 }
 
-// SetParams append slice of key-value params
+// SetParams append slice of key-value params to the copy of the error (see Extend)
 func (e *Error) SetParams(params []Parameter) *Error {
 	target := Extend(e)
 	target.params = append(target.params, params...)
 	return target
 }
 
+// NoCopy marks error to be changed in place by setters instead of copying (see Extend).
 func (e *Error) NoCopy() *Error {
 	e.noCopy = true
 	return e
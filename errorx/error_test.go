@@ -0,0 +1,108 @@
+package errorx
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestChainedSetters(t *testing.T) {
+	base := New("user.not_found")
+	cause := errors.New("sql: no rows")
+
+	err := base.
+		SetError(cause).
+		SetData(map[string]any{"id": 1}).
+		AddParam("id", 1).
+		SetLocaleMessage("User not found")
+
+	if err.Message() != "user.not_found" {
+		t.Errorf("unexpected message: %s", err.Message())
+	}
+
+	if !errors.Is(err.Inner(), cause) {
+		t.Errorf("expected inner error to be kept, got %v", err.Inner())
+	}
+
+	if data, ok := err.Data().(map[string]any); !ok || data["id"] != 1 {
+		t.Errorf("expected data to be kept, got %v", err.Data())
+	}
+
+	if params := err.Params(); len(params) != 1 || params[0].Key != "id" {
+		t.Errorf("expected params to be kept, got %v", params)
+	}
+
+	if err.LocaleMessage() != "User not found" {
+		t.Errorf("unexpected locale message: %s", err.LocaleMessage())
+	}
+
+	// base error is not changed
+	if base.Inner() != nil || base.Data() != nil || len(base.Params()) != 0 || base.LocaleMessage() != "" {
+		t.Errorf("expected base error not to be changed, got %+v", base)
+	}
+}
+
+func TestExtendCopiesParams(t *testing.T) {
+	base := New("order.invalid").AddParam("order", 1)
+
+	first := base.AddParam("field", "price")
+	second := base.AddParam("field", "amount")
+
+	if len(base.Params()) != 1 {
+		t.Fatalf("expected base params not to be changed, got %v", base.Params())
+	}
+
+	if first.Params()[1].Value != "price" || second.Params()[1].Value != "amount" {
+		t.Errorf("expected params to be independent, got %v and %v", first.Params(), second.Params())
+	}
+}
+
+func TestExtend(t *testing.T) {
+	t.Run("custom error", func(t *testing.T) {
+		base := New("payment.failed").
+			SetError(ErrBadRequest).
+			SetData("context").
+			AddParam("id", 1)
+
+		extended := Extend(base)
+		if extended == base {
+			t.Fatal("expected copy")
+		}
+
+		if extended.Message() != base.Message() ||
+			extended.Inner() != base.Inner() ||
+			extended.Data() != base.Data() ||
+			len(extended.Params()) != 1 {
+			t.Errorf("expected all fields to be copied, got %+v", extended)
+		}
+	})
+
+	t.Run("no copy", func(t *testing.T) {
+		base := New("payment.failed").NoCopy()
+		if Extend(base) != base {
+			t.Error("expected the same error")
+		}
+
+		if base.AddParam("id", 1) != base || len(base.Params()) != 1 {
+			t.Error("expected error to be changed in place")
+		}
+	})
+
+	t.Run("built-in error", func(t *testing.T) {
+		extended := Extend(errors.New("built-in"))
+		if extended.Message() != "built-in" || extended.Inner() != nil {
+			t.Errorf("unexpected error: %+v", extended)
+		}
+	})
+}
+
+func TestIs(t *testing.T) {
+	err := New("user.create").SetError(ErrNotFound.AddParam("id", 1))
+
+	if !errors.Is(err, ErrNotFound) {
+		t.Error("expected inner error to be found")
+	}
+
+	if errors.Is(err, ErrBadRequest) {
+		t.Error("expected other error not to be found")
+	}
+}
@@ -0,0 +1,69 @@
+package filterx
+
+import (
+	"regexp"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+var bsonOperators = map[Operator]string{
+	OperatorNe:    "$ne",
+	OperatorGt:    "$gt",
+	OperatorGte:   "$gte",
+	OperatorLt:    "$lt",
+	OperatorLte:   "$lte",
+	OperatorIn:    "$in",
+	OperatorNotIn: "$nin",
+}
+
+// BSON returns mongo filter. Several filters are joined by "$and", no filters - empty document
+func (spec Spec) BSON() bson.D {
+	switch len(spec.Filters) {
+	case 0:
+		return bson.D{}
+	case 1:
+		return spec.Filters[0].bson()
+	}
+
+	conditions := make(bson.A, 0, len(spec.Filters))
+	for _, filter := range spec.Filters {
+		conditions = append(conditions, filter.bson())
+	}
+
+	return bson.D{{Key: "$and", Value: conditions}}
+}
+
+// SortBSON returns mongo sort document, like {created_at: -1, name: 1}
+func (spec Spec) SortBSON() bson.D {
+	sorts := make(bson.D, 0, len(spec.Sorts))
+	for _, sort := range spec.Sorts {
+		direction := 1
+		if sort.Desc {
+			direction = -1
+		}
+
+		sorts = append(sorts, bson.E{Key: sort.Column, Value: direction})
+	}
+
+	return sorts
+}
+
+func (filter Filter) bson() bson.D {
+	switch filter.Operator {
+	case OperatorEq:
+		return bson.D{{Key: filter.Column, Value: filter.Value}}
+	case OperatorContains:
+		return bson.D{{Key: filter.Column, Value: bson.D{
+			{Key: "$regex", Value: regexp.QuoteMeta(filter.Value.(string))},
+			{Key: "$options", Value: "i"},
+		}}}
+	case OperatorNull:
+		if filter.Value.(bool) {
+			return bson.D{{Key: filter.Column, Value: nil}}
+		}
+
+		return bson.D{{Key: filter.Column, Value: bson.D{{Key: "$ne", Value: nil}}}}
+	default:
+		return bson.D{{Key: filter.Column, Value: bson.D{{Key: bsonOperators[filter.Operator], Value: filter.Value}}}}
+	}
+}
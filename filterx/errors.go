@@ -0,0 +1,13 @@
+package filterx
+
+import "github.com/boostgo/core/errorx"
+
+var (
+	ErrInvalidFilter       = errorx.New("filter.invalid").SetError(errorx.ErrBadRequest)
+	ErrInvalidSort         = errorx.New("filter.invalid_sort").SetError(errorx.ErrBadRequest)
+	ErrFieldNotAllowed     = errorx.New("filter.field_not_allowed").SetError(errorx.ErrBadRequest)
+	ErrOperatorNotAllowed  = errorx.New("filter.operator_not_allowed").SetError(errorx.ErrBadRequest)
+	ErrInvalidFilterValue  = errorx.New("filter.invalid_value").SetError(errorx.ErrBadRequest)
+	ErrFieldNotSortable    = errorx.New("filter.field_not_sortable").SetError(errorx.ErrBadRequest)
+	ErrTooManyFilterFields = errorx.New("filter.too_many_fields").SetError(errorx.ErrBadRequest)
+)
@@ -0,0 +1,65 @@
+// Package filterx provides filtering & sorting DSL parsed from query strings.
+// Features:
+// - Filters like "status:eq:active,amount:gte:100" and sorts like "-created_at,name".
+// - Allowlist of fields per resource (Schema) with allowed operators & value types.
+// - Compiling to SQL WHERE & ORDER BY with sql.Arguments placeholders.
+// - Compiling to bson filters & sorts for mongox.
+package filterx
+
+// Operator is filter comparison operator
+type Operator string
+
+const (
+	OperatorEq       Operator = "eq"
+	OperatorNe       Operator = "ne"
+	OperatorGt       Operator = "gt"
+	OperatorGte      Operator = "gte"
+	OperatorLt       Operator = "lt"
+	OperatorLte      Operator = "lte"
+	OperatorIn       Operator = "in"
+	OperatorNotIn    Operator = "nin"
+	OperatorContains Operator = "contains"
+	OperatorNull     Operator = "null"
+)
+
+var operators = []Operator{
+	OperatorEq,
+	OperatorNe,
+	OperatorGt,
+	OperatorGte,
+	OperatorLt,
+	OperatorLte,
+	OperatorIn,
+	OperatorNotIn,
+	OperatorContains,
+	OperatorNull,
+}
+
+// Filter is one parsed filter condition.
+//
+// Column is taken from Schema, so it is safe to use it in queries. Value is converted by field type,
+// for "in" & "nin" operators it is []any, for "null" operator it is bool
+type Filter struct {
+	Field    string
+	Column   string
+	Operator Operator
+	Value    any
+}
+
+// Sort is one parsed sort field
+type Sort struct {
+	Field  string
+	Column string
+	Desc   bool
+}
+
+// Spec is parsed filters & sorts
+type Spec struct {
+	Filters []Filter
+	Sorts   []Sort
+}
+
+// Empty returns true if there are no filters & sorts
+func (spec Spec) Empty() bool {
+	return len(spec.Filters) == 0 && len(spec.Sorts) == 0
+}
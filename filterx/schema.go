@@ -0,0 +1,315 @@
+package filterx
+
+import (
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/boostgo/core/errorx"
+)
+
+const (
+	filterSeparator = ","
+	partSeparator   = ":"
+	valuesSeparator = "|"
+	descPrefix      = "-"
+	ascPrefix       = "+"
+
+	// QueryFilter is query param name of filters
+	QueryFilter = "filter"
+	// QuerySort is query param name of sorts
+	QuerySort = "sort"
+
+	defaultMaxFilters = 20
+)
+
+// FieldType is type of field value. Filter values are converted by it
+type FieldType int
+
+const (
+	TypeString FieldType = iota
+	TypeInt
+	TypeFloat
+	TypeBool
+	TypeTime
+)
+
+// Field is allowed field of resource
+type Field struct {
+	// Name is field name in query string
+	Name string
+	// Column is SQL column or bson key. By default, it is Name
+	Column string
+	Type   FieldType
+	// Operators are allowed operators. By default, all operators are allowed
+	Operators []Operator
+	// Sortable allows sorting by field
+	Sortable bool
+}
+
+// SchemaOption modifies Schema settings
+type SchemaOption func(schema *Schema)
+
+// WithMaxFilters sets max count of filters. By default, it is 20
+func WithMaxFilters(count int) SchemaOption {
+	return func(schema *Schema) {
+		if count > 0 {
+			schema.maxFilters = count
+		}
+	}
+}
+
+// WithDefaultSort sets sorts which are used if there is no sort in query, like "-created_at"
+func WithDefaultSort(sort string) SchemaOption {
+	return func(schema *Schema) {
+		schema.defaultSort = sort
+	}
+}
+
+// Schema is allowlist of resource fields. Only fields of schema could be used in filters & sorts
+type Schema struct {
+	fields      map[string]Field
+	maxFilters  int
+	defaultSort string
+}
+
+// NewSchema creates schema of resource fields.
+//
+//	schema := filterx.NewSchema([]filterx.Field{
+//		{Name: "status", Operators: []filterx.Operator{filterx.OperatorEq, filterx.OperatorIn}},
+//		{Name: "amount", Type: filterx.TypeFloat, Sortable: true},
+//		{Name: "created_at", Type: filterx.TypeTime, Sortable: true},
+//	}, filterx.WithDefaultSort("-created_at"))
+func NewSchema(fields []Field, opts ...SchemaOption) *Schema {
+	schema := &Schema{
+		fields:     make(map[string]Field, len(fields)),
+		maxFilters: defaultMaxFilters,
+	}
+
+	for _, field := range fields {
+		if field.Column == "" {
+			field.Column = field.Name
+		}
+
+		schema.fields[field.Name] = field
+	}
+
+	for _, opt := range opts {
+		opt(schema)
+	}
+
+	return schema
+}
+
+// ParseQuery parses spec from "filter" & "sort" query params
+func (schema *Schema) ParseQuery(values url.Values) (Spec, error) {
+	return schema.Parse(values.Get(QueryFilter), values.Get(QuerySort))
+}
+
+// Parse parses spec from filter ("status:eq:active,amount:gte:100") & sort ("-created_at,name") strings.
+//
+// Values of "in" & "nin" operators are separated by "|": "status:in:active|blocked".
+// Value of "null" operator is "true" or "false"
+func (schema *Schema) Parse(filter, sort string) (Spec, error) {
+	filters, err := schema.parseFilters(filter)
+	if err != nil {
+		return Spec{}, err
+	}
+
+	if sort == "" {
+		sort = schema.defaultSort
+	}
+
+	sorts, err := schema.parseSorts(sort)
+	if err != nil {
+		return Spec{}, err
+	}
+
+	return Spec{
+		Filters: filters,
+		Sorts:   sorts,
+	}, nil
+}
+
+func (schema *Schema) parseFilters(filter string) ([]Filter, error) {
+	if filter == "" {
+		return nil, nil
+	}
+
+	parts := strings.Split(filter, filterSeparator)
+	if len(parts) > schema.maxFilters {
+		return nil, ErrTooManyFilterFields.AddParam("max", schema.maxFilters)
+	}
+
+	filters := make([]Filter, 0, len(parts))
+	for _, part := range parts {
+		// value could contain separator (for example, time)
+		name, rest, ok := strings.Cut(part, partSeparator)
+		if !ok {
+			return nil, ErrInvalidFilter.AddParam("filter", part)
+		}
+
+		operator, value, ok := strings.Cut(rest, partSeparator)
+		if !ok {
+			return nil, ErrInvalidFilter.AddParam("filter", part)
+		}
+
+		field, ok := schema.fields[name]
+		if !ok {
+			return nil, ErrFieldNotAllowed.AddParam("field", name)
+		}
+
+		parsed, err := field.parseFilter(Operator(operator), value)
+		if err != nil {
+			return nil, err
+		}
+
+		filters = append(filters, parsed)
+	}
+
+	return filters, nil
+}
+
+func (schema *Schema) parseSorts(sort string) ([]Sort, error) {
+	if sort == "" {
+		return nil, nil
+	}
+
+	parts := strings.Split(sort, filterSeparator)
+	sorts := make([]Sort, 0, len(parts))
+	for _, part := range parts {
+		name := part
+		desc := false
+
+		switch {
+		case strings.HasPrefix(name, descPrefix):
+			name = name[len(descPrefix):]
+			desc = true
+		case strings.HasPrefix(name, ascPrefix):
+			name = name[len(ascPrefix):]
+		}
+
+		if name == "" {
+			return nil, ErrInvalidSort.AddParam("sort", part)
+		}
+
+		field, ok := schema.fields[name]
+		if !ok {
+			return nil, ErrFieldNotAllowed.AddParam("field", name)
+		}
+
+		if !field.Sortable {
+			return nil, ErrFieldNotSortable.AddParam("field", name)
+		}
+
+		sorts = append(sorts, Sort{
+			Field:  field.Name,
+			Column: field.Column,
+			Desc:   desc,
+		})
+	}
+
+	return sorts, nil
+}
+
+func (field Field) parseFilter(operator Operator, value string) (Filter, error) {
+	if !slices.Contains(operators, operator) {
+		return Filter{}, ErrInvalidFilter.
+			AddParam("field", field.Name).
+			AddParam("operator", operator)
+	}
+
+	if len(field.Operators) > 0 && !slices.Contains(field.Operators, operator) {
+		return Filter{}, ErrOperatorNotAllowed.
+			AddParam("field", field.Name).
+			AddParam("operator", operator)
+	}
+
+	filter := Filter{
+		Field:    field.Name,
+		Column:   field.Column,
+		Operator: operator,
+	}
+
+	switch operator {
+	case OperatorNull:
+		isNull, err := strconv.ParseBool(value)
+		if err != nil {
+			return Filter{}, newInvalidValueError(field, value, err)
+		}
+
+		filter.Value = isNull
+	case OperatorIn, OperatorNotIn:
+		items := strings.Split(value, valuesSeparator)
+		values := make([]any, 0, len(items))
+		for _, item := range items {
+			converted, err := field.convert(item)
+			if err != nil {
+				return Filter{}, err
+			}
+
+			values = append(values, converted)
+		}
+
+		filter.Value = values
+	case OperatorContains:
+		if field.Type != TypeString {
+			return Filter{}, ErrOperatorNotAllowed.
+				AddParam("field", field.Name).
+				AddParam("operator", operator)
+		}
+
+		filter.Value = value
+	default:
+		converted, err := field.convert(value)
+		if err != nil {
+			return Filter{}, err
+		}
+
+		filter.Value = converted
+	}
+
+	return filter, nil
+}
+
+func (field Field) convert(value string) (any, error) {
+	var converted any
+	var err error
+
+	switch field.Type {
+	case TypeInt:
+		converted, err = strconv.ParseInt(value, 10, 64)
+	case TypeFloat:
+		converted, err = strconv.ParseFloat(value, 64)
+	case TypeBool:
+		converted, err = strconv.ParseBool(value)
+	case TypeTime:
+		converted, err = parseTime(value)
+	default:
+		converted = value
+	}
+
+	if err != nil {
+		return nil, newInvalidValueError(field, value, err)
+	}
+
+	return converted, nil
+}
+
+func newInvalidValueError(field Field, value string, err error) error {
+	return ErrInvalidFilterValue.
+		SetError(errorx.ErrBadRequest, err).
+		AddParam("field", field.Name).
+		AddParam("value", value)
+}
+
+func parseTime(value string) (time.Time, error) {
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err == nil {
+		return parsed, nil
+	}
+
+	return time.Parse(time.DateOnly, value)
+}
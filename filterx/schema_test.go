@@ -0,0 +1,164 @@
+package filterx
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func newTestSchema(opts ...SchemaOption) *Schema {
+	return NewSchema([]Field{
+		{Name: "status", Operators: []Operator{OperatorEq, OperatorIn}},
+		{Name: "amount", Type: TypeFloat, Sortable: true},
+		{Name: "count", Type: TypeInt},
+		{Name: "active", Type: TypeBool},
+		{Name: "created_at", Column: "created", Type: TypeTime, Sortable: true},
+		{Name: "name", Sortable: true},
+	}, opts...)
+}
+
+func TestSchemaParse(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter string
+		sort   string
+		opts   []SchemaOption
+		err    error
+	}{
+		{
+			name:   "valid filters & sorts",
+			filter: "status:in:active|blocked,amount:gte:100.5,created_at:lt:2024-01-02T10:00:00Z",
+			sort:   "-created_at,name",
+		},
+		{
+			name:   "field not in allowlist",
+			filter: "password:eq:secret",
+			err:    ErrFieldNotAllowed,
+		},
+		{
+			name:   "operator not allowed for field",
+			filter: "status:ne:active",
+			err:    ErrOperatorNotAllowed,
+		},
+		{
+			name:   "unknown operator",
+			filter: "amount:like:1",
+			err:    ErrInvalidFilter,
+		},
+		{
+			name:   "contains for not string field",
+			filter: "amount:contains:1",
+			err:    ErrOperatorNotAllowed,
+		},
+		{
+			name:   "missing operator",
+			filter: "amount",
+			err:    ErrInvalidFilter,
+		},
+		{
+			name:   "too many filters",
+			filter: "amount:gt:1,amount:lt:10,count:eq:1",
+			opts:   []SchemaOption{WithMaxFilters(2)},
+			err:    ErrTooManyFilterFields,
+		},
+		{
+			name:   "bad float value",
+			filter: "amount:gt:many",
+			err:    ErrInvalidFilterValue,
+		},
+		{
+			name:   "bad int value in list",
+			filter: "count:in:1|two",
+			err:    ErrInvalidFilterValue,
+		},
+		{
+			name:   "bad bool value",
+			filter: "active:eq:yes",
+			err:    ErrInvalidFilterValue,
+		},
+		{
+			name:   "bad time value",
+			filter: "created_at:gt:yesterday",
+			err:    ErrInvalidFilterValue,
+		},
+		{
+			name:   "bad null value",
+			filter: "name:null:maybe",
+			err:    ErrInvalidFilterValue,
+		},
+		{
+			name: "sort by not sortable field",
+			sort: "status",
+			err:  ErrFieldNotSortable,
+		},
+		{
+			name: "sort by unknown field",
+			sort: "-password",
+			err:  ErrFieldNotAllowed,
+		},
+		{
+			name: "empty sort field",
+			sort: "-",
+			err:  ErrInvalidSort,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newTestSchema(tt.opts...).Parse(tt.filter, tt.sort)
+			if tt.err == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("expected %v, got %v", tt.err, err)
+			}
+		})
+	}
+}
+
+func TestSchemaParseValues(t *testing.T) {
+	spec, err := newTestSchema(WithDefaultSort("-created_at")).Parse(
+		"status:in:active|blocked,count:eq:5,active:eq:true,created_at:gte:2024-01-02,name:null:false",
+		"",
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(spec.Filters) != 5 {
+		t.Fatalf("expected 5 filters, got %d", len(spec.Filters))
+	}
+
+	if values, ok := spec.Filters[0].Value.([]any); !ok || len(values) != 2 || values[1] != "blocked" {
+		t.Errorf("unexpected in values: %v", spec.Filters[0].Value)
+	}
+
+	if spec.Filters[1].Value != int64(5) {
+		t.Errorf("expected int64 value, got %T", spec.Filters[1].Value)
+	}
+
+	if spec.Filters[2].Value != true {
+		t.Errorf("expected bool value, got %v", spec.Filters[2].Value)
+	}
+
+	if value, ok := spec.Filters[3].Value.(time.Time); !ok || !value.Equal(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected time value: %v", spec.Filters[3].Value)
+	}
+
+	if spec.Filters[3].Column != "created" {
+		t.Errorf("expected column from schema, got %s", spec.Filters[3].Column)
+	}
+
+	if spec.Filters[4].Value != false {
+		t.Errorf("expected null value false, got %v", spec.Filters[4].Value)
+	}
+
+	// default sort
+	if len(spec.Sorts) != 1 || spec.Sorts[0].Column != "created" || !spec.Sorts[0].Desc {
+		t.Errorf("unexpected default sort: %+v", spec.Sorts)
+	}
+}
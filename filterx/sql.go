@@ -0,0 +1,82 @@
+package filterx
+
+import (
+	"strings"
+
+	"github.com/boostgo/core/sql"
+)
+
+var sqlOperators = map[Operator]string{
+	OperatorEq:  "=",
+	OperatorNe:  "<>",
+	OperatorGt:  ">",
+	OperatorGte: ">=",
+	OperatorLt:  "<",
+	OperatorLte: "<=",
+}
+
+// Where returns SQL conditions joined by "AND" (without "WHERE" keyword) or empty string if there are no filters.
+//
+// Values are added to args as placeholders, columns are taken from Schema:
+//
+//	args := sql.NewArguments()
+//	query := "SELECT * FROM orders"
+//	if where := spec.Where(args, "o"); where != "" {
+//		query += " WHERE " + where
+//	}
+func (spec Spec) Where(args *sql.Arguments, alias ...string) string {
+	conditions := make([]string, 0, len(spec.Filters))
+	for _, filter := range spec.Filters {
+		conditions = append(conditions, filter.sql(args, alias...))
+	}
+
+	return strings.Join(conditions, " AND ")
+}
+
+// OrderBy returns SQL sorts (without "ORDER BY" keywords) like "created_at DESC, name ASC" or empty string if there are no sorts
+func (spec Spec) OrderBy(alias ...string) string {
+	sorts := make([]string, 0, len(spec.Sorts))
+	for _, sort := range spec.Sorts {
+		direction := "ASC"
+		if sort.Desc {
+			direction = "DESC"
+		}
+
+		sorts = append(sorts, column(sort.Column, alias...)+" "+direction)
+	}
+
+	return strings.Join(sorts, ", ")
+}
+
+func (filter Filter) sql(args *sql.Arguments, alias ...string) string {
+	name := column(filter.Column, alias...)
+
+	switch filter.Operator {
+	case OperatorIn:
+		return name + " IN " + args.AddMany(filter.Value.([]any)...)
+	case OperatorNotIn:
+		return name + " NOT IN " + args.AddMany(filter.Value.([]any)...)
+	case OperatorContains:
+		return name + " ILIKE " + args.Add("%"+escapeLike(filter.Value.(string))+"%").Number()
+	case OperatorNull:
+		if filter.Value.(bool) {
+			return name + " IS NULL"
+		}
+
+		return name + " IS NOT NULL"
+	default:
+		return name + " " + sqlOperators[filter.Operator] + " " + args.Add(filter.Value).Number()
+	}
+}
+
+func column(name string, alias ...string) string {
+	if len(alias) > 0 && alias[0] != "" {
+		return alias[0] + "." + name
+	}
+
+	return name
+}
+
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
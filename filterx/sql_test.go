@@ -0,0 +1,61 @@
+package filterx
+
+import (
+	"testing"
+
+	"github.com/boostgo/core/sql"
+)
+
+func TestSpecWhere(t *testing.T) {
+	spec, err := newTestSchema().Parse("status:in:active|blocked,amount:gte:100,name:contains:50%_off,active:null:true", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// placeholders continue numbering of already added arguments
+	args := sql.NewArguments("tenant")
+	where := spec.Where(args, "o")
+
+	expected := `o.status IN ($2, $3) AND o.amount >= $4 AND o.name ILIKE $5 AND o.active IS NULL`
+	if where != expected {
+		t.Fatalf("expected %q, got %q", expected, where)
+	}
+
+	expectedArgs := []any{"tenant", "active", "blocked", float64(100), `%50\%\_off%`}
+	actualArgs := args.Args()
+	if len(actualArgs) != len(expectedArgs) {
+		t.Fatalf("expected args %v, got %v", expectedArgs, actualArgs)
+	}
+
+	for i := range expectedArgs {
+		if actualArgs[i] != expectedArgs[i] {
+			t.Errorf("arg %d: expected %v, got %v", i, expectedArgs[i], actualArgs[i])
+		}
+	}
+}
+
+func TestSpecWhereEmpty(t *testing.T) {
+	args := sql.NewArguments()
+	if where := (Spec{}).Where(args); where != "" {
+		t.Errorf("expected empty where, got %q", where)
+	}
+
+	if len(args.Args()) != 0 {
+		t.Errorf("expected no args, got %v", args.Args())
+	}
+}
+
+func TestSpecOrderBy(t *testing.T) {
+	spec, err := newTestSchema().Parse("", "-created_at,+name,amount")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if orderBy := spec.OrderBy(); orderBy != "created DESC, name ASC, amount ASC" {
+		t.Errorf("unexpected order by: %q", orderBy)
+	}
+
+	if orderBy := spec.OrderBy("o"); orderBy != "o.created DESC, o.name ASC, o.amount ASC" {
+		t.Errorf("unexpected order by with alias: %q", orderBy)
+	}
+}
@@ -3,6 +3,7 @@ package fsx
 import (
 	"archive/zip"
 	"bufio"
+	"bytes"
 	"compress/gzip"
 	"crypto/md5"
 	"crypto/sha1"
@@ -365,12 +366,21 @@ func TouchFile(path string, options ...FileOption) error {
 
 // AtomicWriteFile writes data to a file atomically
 func AtomicWriteFile(path string, data []byte, perm os.FileMode) error {
+	_, err := AtomicWriteFileFrom(path, bytes.NewReader(data), perm)
+	return err
+}
+
+// AtomicWriteFileFrom writes data from reader to a file atomically and returns count of written bytes.
+//
+// Data is written to temporary file which is renamed to the path only if reading is succeeded,
+// so file is never left partially written
+func AtomicWriteFileFrom(path string, reader io.Reader, perm os.FileMode) (int64, error) {
 	dir := filepath.Dir(path)
 
 	// Create temporary file in the same directory
 	tmpFile, err := os.CreateTemp(dir, ".tmp-*")
 	if err != nil {
-		return ErrAtomicOperation.
+		return 0, ErrAtomicOperation.
 			SetError(err).
 			SetData(pathErrorContext{
 				Path:  path,
@@ -388,9 +398,10 @@ func AtomicWriteFile(path string, data []byte, perm os.FileMode) error {
 	}()
 
 	// Write data to temp file
-	if _, err := tmpFile.Write(data); err != nil {
+	written, err := io.Copy(tmpFile, reader)
+	if err != nil {
 		tmpFile.Close()
-		return ErrAtomicOperation.
+		return written, ErrAtomicOperation.
 			SetError(err).
 			SetData(pathErrorContext{
 				Path:  path,
@@ -401,7 +412,7 @@ func AtomicWriteFile(path string, data []byte, perm os.FileMode) error {
 	// Sync to disk
 	if err := tmpFile.Sync(); err != nil {
 		tmpFile.Close()
-		return ErrAtomicOperation.
+		return written, ErrAtomicOperation.
 			SetError(err).
 			SetData(pathErrorContext{
 				Path:  path,
@@ -411,7 +422,7 @@ func AtomicWriteFile(path string, data []byte, perm os.FileMode) error {
 
 	// Close temp file
 	if err := tmpFile.Close(); err != nil {
-		return ErrAtomicOperation.
+		return written, ErrAtomicOperation.
 			SetError(err).
 			SetData(pathErrorContext{
 				Path:  path,
@@ -421,7 +432,7 @@ func AtomicWriteFile(path string, data []byte, perm os.FileMode) error {
 
 	// Set permissions
 	if err := os.Chmod(tmpPath, perm); err != nil {
-		return ErrAtomicOperation.
+		return written, ErrAtomicOperation.
 			SetError(err).
 			SetData(pathErrorContext{
 				Path:  path,
@@ -431,7 +442,7 @@ func AtomicWriteFile(path string, data []byte, perm os.FileMode) error {
 
 	// Atomic rename
 	if err := os.Rename(tmpPath, path); err != nil {
-		return ErrAtomicOperation.
+		return written, ErrAtomicOperation.
 			SetError(err).
 			SetData(pathErrorContext{
 				Path:  path,
@@ -439,7 +450,7 @@ func AtomicWriteFile(path string, data []byte, perm os.FileMode) error {
 			})
 	}
 
-	return nil
+	return written, nil
 }
 
 // AtomicWriteFileString writes string data atomically
@@ -840,15 +851,8 @@ func CalculateFileChecksum(path string, hashType HashType) (string, error) {
 	}
 	defer file.Close()
 
-	var h hash.Hash
-	switch hashType {
-	case HashMD5:
-		h = md5.New()
-	case HashSHA1:
-		h = sha1.New()
-	case HashSHA256:
-		h = sha256.New()
-	default:
+	h, ok := NewHash(hashType)
+	if !ok {
 		return "", ErrChecksum.
 			SetData(struct {
 				Path     string   `json:"path"`
@@ -871,6 +875,22 @@ func CalculateFileChecksum(path string, hashType HashType) (string, error) {
 	return hex.EncodeToString(h.Sum(nil)), nil
 }
 
+// NewHash creates hash by hash type. Returns false if hash type is not supported.
+//
+// Could be used for calculating checksum of streamed data (with io.TeeReader or io.MultiWriter)
+func NewHash(hashType HashType) (hash.Hash, bool) {
+	switch hashType {
+	case HashMD5:
+		return md5.New(), true
+	case HashSHA1:
+		return sha1.New(), true
+	case HashSHA256:
+		return sha256.New(), true
+	default:
+		return nil, false
+	}
+}
+
 // VerifyFileChecksum verifies if a file matches the given checksum
 func VerifyFileChecksum(path string, expectedChecksum string, hashType HashType) (bool, error) {
 	actualChecksum, err := CalculateFileChecksum(path, hashType)
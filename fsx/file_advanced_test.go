@@ -3,11 +3,13 @@ package fsx
 import (
 	"bytes"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
 	"sync"
 	"testing"
+	"testing/iotest"
 )
 
 func TestAdvancedFileOperations(t *testing.T) {
@@ -49,6 +51,52 @@ func TestAdvancedFileOperations(t *testing.T) {
 		}
 	})
 
+	t.Run("AtomicWriteFileFrom", func(t *testing.T) {
+		path := filepath.Join(tmpDir, "atomic_stream.txt")
+		content := "streamed content"
+
+		written, err := AtomicWriteFileFrom(path, strings.NewReader(content), 0644)
+		if err != nil {
+			t.Fatalf("Failed to write file atomically: %v", err)
+		}
+
+		if written != int64(len(content)) {
+			t.Errorf("Expected %d written bytes, got %d", len(content), written)
+		}
+
+		readContent, _ := ReadFileString(path)
+		if readContent != content {
+			t.Error("Content mismatch after atomic stream write")
+		}
+
+		// Failed reading keeps previous content
+		failing := io.MultiReader(strings.NewReader("partial"), iotest.ErrReader(fmt.Errorf("read failed")))
+		if _, err := AtomicWriteFileFrom(path, failing, 0644); err == nil {
+			t.Fatal("Expected error from failed reader")
+		}
+
+		readContent, _ = ReadFileString(path)
+		if readContent != content {
+			t.Error("File must not be changed by failed atomic write")
+		}
+	})
+
+	t.Run("NewHash", func(t *testing.T) {
+		h, ok := NewHash(HashSHA256)
+		if !ok {
+			t.Fatal("Expected sha256 to be supported")
+		}
+
+		h.Write([]byte("content"))
+		if len(h.Sum(nil)) != 32 {
+			t.Error("Unexpected sha256 sum size")
+		}
+
+		if _, ok = NewHash("crc32"); ok {
+			t.Error("Expected unsupported hash type")
+		}
+	})
+
 	t.Run("TempFile", func(t *testing.T) {
 		// Create temp file with content
 		content := []byte("temp content")
@@ -85,7 +85,7 @@ func ErrorHandling(ctx context.Context, req any, info *grpc.UnaryServerInfo, han
 }
 
 func Validate(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
-	if err := validator.Get().Struct(req); err != nil {
+	if err := validator.Get().StructCtx(ctx, req); err != nil {
 		return nil, status.Error(errs.Code(err), err.Error())
 	}
 
@@ -0,0 +1,430 @@
+package httpx
+
+import (
+	"encoding"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/boostgo/core/timex"
+	"github.com/boostgo/core/validator"
+)
+
+const (
+	defaultBindTag = "query"
+
+	bindTagLayout = "layout"
+	bindTagEnum   = "enum"
+
+	bindOptionComma = "comma"
+
+	bindRuleType = "type"
+	bindRuleEnum = "oneof"
+)
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	durationType      = reflect.TypeOf(time.Duration(0))
+	timexDurationType = reflect.TypeOf(timex.Duration{})
+	fileHeaderType    = reflect.TypeOf((*multipart.FileHeader)(nil))
+	fileHeadersType   = reflect.TypeOf([]*multipart.FileHeader(nil))
+	textUnmarshaler   = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// BindOption modifies Bind settings
+type BindOption func(options *bindOptions)
+
+type bindOptions struct {
+	tag   string
+	files map[string][]*multipart.FileHeader
+}
+
+// WithBindTag sets tag which contains param names. By default, it is "query"
+func WithBindTag(tag string) BindOption {
+	return func(options *bindOptions) {
+		options.tag = tag
+	}
+}
+
+// WithBindFiles sets multipart form files, which are bound to *multipart.FileHeader & []*multipart.FileHeader fields
+func WithBindFiles(files map[string][]*multipart.FileHeader) BindOption {
+	return func(options *bindOptions) {
+		options.files = files
+	}
+}
+
+// Bind binds values (query params, form values, etc.) to dst, which must be pointer to struct.
+//
+// Param names are taken from tags ("query" by default, see WithBindTag). Names are compared case-insensitive if
+// there is no exact match. Supported fields:
+//   - strings, integers, floats & booleans.
+//   - time.Time (RFC3339 or "2006-01-02", could be set by "layout" tag), time.Duration & timex.Duration ("1h30m").
+//   - types implementing encoding.TextUnmarshaler (like uuid.UUID).
+//   - pointers for optional fields: pointer is nil if there is no param or param is empty.
+//   - slices from repeated params ("id=1&id=2" or "id[]=1&id[]=2") or comma separated params (tag option "comma").
+//   - nested structs with tag: "filter[status]=active". Structs without tag are bound like embedded.
+//
+// Allowed values could be set by "enum" tag:
+//
+//	type Request struct {
+//		Status []string `query:"status,comma" enum:"active,blocked"`
+//		Filter struct {
+//			From *time.Time `query:"from" layout:"2006-01-02"`
+//		} `query:"filter"`
+//	}
+//
+// Errors of all fields are collected and returned as ErrBindParams with validator.FieldErrors data
+func Bind(values url.Values, dst any, opts ...BindOption) error {
+	options := bindOptions{
+		tag: defaultBindTag,
+	}
+
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	value := reflect.ValueOf(dst)
+	if value.Kind() != reflect.Pointer || value.IsNil() {
+		return ErrBindDestination.AddParam("type", reflect.TypeOf(dst))
+	}
+
+	// nothing to bind to non struct objects, they could be bound from body
+	if value.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+
+	binder := paramsBinder{
+		values:  values,
+		options: options,
+	}
+	binder.bindStruct(value.Elem(), "")
+
+	if len(binder.fields) > 0 {
+		return ErrBindParams.SetData(validator.FieldErrors(binder.fields))
+	}
+
+	return nil
+}
+
+// BindHeaders binds request headers to dst by "header" tags
+func BindHeaders(header http.Header, dst any) error {
+	return Bind(url.Values(header), dst, WithBindTag("header"))
+}
+
+// BindCookies binds request cookies to dst by "cookie" tags
+func BindCookies(cookies []*http.Cookie, dst any) error {
+	values := make(url.Values, len(cookies))
+	for _, cookie := range cookies {
+		values.Add(cookie.Name, cookie.Value)
+	}
+
+	return Bind(values, dst, WithBindTag("cookie"))
+}
+
+type paramsBinder struct {
+	values  url.Values
+	options bindOptions
+	fields  []validator.FieldError
+}
+
+func (binder *paramsBinder) bindStruct(structValue reflect.Value, prefix string) {
+	structType := structValue.Type()
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		fieldValue := structValue.Field(i)
+		if !fieldValue.CanSet() {
+			continue
+		}
+
+		name, tagOptions, _ := strings.Cut(field.Tag.Get(binder.options.tag), ",")
+		if name == "-" {
+			continue
+		}
+
+		// fields without tag are not bound, but structs are bound like embedded
+		if name == "" {
+			if fieldValue.Kind() == reflect.Struct && !isScalarType(fieldValue.Type()) {
+				binder.bindStruct(fieldValue, prefix)
+			}
+
+			continue
+		}
+
+		key := name
+		if prefix != "" {
+			key = prefix + "[" + name + "]"
+		}
+
+		binder.bindField(field, fieldValue, key, tagOptions)
+	}
+}
+
+func (binder *paramsBinder) bindField(field reflect.StructField, fieldValue reflect.Value, key, tagOptions string) {
+	fieldType := fieldValue.Type()
+
+	if fieldType == fileHeaderType || fieldType == fileHeadersType {
+		binder.bindFiles(fieldValue, key)
+		return
+	}
+
+	// nested struct: "filter[status]"
+	structType := fieldType
+	if structType.Kind() == reflect.Pointer {
+		structType = structType.Elem()
+	}
+
+	if structType.Kind() == reflect.Struct && !isScalarType(structType) {
+		if !binder.hasPrefix(key + "[") {
+			return
+		}
+
+		if fieldValue.Kind() == reflect.Pointer {
+			if fieldValue.IsNil() {
+				fieldValue.Set(reflect.New(structType))
+			}
+
+			fieldValue = fieldValue.Elem()
+		}
+
+		binder.bindStruct(fieldValue, key)
+		return
+	}
+
+	raw, ok := binder.lookup(key)
+	if !ok {
+		return
+	}
+
+	if fieldType.Kind() == reflect.Slice && !isScalarType(fieldType) {
+		if slices.Contains(strings.Split(tagOptions, ","), bindOptionComma) {
+			raw = splitComma(raw)
+		}
+
+		items := reflect.MakeSlice(fieldType, 0, len(raw))
+		for _, value := range raw {
+			item := reflect.New(fieldType.Elem()).Elem()
+			if !binder.setValue(field, item, key, value) {
+				return
+			}
+
+			items = reflect.Append(items, item)
+		}
+
+		fieldValue.Set(items)
+		return
+	}
+
+	_ = binder.setValue(field, fieldValue, key, raw[0])
+}
+
+func (binder *paramsBinder) bindFiles(fieldValue reflect.Value, key string) {
+	files, ok := binder.options.files[key]
+	if !ok || len(files) == 0 {
+		return
+	}
+
+	if fieldValue.Type() == fileHeaderType {
+		fieldValue.Set(reflect.ValueOf(files[0]))
+		return
+	}
+
+	fieldValue.Set(reflect.ValueOf(files))
+}
+
+// setValue parses raw value to provided value. Returns false and reports field error if value is invalid
+func (binder *paramsBinder) setValue(field reflect.StructField, value reflect.Value, key, raw string) bool {
+	// empty param of optional field means no value: "?limit=" leaves pointer nil
+	if value.Kind() == reflect.Pointer && raw == "" {
+		return true
+	}
+
+	if enum := field.Tag.Get(bindTagEnum); enum != "" {
+		allowed := strings.Split(enum, ",")
+		if !slices.Contains(allowed, raw) {
+			binder.fields = append(binder.fields, validator.NewFieldError(key, bindRuleEnum, strings.Join(allowed, " "), raw))
+			return false
+		}
+	}
+
+	if value.Kind() == reflect.Pointer {
+		target := reflect.New(value.Type().Elem())
+		if !binder.setValue(field, target.Elem(), key, raw) {
+			return false
+		}
+
+		value.Set(target)
+		return true
+	}
+
+	if err := parseValue(value, raw, field.Tag.Get(bindTagLayout)); err != nil {
+		binder.fields = append(binder.fields, validator.NewFieldError(key, bindRuleType, typeName(value.Type()), raw))
+		return false
+	}
+
+	return true
+}
+
+// lookup returns values by key and "key[]". If there are no values, key is searched in other case
+func (binder *paramsBinder) lookup(key string) ([]string, bool) {
+	values := slices.Concat(binder.values[key], binder.values[key+"[]"])
+	if len(values) > 0 {
+		return values, true
+	}
+
+	for name, values := range binder.values {
+		if strings.EqualFold(name, key) && len(values) > 0 {
+			return values, true
+		}
+	}
+
+	return nil, false
+}
+
+func (binder *paramsBinder) hasPrefix(prefix string) bool {
+	for name := range binder.values {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func parseValue(value reflect.Value, raw, layout string) error {
+	switch value.Type() {
+	case timeType:
+		parsed, err := parseTime(raw, layout)
+		if err != nil {
+			return err
+		}
+
+		value.Set(reflect.ValueOf(parsed))
+		return nil
+	case durationType:
+		parsed, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+
+		value.SetInt(int64(parsed))
+		return nil
+	case timexDurationType:
+		parsed, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+
+		value.Set(reflect.ValueOf(timex.NewDuration(parsed)))
+		return nil
+	}
+
+	if reflect.PointerTo(value.Type()).Implements(textUnmarshaler) {
+		return value.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(raw))
+	}
+
+	if raw == "" && value.Kind() != reflect.String {
+		return nil
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(raw)
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+
+		value.SetBool(parsed)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		parsed, err := strconv.ParseInt(raw, 10, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		value.SetInt(parsed)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		parsed, err := strconv.ParseUint(raw, 10, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		value.SetUint(parsed)
+	case reflect.Float32, reflect.Float64:
+		parsed, err := strconv.ParseFloat(raw, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		value.SetFloat(parsed)
+	default:
+		return ErrBindUnsupportedType.AddParam("type", value.Type())
+	}
+
+	return nil
+}
+
+func parseTime(raw, layout string) (time.Time, error) {
+	if layout != "" {
+		return time.Parse(layout, raw)
+	}
+
+	parsed, err := time.Parse(time.RFC3339, raw)
+	if err == nil {
+		return parsed, nil
+	}
+
+	return time.Parse(time.DateOnly, raw)
+}
+
+// isScalarType returns true for types which are bound from one param (not like nested struct or slice)
+func isScalarType(valueType reflect.Type) bool {
+	switch valueType {
+	case timeType, durationType, timexDurationType:
+		return true
+	}
+
+	return reflect.PointerTo(valueType).Implements(textUnmarshaler)
+}
+
+// typeName returns type name for field error param
+func typeName(valueType reflect.Type) string {
+	switch valueType {
+	case timeType:
+		return "time"
+	case durationType, timexDurationType:
+		return "duration"
+	}
+
+	switch valueType.Kind() {
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	}
+
+	return strings.ToLower(valueType.Name())
+}
+
+func splitComma(values []string) []string {
+	split := make([]string, 0, len(values))
+	for _, value := range values {
+		for _, item := range strings.Split(value, ",") {
+			if item == "" {
+				continue
+			}
+
+			split = append(split, item)
+		}
+	}
+
+	return split
+}
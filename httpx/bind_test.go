@@ -0,0 +1,238 @@
+package httpx
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/boostgo/core/timex"
+	"github.com/boostgo/core/validator"
+
+	"github.com/google/uuid"
+)
+
+type testBindFilter struct {
+	Status string     `query:"status" enum:"active,blocked"`
+	From   *time.Time `query:"from" layout:"2006-01-02"`
+}
+
+type testBindRequest struct {
+	Name     string          `query:"name"`
+	Limit    *int            `query:"limit"`
+	Active   *bool           `query:"active"`
+	Price    float64         `query:"price"`
+	IDs      []int           `query:"id"`
+	Tags     []string        `query:"tags,comma"`
+	Statuses []string        `query:"statuses,comma" enum:"active,blocked"`
+	Created  time.Time       `query:"created"`
+	Timeout  time.Duration   `query:"timeout"`
+	TTL      timex.Duration  `query:"ttl"`
+	UserID   uuid.UUID       `query:"user_id"`
+	Owner    *uuid.UUID      `query:"owner"`
+	Filter   testBindFilter  `query:"filter"`
+	Sort     *testBindFilter `query:"sort"`
+	Ignored  string          `query:"-"`
+}
+
+func intPointer(value int) *int {
+	return &value
+}
+
+func boolPointer(value bool) *bool {
+	return &value
+}
+
+func timePointer(value time.Time) *time.Time {
+	return &value
+}
+
+func TestBind(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name     string
+		values   url.Values
+		expected testBindRequest
+	}{
+		{
+			name: "no params",
+		},
+		{
+			name:     "scalars",
+			values:   url.Values{"name": {"phone"}, "price": {"9.5"}},
+			expected: testBindRequest{Name: "phone", Price: 9.5},
+		},
+		{
+			name:     "case insensitive name",
+			values:   url.Values{"NAME": {"phone"}},
+			expected: testBindRequest{Name: "phone"},
+		},
+		{
+			name:     "pointers",
+			values:   url.Values{"limit": {"10"}, "active": {"false"}},
+			expected: testBindRequest{Limit: intPointer(10), Active: boolPointer(false)},
+		},
+		{
+			name:   "empty params leave pointers nil",
+			values: url.Values{"limit": {""}, "active": {""}, "owner": {""}},
+		},
+		{
+			name:     "repeated params",
+			values:   url.Values{"id": {"1", "2"}},
+			expected: testBindRequest{IDs: []int{1, 2}},
+		},
+		{
+			name:     "bracket params",
+			values:   url.Values{"id[]": {"1", "2"}},
+			expected: testBindRequest{IDs: []int{1, 2}},
+		},
+		{
+			name:     "comma params",
+			values:   url.Values{"tags": {"a,b", "c"}, "statuses": {"active,blocked"}},
+			expected: testBindRequest{Tags: []string{"a", "b", "c"}, Statuses: []string{"active", "blocked"}},
+		},
+		{
+			name:     "time",
+			values:   url.Values{"created": {"2024-05-01T10:00:00Z"}},
+			expected: testBindRequest{Created: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)},
+		},
+		{
+			name:     "date",
+			values:   url.Values{"created": {"2024-05-01"}},
+			expected: testBindRequest{Created: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)},
+		},
+		{
+			name:   "durations",
+			values: url.Values{"timeout": {"1m30s"}, "ttl": {"2h"}},
+			expected: testBindRequest{
+				Timeout: time.Minute + 30*time.Second,
+				TTL:     timex.NewDuration(2 * time.Hour),
+			},
+		},
+		{
+			name:     "text unmarshaler",
+			values:   url.Values{"user_id": {userID.String()}, "owner": {userID.String()}},
+			expected: testBindRequest{UserID: userID, Owner: &userID},
+		},
+		{
+			name:   "nested",
+			values: url.Values{"filter[status]": {"active"}, "filter[from]": {"2024-05-01"}, "sort[status]": {"blocked"}},
+			expected: testBindRequest{
+				Filter: testBindFilter{Status: "active", From: timePointer(time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC))},
+				Sort:   &testBindFilter{Status: "blocked"},
+			},
+		},
+		{
+			name:   "ignored field",
+			values: url.Values{"-": {"value"}, "Ignored": {"value"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var request testBindRequest
+			if err := Bind(tt.values, &request); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(request, tt.expected) {
+				t.Errorf("expected %+v, got %+v", tt.expected, request)
+			}
+		})
+	}
+}
+
+func TestBindErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   url.Values
+		expected []validator.FieldError
+	}{
+		{
+			name:     "invalid integer",
+			values:   url.Values{"limit": {"ten"}},
+			expected: []validator.FieldError{{Field: "limit", Rule: "type", Param: "integer", Value: "ten"}},
+		},
+		{
+			name:     "invalid slice item",
+			values:   url.Values{"id": {"1", "x"}},
+			expected: []validator.FieldError{{Field: "id", Rule: "type", Param: "integer", Value: "x"}},
+		},
+		{
+			name:     "invalid time layout",
+			values:   url.Values{"filter[from]": {"01.05.2024"}},
+			expected: []validator.FieldError{{Field: "filter[from]", Rule: "type", Param: "time", Value: "01.05.2024"}},
+		},
+		{
+			name:     "invalid duration",
+			values:   url.Values{"ttl": {"2 hours"}},
+			expected: []validator.FieldError{{Field: "ttl", Rule: "type", Param: "duration", Value: "2 hours"}},
+		},
+		{
+			name:     "invalid text unmarshaler",
+			values:   url.Values{"user_id": {"not-uuid"}},
+			expected: []validator.FieldError{{Field: "user_id", Rule: "type", Param: "uuid", Value: "not-uuid"}},
+		},
+		{
+			name:     "enum",
+			values:   url.Values{"filter[status]": {"deleted"}},
+			expected: []validator.FieldError{{Field: "filter[status]", Rule: "oneof", Param: "active blocked", Value: "deleted"}},
+		},
+		{
+			name:     "comma enum",
+			values:   url.Values{"statuses": {"active,deleted"}},
+			expected: []validator.FieldError{{Field: "statuses", Rule: "oneof", Param: "active blocked", Value: "deleted"}},
+		},
+		{
+			name:   "errors of all fields",
+			values: url.Values{"limit": {"ten"}, "active": {"maybe"}, "price": {"free"}},
+			expected: []validator.FieldError{
+				{Field: "limit", Rule: "type", Param: "integer", Value: "ten"},
+				{Field: "active", Rule: "type", Param: "boolean", Value: "maybe"},
+				{Field: "price", Rule: "type", Param: "number", Value: "free"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var request testBindRequest
+			err := Bind(tt.values, &request)
+			if !errors.Is(err, ErrBindParams) {
+				t.Fatalf("expected bind params error, got %v", err)
+			}
+
+			fields, ok := validator.Fields(err)
+			if !ok || len(fields) != len(tt.expected) {
+				t.Fatalf("expected %d field errors, got %v", len(tt.expected), fields)
+			}
+
+			for i, field := range fields {
+				expected := tt.expected[i]
+				if field.Field != expected.Field || field.Rule != expected.Rule ||
+					field.Param != expected.Param || field.Value != expected.Value {
+					t.Errorf("expected field error %+v, got %+v", expected, field)
+				}
+			}
+		})
+	}
+}
+
+func TestBindDestination(t *testing.T) {
+	var request testBindRequest
+	if err := Bind(url.Values{}, request); !errors.Is(err, ErrBindDestination) {
+		t.Errorf("expected destination error, got %v", err)
+	}
+
+	if err := Bind(url.Values{}, (*testBindRequest)(nil)); !errors.Is(err, ErrBindDestination) {
+		t.Errorf("expected destination error for nil pointer, got %v", err)
+	}
+
+	// non struct destination is bound from body
+	var items []int
+	if err := Bind(url.Values{"id": {"1"}}, &items); err != nil || items != nil {
+		t.Errorf("expected non struct destination to be skipped, got %v, %v", items, err)
+	}
+}
@@ -43,3 +43,42 @@ func (writer *cacheResponseWriter) Write(b []byte) (int, error) {
 func (writer *cacheResponseWriter) WriteHeader(statusCode int) {
 	writer.inner.WriteHeader(statusCode)
 }
+
+const cacheKeyContextKey = "http-cache-key"
+
+// CachedResponse is response stored by cache middleware
+type CachedResponse struct {
+	Status   int         `json:"status"`
+	Header   http.Header `json:"header,omitempty"`
+	Body     []byte      `json:"body,omitempty"`
+	ETag     string      `json:"etag,omitempty"`
+	StoredAt time.Time   `json:"stored_at"`
+	// MaxAge is time while response is fresh
+	MaxAge time.Duration `json:"max_age"`
+}
+
+// Age returns time passed since response was stored
+func (response CachedResponse) Age() time.Duration {
+	return time.Since(response.StoredAt)
+}
+
+// Fresh returns true if response age is not bigger than its max age
+func (response CachedResponse) Fresh() bool {
+	return response.Age() <= response.MaxAge
+}
+
+// SetCacheKey returns request copy with provided cache key. Key could be got by CacheKey function
+func SetCacheKey(request *http.Request, key string) *http.Request {
+	return request.WithContext(context.WithValue(request.Context(), cacheKeyContextKey, key))
+}
+
+// CacheKey returns request cache key set by SetCacheKey.
+//
+// If key was not set, key is built from request method & URL
+func CacheKey(request *http.Request) string {
+	if key, ok := request.Context().Value(cacheKeyContextKey).(string); ok && key != "" {
+		return key
+	}
+
+	return request.Method + ":" + request.URL.String()
+}
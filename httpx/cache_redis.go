@@ -0,0 +1,41 @@
+package httpx
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/boostgo/core/errorx"
+	"github.com/boostgo/core/redis"
+)
+
+type redisCacheDistributor struct {
+	client redis.Client
+	prefix string
+}
+
+// NewRedisCacheDistributor creates CacheDistributor which stores responses in redis by CacheKey
+func NewRedisCacheDistributor(client redis.Client, prefix string) CacheDistributor {
+	return &redisCacheDistributor{
+		client: client,
+		prefix: prefix,
+	}
+}
+
+func (distributor *redisCacheDistributor) Set(ctx context.Context, request *http.Request, responseBody []byte, ttl time.Duration) error {
+	return distributor.client.Set(ctx, distributor.prefix+CacheKey(request), responseBody, ttl)
+}
+
+func (distributor *redisCacheDistributor) Get(ctx context.Context, request *http.Request) ([]byte, bool, error) {
+	responseBody, err := distributor.client.GetBytes(ctx, distributor.prefix+CacheKey(request))
+	if err != nil {
+		if errors.Is(err, errorx.ErrNotFound) {
+			return nil, false, nil
+		}
+
+		return nil, false, err
+	}
+
+	return responseBody, true, nil
+}
@@ -7,6 +7,8 @@ const (
 	ContentTypeJS   = "text/javascript"
 	ContentTypeCSV  = "text/csv"
 
+	ContentTypeEventStream = "text/event-stream"
+
 	ContentTypeGif  = "image/gif"
 	ContentTypeIco  = "image/vnd.microsoft.icon"
 	ContentTypeJpeg = "image/jpeg"
@@ -22,6 +24,7 @@ const (
 
 	ContentTypeBytes      = "application/octet-stream"
 	ContentTypeJSON       = "application/json"
+	ContentTypeNDJSON     = "application/x-ndjson"
 	ContentTypeXML        = "application/xml"
 	ContentTypeForm       = "application/x-www-form-urlencoded"
 	ContentTypePdf        = "application/pdf"
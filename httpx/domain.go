@@ -2,6 +2,7 @@ package httpx
 
 import (
 	"github.com/boostgo/core/errorx"
+	"github.com/boostgo/core/validator"
 )
 
 const (
@@ -9,15 +10,19 @@ const (
 	statusFailure = "Failure"
 )
 
+// FailureResponse is body of failure response.
+//
+// Fields contains validation errors of request fields (see validator.FieldError), so they could be mapped to form fields
 type FailureResponse struct {
-	Status     string             `json:"status"`
-	StatusCode int                `json:"status_code"`
-	Message    string             `json:"message"`
-	Code       string             `json:"code"`
-	Inner      string             `json:"inner,omitempty"`
-	Context    any                `json:"context,omitempty"`
-	Params     []errorx.Parameter `json:"params,omitempty"`
-	RequestID  string             `json:"request_id,omitempty"`
+	Status     string                 `json:"status"`
+	StatusCode int                    `json:"status_code"`
+	Message    string                 `json:"message"`
+	Code       string                 `json:"code"`
+	Inner      string                 `json:"inner,omitempty"`
+	Context    any                    `json:"context,omitempty"`
+	Params     []errorx.Parameter     `json:"params,omitempty"`
+	Fields     []validator.FieldError `json:"fields,omitempty"`
+	RequestID  string                 `json:"request_id,omitempty"`
 }
 
 func NewFailureResponse(err *errorx.Error, statusCode int, requestID string) FailureResponse {
@@ -26,12 +31,21 @@ func NewFailureResponse(err *errorx.Error, statusCode int, requestID string) Fai
 		message = err.LocaleMessage()
 	}
 
+	fields, _ := validator.Fields(err)
+
+	// field errors without other context are rendered only as fields
+	context := err.Data()
+	if _, ok := context.(validator.FieldErrors); ok {
+		context = nil
+	}
+
 	return FailureResponse{
 		Status:     statusFailure,
 		Message:    message,
 		Code:       err.Message(),
-		Context:    err.Data(),
+		Context:    context,
 		Params:     err.Params(),
+		Fields:     fields,
 		StatusCode: statusCode,
 		RequestID:  requestID,
 	}
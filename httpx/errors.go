@@ -3,18 +3,29 @@ package httpx
 import "github.com/boostgo/core/errorx"
 
 var (
-	ErrParseRequestBody = errorx.New("request_parse_body").SetError(errorx.ErrBadRequest)
-	ErrReadFormFile     = errorx.New("read_form_file").SetError(errorx.ErrBadRequest)
-	ErrOpenFormFile     = errorx.New("open_form_file").SetError(errorx.ErrBadRequest)
+	ErrParseRequestBody    = errorx.New("request_parse_body").SetError(errorx.ErrBadRequest)
+	ErrRequestBodyTooLarge = errorx.New("request_body_too_large").SetError(errorx.ErrEntityTooLarge)
+	ErrReadFormFile        = errorx.New("read_form_file").SetError(errorx.ErrBadRequest)
+	ErrOpenFormFile        = errorx.New("open_form_file").SetError(errorx.ErrBadRequest)
 
 	ErrParseIntParam   = errorx.New("param.parse_int").SetError(errorx.ErrBadRequest)
 	ErrParseFloatParam = errorx.New("param.parse_float").SetError(errorx.ErrBadRequest)
 	ErrParseUUIDParam  = errorx.New("param.parse_uuid").SetError(errorx.ErrBadRequest)
 
+	ErrBindParams          = errorx.New("param.bind").SetError(errorx.ErrBadRequest)
+	ErrBindDestination     = errorx.New("param.bind_destination")
+	ErrBindUnsupportedType = errorx.New("param.bind_unsupported_type")
+
 	ErrPathParamIsEmpty = errorx.New("path_param_empty").SetError(errorx.ErrBadRequest)
 
-	ErrRouteNotFound = errorx.New("route_not_found").SetError(errorx.ErrNotFound)
-	ErrStartServer   = errorx.New("server_start")
+	ErrRouteNotFound     = errorx.New("route_not_found").SetError(errorx.ErrNotFound)
+	ErrStartServer       = errorx.New("server_start")
+	ErrServerOptions     = errorx.New("server_options")
+	ErrRateLimitExceeded = errorx.New("rate_limit_exceeded").SetError(errorx.ErrTooManyRequests)
+
+	ErrIdempotencyKeyRequired = errorx.New("idempotency.key_required").SetError(errorx.ErrBadRequest)
+	ErrIdempotencyKeyReused   = errorx.New("idempotency.key_reused").SetError(errorx.ErrConflict)
+	ErrIdempotencyInProgress  = errorx.New("idempotency.in_progress").SetError(errorx.ErrConflict)
 )
 
 type parseContext struct {
@@ -0,0 +1,188 @@
+package pagex
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/boostgo/core/errorx"
+
+	"github.com/google/uuid"
+)
+
+const cursorSignatureSeparator = "."
+
+const (
+	cursorTypeString = "s"
+	cursorTypeInt    = "i"
+	cursorTypeUint   = "u"
+	cursorTypeFloat  = "f"
+	cursorTypeBool   = "b"
+	cursorTypeTime   = "t"
+	cursorTypeUUID   = "uuid"
+)
+
+// Cursor is decoded keyset cursor. It contains sort key values of the last item of the page
+// (or the first item for previous page cursor)
+type Cursor struct {
+	Values   []any
+	Backward bool
+}
+
+// Empty returns true for the first page (there is no cursor)
+func (cursor Cursor) Empty() bool {
+	return len(cursor.Values) == 0
+}
+
+type cursorPayload struct {
+	Values   []cursorValue `json:"v"`
+	Backward bool          `json:"b,omitempty"`
+}
+
+// cursorValue keeps value type, so values are decoded to the same types (time is time, not string)
+type cursorValue struct {
+	Type  string `json:"t"`
+	Value string `json:"v,omitempty"`
+}
+
+// encodeCursor converts cursor to opaque token: base64(payload) + "." + base64(hmac of payload)
+func encodeCursor(secret []byte, cursor Cursor) (string, error) {
+	payload := cursorPayload{
+		Values:   make([]cursorValue, 0, len(cursor.Values)),
+		Backward: cursor.Backward,
+	}
+
+	for _, value := range cursor.Values {
+		encoded, err := encodeCursorValue(value)
+		if err != nil {
+			return "", err
+		}
+
+		payload.Values = append(payload.Values, encoded)
+	}
+
+	blob, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(blob) +
+		cursorSignatureSeparator +
+		base64.RawURLEncoding.EncodeToString(signCursor(secret, blob)), nil
+}
+
+// decodeCursor checks token signature and converts it to cursor
+func decodeCursor(secret []byte, token string) (Cursor, error) {
+	encodedPayload, encodedSignature, ok := strings.Cut(token, cursorSignatureSeparator)
+	if !ok {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	blob, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor.SetError(errorx.ErrBadRequest, err)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor.SetError(errorx.ErrBadRequest, err)
+	}
+
+	if !hmac.Equal(signature, signCursor(secret, blob)) {
+		return Cursor{}, ErrInvalidCursor.AddParam("reason", "signature")
+	}
+
+	var payload cursorPayload
+	if err = json.Unmarshal(blob, &payload); err != nil {
+		return Cursor{}, ErrInvalidCursor.SetError(errorx.ErrBadRequest, err)
+	}
+
+	cursor := Cursor{
+		Values:   make([]any, 0, len(payload.Values)),
+		Backward: payload.Backward,
+	}
+
+	for _, value := range payload.Values {
+		decoded, err := decodeCursorValue(value)
+		if err != nil {
+			return Cursor{}, ErrInvalidCursor.SetError(errorx.ErrBadRequest, err)
+		}
+
+		cursor.Values = append(cursor.Values, decoded)
+	}
+
+	return cursor, nil
+}
+
+func signCursor(secret, payload []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+func encodeCursorValue(value any) (cursorValue, error) {
+	switch typed := value.(type) {
+	case nil:
+		// "col > NULL" is never true, so nullable columns could not be keyset keys
+		return cursorValue{}, ErrUnsupportedCursorValue.AddParam("reason", "nil")
+	case string:
+		return cursorValue{Type: cursorTypeString, Value: typed}, nil
+	case int:
+		return cursorValue{Type: cursorTypeInt, Value: strconv.FormatInt(int64(typed), 10)}, nil
+	case int8:
+		return cursorValue{Type: cursorTypeInt, Value: strconv.FormatInt(int64(typed), 10)}, nil
+	case int16:
+		return cursorValue{Type: cursorTypeInt, Value: strconv.FormatInt(int64(typed), 10)}, nil
+	case int32:
+		return cursorValue{Type: cursorTypeInt, Value: strconv.FormatInt(int64(typed), 10)}, nil
+	case int64:
+		return cursorValue{Type: cursorTypeInt, Value: strconv.FormatInt(typed, 10)}, nil
+	case uint:
+		return cursorValue{Type: cursorTypeUint, Value: strconv.FormatUint(uint64(typed), 10)}, nil
+	case uint8:
+		return cursorValue{Type: cursorTypeUint, Value: strconv.FormatUint(uint64(typed), 10)}, nil
+	case uint16:
+		return cursorValue{Type: cursorTypeUint, Value: strconv.FormatUint(uint64(typed), 10)}, nil
+	case uint32:
+		return cursorValue{Type: cursorTypeUint, Value: strconv.FormatUint(uint64(typed), 10)}, nil
+	case uint64:
+		return cursorValue{Type: cursorTypeUint, Value: strconv.FormatUint(typed, 10)}, nil
+	case float32:
+		return cursorValue{Type: cursorTypeFloat, Value: strconv.FormatFloat(float64(typed), 'g', -1, 32)}, nil
+	case float64:
+		return cursorValue{Type: cursorTypeFloat, Value: strconv.FormatFloat(typed, 'g', -1, 64)}, nil
+	case bool:
+		return cursorValue{Type: cursorTypeBool, Value: strconv.FormatBool(typed)}, nil
+	case time.Time:
+		return cursorValue{Type: cursorTypeTime, Value: typed.Format(time.RFC3339Nano)}, nil
+	case uuid.UUID:
+		return cursorValue{Type: cursorTypeUUID, Value: typed.String()}, nil
+	default:
+		return cursorValue{}, ErrUnsupportedCursorValue.AddParam("value", value)
+	}
+}
+
+func decodeCursorValue(value cursorValue) (any, error) {
+	switch value.Type {
+	case cursorTypeString:
+		return value.Value, nil
+	case cursorTypeInt:
+		return strconv.ParseInt(value.Value, 10, 64)
+	case cursorTypeUint:
+		return strconv.ParseUint(value.Value, 10, 64)
+	case cursorTypeFloat:
+		return strconv.ParseFloat(value.Value, 64)
+	case cursorTypeBool:
+		return strconv.ParseBool(value.Value)
+	case cursorTypeTime:
+		return time.Parse(time.RFC3339Nano, value.Value)
+	case cursorTypeUUID:
+		return uuid.Parse(value.Value)
+	default:
+		return nil, ErrUnsupportedCursorValue.AddParam("type", value.Type)
+	}
+}
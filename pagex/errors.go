@@ -0,0 +1,9 @@
+package pagex
+
+import "github.com/boostgo/core/errorx"
+
+var (
+	ErrInvalidCursor          = errorx.New("pagex.invalid_cursor").SetError(errorx.ErrBadRequest)
+	ErrUnsupportedCursorValue = errorx.New("pagex.unsupported_cursor_value")
+	ErrKeysetSecret           = errorx.New("pagex.keyset_secret")
+)
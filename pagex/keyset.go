@@ -0,0 +1,297 @@
+package pagex
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/boostgo/core/sql"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// KeysetKey is sort key of keyset pagination. Last key must be unique (like id), so items order is stable.
+// Key columns must be NOT NULL: nil values are not comparable, so they could not be encoded to cursor
+type KeysetKey struct {
+	Column string
+	Desc   bool
+}
+
+// Keyset is keyset (cursor) pagination by sort keys.
+//
+// Cursors are opaque base64 tokens signed by secret, so clients could not change them.
+//
+//	keyset := pagex.MustKeyset(secret, pagex.KeysetKey{Column: "created_at", Desc: true}, pagex.KeysetKey{Column: "id", Desc: true})
+//	cursor, err := keyset.Decode(request.Cursor)
+//
+//	args := sql.NewArguments()
+//	query := "SELECT * FROM orders"
+//	if where := keyset.Where(cursor, args); where != "" {
+//		query += " WHERE " + where
+//	}
+//	query += " ORDER BY " + keyset.OrderBy(cursor) + " LIMIT " + strconv.FormatInt(pagex.CursorLimit(size), 10)
+//
+//	page, err := pagex.NewCursorPage(keyset, cursor, size, orders, func(order Order) []any {
+//		return []any{order.CreatedAt, order.ID}
+//	})
+type Keyset struct {
+	secret []byte
+	keys   []KeysetKey
+}
+
+// NewKeyset creates keyset pagination by provided sort keys.
+//
+// Returns ErrKeysetSecret if secret is empty: cursors signed by empty secret could be forged by clients
+func NewKeyset(secret []byte, keys ...KeysetKey) (*Keyset, error) {
+	if len(secret) == 0 {
+		return nil, ErrKeysetSecret
+	}
+
+	return &Keyset{
+		secret: secret,
+		keys:   keys,
+	}, nil
+}
+
+// MustKeyset calls NewKeyset and panics on error
+func MustKeyset(secret []byte, keys ...KeysetKey) *Keyset {
+	keyset, err := NewKeyset(secret, keys...)
+	if err != nil {
+		panic(err)
+	}
+
+	return keyset
+}
+
+// Encode converts cursor to opaque signed token. Returns ErrUnsupportedCursorValue for nil & unsupported values
+func (keyset *Keyset) Encode(cursor Cursor) (string, error) {
+	return encodeCursor(keyset.secret, cursor)
+}
+
+// Decode checks token signature and converts it to cursor. Empty token is the first page (empty cursor).
+//
+// Returns ErrInvalidCursor if token is invalid or was created for other keys
+func (keyset *Keyset) Decode(token string) (Cursor, error) {
+	if token == "" {
+		return Cursor{}, nil
+	}
+
+	cursor, err := decodeCursor(keyset.secret, token)
+	if err != nil {
+		return Cursor{}, err
+	}
+
+	if len(cursor.Values) != len(keyset.keys) {
+		return Cursor{}, ErrInvalidCursor.AddParam("reason", "keys")
+	}
+
+	return cursor, nil
+}
+
+// Where returns SQL condition (without "WHERE" keyword) which selects items after cursor
+// (or before, for previous page cursor). Returns empty string for empty cursor.
+//
+// If all keys have the same direction, row comparison is used: "(created_at, id) < ($1, $2)"
+func (keyset *Keyset) Where(cursor Cursor, args *sql.Arguments, alias ...string) string {
+	if cursor.Empty() {
+		return ""
+	}
+
+	columns := make([]string, 0, len(keyset.keys))
+	for _, key := range keyset.keys {
+		columns = append(columns, keysetColumn(key.Column, alias...))
+	}
+
+	if keyset.sameDirection() {
+		operator := keyset.operator(keyset.keys[0], cursor)
+		if len(columns) == 1 {
+			return columns[0] + " " + operator + " " + args.Add(cursor.Values[0]).Number()
+		}
+
+		return "(" + strings.Join(columns, ", ") + ") " + operator + " " + args.AddMany(cursor.Values...)
+	}
+
+	// mixed directions: (a > $1) OR (a = $1 AND b < $2) ...
+	conditions := make([]string, 0, len(keyset.keys))
+	for i, key := range keyset.keys {
+		parts := make([]string, 0, i+1)
+		for j := 0; j < i; j++ {
+			parts = append(parts, columns[j]+" = "+args.Add(cursor.Values[j]).Number())
+		}
+
+		parts = append(parts, columns[i]+" "+keyset.operator(key, cursor)+" "+args.Add(cursor.Values[i]).Number())
+		conditions = append(conditions, "("+strings.Join(parts, " AND ")+")")
+	}
+
+	return "(" + strings.Join(conditions, " OR ") + ")"
+}
+
+// OrderBy returns SQL sorts (without "ORDER BY" keywords). For previous page cursor sorts are reversed,
+// items are reversed back by NewCursorPage
+func (keyset *Keyset) OrderBy(cursor Cursor, alias ...string) string {
+	sorts := make([]string, 0, len(keyset.keys))
+	for _, key := range keyset.keys {
+		direction := "ASC"
+		if key.Desc != cursor.Backward {
+			direction = "DESC"
+		}
+
+		sorts = append(sorts, keysetColumn(key.Column, alias...)+" "+direction)
+	}
+
+	return strings.Join(sorts, ", ")
+}
+
+// BSON returns mongo range filter which selects items after cursor (or before, for previous page cursor).
+// Returns empty document for empty cursor
+func (keyset *Keyset) BSON(cursor Cursor) bson.D {
+	if cursor.Empty() {
+		return bson.D{}
+	}
+
+	conditions := make(bson.A, 0, len(keyset.keys))
+	for i, key := range keyset.keys {
+		condition := make(bson.D, 0, i+1)
+		for j := 0; j < i; j++ {
+			condition = append(condition, bson.E{Key: keyset.keys[j].Column, Value: cursor.Values[j]})
+		}
+
+		operator := "$gt"
+		if keyset.operator(key, cursor) == "<" {
+			operator = "$lt"
+		}
+
+		condition = append(condition, bson.E{Key: key.Column, Value: bson.D{{Key: operator, Value: cursor.Values[i]}}})
+		conditions = append(conditions, condition)
+	}
+
+	if len(conditions) == 1 {
+		return conditions[0].(bson.D)
+	}
+
+	return bson.D{{Key: "$or", Value: conditions}}
+}
+
+// SortBSON returns mongo sort document. For previous page cursor sorts are reversed
+func (keyset *Keyset) SortBSON(cursor Cursor) bson.D {
+	sorts := make(bson.D, 0, len(keyset.keys))
+	for _, key := range keyset.keys {
+		direction := 1
+		if key.Desc != cursor.Backward {
+			direction = -1
+		}
+
+		sorts = append(sorts, bson.E{Key: key.Column, Value: direction})
+	}
+
+	return sorts
+}
+
+// operator returns comparison operator of key: ">" for ascending key, "<" for descending. Backward cursor reverses it
+func (keyset *Keyset) operator(key KeysetKey, cursor Cursor) string {
+	if key.Desc != cursor.Backward {
+		return "<"
+	}
+
+	return ">"
+}
+
+func (keyset *Keyset) sameDirection() bool {
+	return !slices.ContainsFunc(keyset.keys, func(key KeysetKey) bool {
+		return key.Desc != keyset.keys[0].Desc
+	})
+}
+
+func keysetColumn(column string, alias ...string) string {
+	if len(alias) > 0 && alias[0] != "" {
+		return alias[0] + "." + column
+	}
+
+	return column
+}
+
+// CursorLimit returns limit of query: one item more than page size, so NewCursorPage knows if there are more items
+func CursorLimit(size int64) int64 {
+	if size <= 0 {
+		size = defaultPageSize
+	}
+
+	return size + 1
+}
+
+// CursorPage is page of keyset pagination. Could be returned as response body
+type CursorPage[T any] struct {
+	Items      []T    `json:"items"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
+	HasMore    bool   `json:"has_more"`
+	Total      *int64 `json:"total,omitempty"`
+}
+
+// WithTotal sets total count of items
+func (page CursorPage[T]) WithTotal(total int64) CursorPage[T] {
+	page.Total = &total
+	return page
+}
+
+// NewCursorPage creates page from items selected with CursorLimit limit by cursor.
+//
+// Values function returns sort key values of item in the same order as keyset keys
+func NewCursorPage[T any](
+	keyset *Keyset,
+	cursor Cursor,
+	size int64,
+	items []T,
+	values func(item T) []any,
+) (CursorPage[T], error) {
+	if size <= 0 {
+		size = defaultPageSize
+	}
+
+	hasMore := int64(len(items)) > size
+	if hasMore {
+		items = items[:size]
+	}
+
+	// previous page items are selected in reversed order
+	if cursor.Backward {
+		slices.Reverse(items)
+	}
+
+	page := CursorPage[T]{
+		Items: items,
+	}
+
+	if len(items) == 0 {
+		return page, nil
+	}
+
+	// going backward there are always items after the page, going forward - if more items are selected
+	hasNext := hasMore
+	hasPrev := !cursor.Empty()
+	if cursor.Backward {
+		hasNext = true
+		hasPrev = hasMore
+	}
+
+	page.HasMore = hasNext
+
+	if hasNext {
+		next, err := keyset.Encode(Cursor{Values: values(items[len(items)-1])})
+		if err != nil {
+			return CursorPage[T]{}, err
+		}
+
+		page.NextCursor = next
+	}
+
+	if hasPrev {
+		prev, err := keyset.Encode(Cursor{Values: values(items[0]), Backward: true})
+		if err != nil {
+			return CursorPage[T]{}, err
+		}
+
+		page.PrevCursor = prev
+	}
+
+	return page, nil
+}
@@ -0,0 +1,291 @@
+package pagex
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/boostgo/core/sql"
+
+	"github.com/google/uuid"
+)
+
+var testSecret = []byte("secret")
+
+func TestKeysetCursor(t *testing.T) {
+	keyset := MustKeyset(testSecret, KeysetKey{Column: "created_at", Desc: true}, KeysetKey{Column: "id"})
+
+	createdAt := time.Date(2024, 1, 2, 10, 0, 0, 123, time.UTC)
+	id := uuid.New()
+	token, err := keyset.Encode(Cursor{Values: []any{createdAt, id}, Backward: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cursor, err := keyset.Decode(token)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !cursor.Backward {
+		t.Error("expected backward cursor")
+	}
+
+	// values are decoded to the same types
+	if value, ok := cursor.Values[0].(time.Time); !ok || !value.Equal(createdAt) {
+		t.Errorf("unexpected time value: %v", cursor.Values[0])
+	}
+
+	if cursor.Values[1] != id {
+		t.Errorf("unexpected uuid value: %v", cursor.Values[1])
+	}
+
+	if cursor, err = keyset.Decode(""); err != nil || !cursor.Empty() {
+		t.Errorf("expected empty cursor for empty token, got %v, %v", cursor, err)
+	}
+}
+
+func TestNewKeysetSecret(t *testing.T) {
+	for _, secret := range [][]byte{nil, {}} {
+		if _, err := NewKeyset(secret, KeysetKey{Column: "id"}); !errors.Is(err, ErrKeysetSecret) {
+			t.Errorf("expected keyset secret error for %q, got %v", secret, err)
+		}
+	}
+
+	if _, err := NewKeyset(testSecret, KeysetKey{Column: "id"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic on empty secret")
+		}
+	}()
+
+	MustKeyset(nil, KeysetKey{Column: "id"})
+}
+
+func TestKeysetCursorTampering(t *testing.T) {
+	keyset := MustKeyset(testSecret, KeysetKey{Column: "id"})
+	token, err := keyset.Encode(Cursor{Values: []any{int64(10)}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	payload, signature, _ := strings.Cut(token, cursorSignatureSeparator)
+
+	// cursor with other value signed by other secret
+	otherToken, err := MustKeyset([]byte("other"), KeysetKey{Column: "id"}).Encode(Cursor{Values: []any{int64(1000)}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	otherPayload, _, _ := strings.Cut(otherToken, cursorSignatureSeparator)
+
+	// cursor for other keys
+	twoKeysToken, err := MustKeyset(testSecret, KeysetKey{Column: "created_at"}, KeysetKey{Column: "id"}).
+		Encode(Cursor{Values: []any{"a", int64(1)}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{name: "other secret", token: otherToken},
+		{name: "changed payload", token: otherPayload + cursorSignatureSeparator + signature},
+		{name: "no signature", token: payload},
+		{name: "broken signature", token: payload + cursorSignatureSeparator + "!!!"},
+		{name: "broken payload", token: "!!!" + cursorSignatureSeparator + signature},
+		{name: "other keys", token: twoKeysToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := keyset.Decode(tt.token); !errors.Is(err, ErrInvalidCursor) {
+				t.Errorf("expected invalid cursor error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestKeysetEncodeRejectsNil(t *testing.T) {
+	keyset := MustKeyset(testSecret, KeysetKey{Column: "deleted_at"}, KeysetKey{Column: "id"})
+	if _, err := keyset.Encode(Cursor{Values: []any{nil, int64(1)}}); !errors.Is(err, ErrUnsupportedCursorValue) {
+		t.Errorf("expected unsupported value error, got %v", err)
+	}
+
+	if _, err := keyset.Encode(Cursor{Values: []any{struct{}{}, int64(1)}}); !errors.Is(err, ErrUnsupportedCursorValue) {
+		t.Errorf("expected unsupported value error, got %v", err)
+	}
+}
+
+func TestKeysetWhere(t *testing.T) {
+	tests := []struct {
+		name     string
+		keys     []KeysetKey
+		cursor   Cursor
+		expected string
+		args     int
+	}{
+		{
+			name:     "empty cursor",
+			keys:     []KeysetKey{{Column: "id"}},
+			expected: "",
+		},
+		{
+			name:     "single key",
+			keys:     []KeysetKey{{Column: "id", Desc: true}},
+			cursor:   Cursor{Values: []any{int64(10)}},
+			expected: "o.id < $2",
+			args:     2,
+		},
+		{
+			name:     "same direction",
+			keys:     []KeysetKey{{Column: "created_at", Desc: true}, {Column: "id", Desc: true}},
+			cursor:   Cursor{Values: []any{"2024-01-02", int64(10)}},
+			expected: "(o.created_at, o.id) < ($2, $3)",
+			args:     3,
+		},
+		{
+			name:     "same direction backward",
+			keys:     []KeysetKey{{Column: "created_at", Desc: true}, {Column: "id", Desc: true}},
+			cursor:   Cursor{Values: []any{"2024-01-02", int64(10)}, Backward: true},
+			expected: "(o.created_at, o.id) > ($2, $3)",
+			args:     3,
+		},
+		{
+			name:     "mixed directions",
+			keys:     []KeysetKey{{Column: "amount", Desc: true}, {Column: "id"}},
+			cursor:   Cursor{Values: []any{float64(100), int64(10)}},
+			expected: "((o.amount < $2) OR (o.amount = $3 AND o.id > $4))",
+			args:     4,
+		},
+		{
+			name:     "mixed directions backward",
+			keys:     []KeysetKey{{Column: "amount", Desc: true}, {Column: "id"}},
+			cursor:   Cursor{Values: []any{float64(100), int64(10)}, Backward: true},
+			expected: "((o.amount > $2) OR (o.amount = $3 AND o.id < $4))",
+			args:     4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// placeholders continue numbering of already added arguments
+			args := sql.NewArguments("tenant")
+			where := MustKeyset(testSecret, tt.keys...).Where(tt.cursor, args, "o")
+			if where != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, where)
+			}
+
+			if tt.args > 0 && len(args.Args()) != tt.args {
+				t.Errorf("expected %d args, got %v", tt.args, args.Args())
+			}
+		})
+	}
+}
+
+func TestKeysetOrderBy(t *testing.T) {
+	keyset := MustKeyset(testSecret, KeysetKey{Column: "amount", Desc: true}, KeysetKey{Column: "id"})
+
+	if orderBy := keyset.OrderBy(Cursor{}); orderBy != "amount DESC, id ASC" {
+		t.Errorf("unexpected order by: %q", orderBy)
+	}
+
+	if orderBy := keyset.OrderBy(Cursor{Values: []any{1, 1}, Backward: true}, "o"); orderBy != "o.amount ASC, o.id DESC" {
+		t.Errorf("unexpected backward order by: %q", orderBy)
+	}
+}
+
+func TestNewCursorPage(t *testing.T) {
+	keyset := MustKeyset(testSecret, KeysetKey{Column: "id"})
+	values := func(item int64) []any {
+		return []any{item}
+	}
+
+	decode := func(t *testing.T, token string) Cursor {
+		t.Helper()
+		cursor, err := keyset.Decode(token)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return cursor
+	}
+
+	t.Run("first page", func(t *testing.T) {
+		page, err := NewCursorPage(keyset, Cursor{}, 2, []int64{1, 2, 3}, values)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(page.Items) != 2 || !page.HasMore || page.PrevCursor != "" {
+			t.Fatalf("unexpected page: %+v", page)
+		}
+
+		if next := decode(t, page.NextCursor); next.Values[0] != int64(2) || next.Backward {
+			t.Errorf("unexpected next cursor: %+v", next)
+		}
+	})
+
+	t.Run("last page", func(t *testing.T) {
+		cursor := Cursor{Values: []any{int64(2)}}
+		page, err := NewCursorPage(keyset, cursor, 2, []int64{3}, values)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if page.HasMore || page.NextCursor != "" {
+			t.Fatalf("expected no next page, got %+v", page)
+		}
+
+		if prev := decode(t, page.PrevCursor); prev.Values[0] != int64(3) || !prev.Backward {
+			t.Errorf("unexpected prev cursor: %+v", prev)
+		}
+	})
+
+	t.Run("previous page", func(t *testing.T) {
+		// items are selected in reversed order
+		cursor := Cursor{Values: []any{int64(5)}, Backward: true}
+		page, err := NewCursorPage(keyset, cursor, 2, []int64{4, 3, 2}, values)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(page.Items) != 2 || page.Items[0] != 3 || page.Items[1] != 4 {
+			t.Fatalf("expected reversed items [3 4], got %v", page.Items)
+		}
+
+		if next := decode(t, page.NextCursor); next.Values[0] != int64(4) || next.Backward || !page.HasMore {
+			t.Errorf("unexpected next cursor: %+v", next)
+		}
+
+		if prev := decode(t, page.PrevCursor); prev.Values[0] != int64(3) || !prev.Backward {
+			t.Errorf("unexpected prev cursor: %+v", prev)
+		}
+	})
+
+	t.Run("first page going backward", func(t *testing.T) {
+		cursor := Cursor{Values: []any{int64(3)}, Backward: true}
+		page, err := NewCursorPage(keyset, cursor, 2, []int64{2, 1}, values)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if page.PrevCursor != "" || page.NextCursor == "" {
+			t.Errorf("expected only next cursor, got %+v", page)
+		}
+	})
+
+	t.Run("empty page", func(t *testing.T) {
+		page, err := NewCursorPage(keyset, Cursor{}, 2, []int64{}, values)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if page.HasMore || page.NextCursor != "" || page.PrevCursor != "" {
+			t.Errorf("unexpected page: %+v", page)
+		}
+	})
+}
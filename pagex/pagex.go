@@ -8,6 +8,7 @@ type Pagination struct {
 	SizeMultiplier int64 `default:"1"`
 }
 
+// MaxPages returns count of pages with provided size for count of items. There is at least 1 page
 func MaxPages(size, count int64) int64 {
 	if size <= 0 {
 		size = defaultPageSize
@@ -18,11 +19,7 @@ func MaxPages(size, count int64) int64 {
 	}
 
 	maxPages := count / size
-	if maxPages == 0 {
-		maxPages++
-	}
-
-	if size%count > 0 {
+	if count%size > 0 {
 		maxPages++
 	}
 
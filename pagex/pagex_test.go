@@ -0,0 +1,37 @@
+package pagex
+
+import "testing"
+
+func TestMaxPages(t *testing.T) {
+	tests := []struct {
+		name     string
+		size     int64
+		count    int64
+		expected int64
+	}{
+		{name: "no items", size: 10, count: 0, expected: 1},
+		{name: "negative count", size: 10, count: -1, expected: 1},
+		{name: "one page", size: 10, count: 10, expected: 1},
+		{name: "partial last page", size: 10, count: 11, expected: 2},
+		{name: "full pages", size: 10, count: 30, expected: 3},
+		{name: "default size", size: 0, count: 41, expected: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if pages := MaxPages(tt.size, tt.count); pages != tt.expected {
+				t.Errorf("expected %d, got %d", tt.expected, pages)
+			}
+		})
+	}
+}
+
+func TestCursorLimit(t *testing.T) {
+	if limit := CursorLimit(10); limit != 11 {
+		t.Errorf("expected 11, got %d", limit)
+	}
+
+	if limit := CursorLimit(0); limit != defaultPageSize+1 {
+		t.Errorf("expected default limit, got %d", limit)
+	}
+}
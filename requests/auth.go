@@ -1,5 +1,11 @@
 package requests
 
+import (
+	"net/http"
+
+	"github.com/boostgo/core/authx"
+)
+
 type basicAuth struct {
 	username string
 	password string
@@ -11,3 +17,26 @@ func initBasicAuth(username, password string) basicAuth {
 		password: password,
 	}
 }
+
+// HMACSigner returns middleware which signs every attempt of the request by HMAC-SHA256 (see authx.SignRequest).
+//
+// Endpoints could verify signature by authx.HMACVerifier (echox.SignatureMiddleware).
+// Middleware must be added last (inner one), so headers set by other middlewares are signed too.
+//
+// Request is not sent if it could not be signed (for example, StreamFormDataWriter body could not be read twice)
+func HMACSigner(keyID, secret string) Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			signed := req.Clone(req.Context())
+			if err := authx.SignRequest(signed, keyID, []byte(secret)); err != nil {
+				if req.Body != nil {
+					_ = req.Body.Close()
+				}
+
+				return nil, err
+			}
+
+			return next.Do(signed)
+		})
+	}
+}
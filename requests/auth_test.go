@@ -3,10 +3,15 @@ package requests
 import (
 	"context"
 	"encoding/base64"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/boostgo/core/authx"
+	"github.com/boostgo/core/retry"
 )
 
 // Test Basic Authentication
@@ -325,3 +330,183 @@ func TestAuthenticationEdgeCases(t *testing.T) {
 		}
 	})
 }
+
+// Test HMAC request signing
+func TestHMACSigner(t *testing.T) {
+	verifier := authx.NewHMACVerifier(
+		authx.StaticHMACSecrets(map[string]string{"service": "secret"}),
+		authx.WithNonceStore(authx.NewMemoryNonceStore(0)),
+	)
+
+	tests := []struct {
+		name           string
+		secret         string
+		expectedStatus int
+	}{
+		{
+			name:           "valid signature",
+			secret:         "secret",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "wrong secret",
+			secret:         "wrong",
+			expectedStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if _, err := verifier.Verify(r.Context(), r); err != nil {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer server.Close()
+
+			resp, err := R(context.Background()).
+				Query("b", "2").
+				Query("a", "1").
+				Use(HMACSigner("service", tt.secret)).
+				POST(server.URL+"/orders", map[string]any{"id": 1})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if resp.StatusCode() != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, resp.StatusCode())
+			}
+		})
+	}
+
+	t.Run("replayed request", func(t *testing.T) {
+		calls := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			if _, err := verifier.Verify(r.Context(), r); err != nil {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		request, err := http.NewRequest(http.MethodGet, server.URL+"/orders?id=1", nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err = authx.SignRequest(request, "service", []byte("secret")); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		for _, expectedStatus := range []int{http.StatusOK, http.StatusUnauthorized} {
+			resp, err := http.DefaultClient.Do(request)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			_ = resp.Body.Close()
+
+			if resp.StatusCode != expectedStatus {
+				t.Errorf("call %d: expected status %d, got %d", calls, expectedStatus, resp.StatusCode)
+			}
+		}
+	})
+
+	t.Run("retried request", func(t *testing.T) {
+		calls := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			if _, err := verifier.Verify(r.Context(), r); err != nil {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+
+			if calls == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		// every attempt is signed with new nonce
+		resp, err := R(context.Background()).
+			RetryPolicy(retry.NewFixedDelay(time.Millisecond, 2)).
+			Use(HMACSigner("service", "secret")).
+			PUT(server.URL+"/orders", map[string]any{"id": 1})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if resp.StatusCode() != http.StatusOK {
+			t.Errorf("expected status 200, got %d", resp.StatusCode())
+		}
+
+		if calls != 2 {
+			t.Errorf("expected 2 calls, got %d", calls)
+		}
+	})
+	t.Run("headers of other middlewares are signed", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if _, err := verifier.Verify(r.Context(), r); err != nil || r.URL.Query().Get("tenant") != "acme" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		tenant := func(next Doer) Doer {
+			return DoerFunc(func(req *http.Request) (*http.Response, error) {
+				req = req.Clone(req.Context())
+				query := req.URL.Query()
+				query.Set("tenant", "acme")
+				req.URL.RawQuery = query.Encode()
+				return next.Do(req)
+			})
+		}
+
+		resp, err := R(context.Background()).
+			Use(tenant, HMACSigner("service", "secret")).
+			GET(server.URL + "/orders")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if resp.StatusCode() != http.StatusOK {
+			t.Errorf("expected status 200, got %d", resp.StatusCode())
+		}
+	})
+
+	t.Run("unsigned request is not sent", func(t *testing.T) {
+		calls := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		// stream reader could not be read twice: for signature and for sending
+		formData := NewStreamFormData()
+		if err := formData.AddReader("file", "file.txt", strings.NewReader("content")); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		_, err := R(context.Background()).
+			Use(HMACSigner("service", "secret")).
+			POST(server.URL+"/upload", formData)
+		if !errors.Is(err, authx.ErrSignRequest) {
+			t.Fatalf("expected sign request error, got %v", err)
+		}
+
+		if calls != 0 {
+			t.Errorf("expected no calls, got %d", calls)
+		}
+	})
+}
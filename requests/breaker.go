@@ -0,0 +1,73 @@
+package requests
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+
+	"github.com/boostgo/core/retry"
+)
+
+// errBreakerFailureStatus is returned to breaker for responses which are counted as failures (5xx & 429).
+// Request itself returns such responses without error
+var errBreakerFailureStatus = errors.New("requests: breaker failure status")
+
+// hostBreakers keeps circuit breaker per request host
+type hostBreakers struct {
+	options  []retry.BreakerOption
+	breakers map[string]*retry.Breaker
+	mx       sync.Mutex
+}
+
+func newHostBreakers(opts ...retry.BreakerOption) *hostBreakers {
+	return &hostBreakers{
+		options:  opts,
+		breakers: make(map[string]*retry.Breaker),
+	}
+}
+
+// get returns breaker of the host, creating it on first call
+func (breakers *hostBreakers) get(host string) *retry.Breaker {
+	breakers.mx.Lock()
+	defer breakers.mx.Unlock()
+
+	breaker, ok := breakers.breakers[host]
+	if !ok {
+		breaker = retry.NewBreaker(host, breakers.options...)
+		breakers.breakers[host] = breaker
+	}
+
+	return breaker
+}
+
+// middleware returns middleware which sends requests through breaker of the request host
+func (breakers *hostBreakers) middleware() Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			var resp *http.Response
+			err := breakers.get(req.URL.Host).Execute(req.Context(), func(_ context.Context) error {
+				var err error
+				resp, err = next.Do(req)
+				if err != nil {
+					return err
+				}
+
+				if isBreakerFailureStatus(resp.StatusCode) {
+					return errBreakerFailureStatus
+				}
+
+				return nil
+			})
+			if errors.Is(err, errBreakerFailureStatus) {
+				return resp, nil
+			}
+
+			return resp, err
+		})
+	}
+}
+
+func isBreakerFailureStatus(code int) bool {
+	return code >= http.StatusInternalServerError || code == http.StatusTooManyRequests
+}
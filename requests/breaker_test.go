@@ -0,0 +1,69 @@
+package requests
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/boostgo/core/errorx"
+	"github.com/boostgo/core/retry"
+)
+
+func TestClientBreaker(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := New().
+		SetBaseURL(server.URL).
+		Breaker(retry.WithBreakerMinRequests(2))
+
+	for i := 0; i < 2; i++ {
+		resp, err := client.R(context.Background()).GET("/")
+		if err != nil {
+			t.Fatalf("expected response, got error: %v", err)
+		}
+
+		if resp.StatusCode() != http.StatusInternalServerError {
+			t.Fatalf("expected status 500, got %d", resp.StatusCode())
+		}
+	}
+
+	_, err := client.R(context.Background()).GET("/")
+	if !errors.Is(err, retry.ErrBreakerOpen) || !errors.Is(err, errorx.ErrServiceUnavailable) {
+		t.Fatalf("expected breaker open error, got %v", err)
+	}
+
+	if calls.Load() != 2 {
+		t.Fatalf("expected 2 server calls, got %d", calls.Load())
+	}
+}
+
+func TestClientBreakerPerHost(t *testing.T) {
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer failing.Close()
+
+	healthy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer healthy.Close()
+
+	client := New().Breaker(retry.WithBreakerMinRequests(1))
+
+	_, _ = client.R(context.Background()).GET(failing.URL)
+	if _, err := client.R(context.Background()).GET(failing.URL); !errors.Is(err, retry.ErrBreakerOpen) {
+		t.Fatalf("expected breaker open error, got %v", err)
+	}
+
+	if _, err := client.R(context.Background()).GET(healthy.URL); err != nil {
+		t.Fatalf("expected healthy host to be called, got %v", err)
+	}
+}
@@ -6,23 +6,31 @@ import (
 	"time"
 
 	"github.com/boostgo/core/convert"
+	"github.com/boostgo/core/retry"
 )
 
 // Client web client which allow to send HTTP requests.
 //
 // It can simplify sending requests by containing base url, headers and cookies for created requests from this client.
 //
-// There is retry mechanism for many request sending till it proceed success.
+// There is retry mechanism for many request sending till it proceed success (see RetryPolicy & RetryIf).
 type Client struct {
 	baseURL string
 	logging bool
 	client  *http.Client
 
-	retryCount int
-	retryWait  time.Duration
+	retryCount    int
+	retryWait     time.Duration
+	retryPolicy   retry.Policy
+	retryIf       RetryIfFunc
+	retryMethods  []string
+	retryAfterMax time.Duration
 
 	timeout time.Duration
 
+	breakers    *hostBreakers
+	middlewares []Middleware
+
 	basic       basicAuth
 	bearerToken string
 
@@ -33,9 +41,15 @@ type Client struct {
 	queryVariables map[string]any
 }
 
+// New creates client with own transport, which is shared between requests of the client.
+//
+// TLS verification is enabled. Transport could be changed by Transport method
 func New() *Client {
 	return &Client{
 		logging: true,
+		client: &http.Client{
+			Transport: newDefaultTransport(),
+		},
 
 		headers:        make(map[string]any),
 		cookies:        make(map[string]any),
@@ -65,6 +79,20 @@ func (client *Client) Client(httpClient http.Client) *Client {
 	return client
 }
 
+// Transport sets transport for every nested request, for example created by NewTransport.
+//
+// Transport is shared between requests, so connections are reused
+func (client *Client) Transport(transport http.RoundTripper) *Client {
+	if transport == nil {
+		return client
+	}
+
+	httpClient := *client.client
+	httpClient.Transport = transport
+	client.client = &httpClient
+	return client
+}
+
 // RetryCount sets count of retries need.
 //
 // By default, retry count is 1
@@ -89,6 +117,39 @@ func (client *Client) RetryWait(wait time.Duration) *Client {
 	return client
 }
 
+// RetryPolicy sets retry strategy for every nested request, for example retry.NewExponentialBackoffWithJitter.
+//
+// If policy is not set, RetryCount & RetryWait are used as fixed delay policy
+func (client *Client) RetryPolicy(policy retry.Policy) *Client {
+	client.retryPolicy = policy
+	return client
+}
+
+// RetryIf sets function which decides if request must be retried.
+//
+// By default, DefaultRetryIf is used
+func (client *Client) RetryIf(retryIf RetryIfFunc) *Client {
+	client.retryIf = retryIf
+	return client
+}
+
+// RetryAfterMax sets max delay which is taken from "Retry-After" response header for every nested request.
+// If server asks to wait longer, request is not retried and the response is returned.
+//
+// By default, max delay is 1 minute
+func (client *Client) RetryAfterMax(max time.Duration) *Client {
+	client.retryAfterMax = max
+	return client
+}
+
+// RetryMethods sets methods which could be retried.
+//
+// By default, only idempotent methods are retried: GET, HEAD, OPTIONS, TRACE, PUT & DELETE
+func (client *Client) RetryMethods(methods ...string) *Client {
+	client.retryMethods = methods
+	return client
+}
+
 // Timeout sets timeout for waiting for request.
 //
 // By default, there is no timeout
@@ -101,6 +162,23 @@ func (client *Client) Timeout(timeout time.Duration) *Client {
 	return client
 }
 
+// Use adds middlewares for every nested request. First middleware is the outer one.
+//
+// Middlewares wrap round trip of every attempt: auth token refresh, logging, metrics, request signing, mocks, etc.
+func (client *Client) Use(middlewares ...Middleware) *Client {
+	client.middlewares = append(client.middlewares, middlewares...)
+	return client
+}
+
+// Breaker turns on circuit breaker per host for every nested request.
+//
+// Transport errors, 5xx & 429 responses are counted as failures.
+// Requests to the host with open breaker return retry.ErrBreakerOpen (errorx.ErrServiceUnavailable)
+func (client *Client) Breaker(opts ...retry.BreakerOption) *Client {
+	client.breakers = newHostBreakers(opts...)
+	return client
+}
+
 // BasicAuth sets username & password for basic auth mechanism
 func (client *Client) BasicAuth(username, password string) *Client {
 	if username == "" {
@@ -119,7 +197,7 @@ func (client *Client) BearerToken(token string) *Client {
 	return client
 }
 
-// Options sets option functions which can modify created request.
+// Options sets option functions which can modify created request. Options are applied before every attempt.
 func (client *Client) Options(opts ...RequestOption) *Client {
 	if len(opts) == 0 {
 		return client
@@ -206,7 +284,13 @@ func (client *Client) R(ctx context.Context) *Request {
 		Queries(client.queryVariables).
 		RetryCount(client.retryCount).
 		RetryWait(client.retryWait).
+		RetryPolicy(client.retryPolicy).
+		RetryIf(client.retryIf).
+		RetryMethods(client.retryMethods...).
+		RetryAfterMax(client.retryAfterMax).
 		Timeout(client.timeout).
+		setBreakers(client.breakers).
+		Use(client.middlewares...).
 		BasicAuth(client.basic.username, client.basic.password).
 		BearerToken(client.bearerToken).
 		Options(client.options...)
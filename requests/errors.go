@@ -2,8 +2,15 @@ package requests
 
 import "github.com/boostgo/core/errorx"
 
+// statuses of httpx.SuccessResponse & httpx.FailureResponse envelopes
+const (
+	successStatus = "Success"
+	failureStatus = "Failure"
+)
+
 var (
 	ErrParseResponseBody           = errorx.New("response.parse_body")
+	ErrResponseFailure             = errorx.New("response.failure")
 	ErrExportResponseMustBePointer = errorx.New("response.export_must_be_pointer")
 	ErrContextCanceledAndHasError  = errorx.New("context.canceled_and_has_error")
 	ErrContextCanceled             = errorx.New("context.canceled")
@@ -13,8 +20,18 @@ var (
 	ErrFormDataWriterAddFile = errorx.New("formdata_writer.add_file")
 	ErrFormDataWriterSet     = errorx.New("formdata_writer.set")
 	ErrFormDataWriterClose   = errorx.New("formdata_writer.close")
+	ErrFormDataWriterReread  = errorx.New("formdata_writer.reread")
 
 	ErrRequestRetryDo = errorx.New("request.retry_do")
+
+	ErrDownload         = errorx.New("request.download")
+	ErrDownloadStatus   = errorx.New("request.download_status")
+	ErrDownloadChecksum = errorx.New("request.download_checksum")
+
+	ErrTransportTLSVersion        = errorx.New("transport.tls_version")
+	ErrTransportCA                = errorx.New("transport.ca")
+	ErrTransportClientCertificate = errorx.New("transport.client_certificate")
+	ErrTransportProxy             = errorx.New("transport.proxy")
 )
 
 type responseBodyContext struct {
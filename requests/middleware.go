@@ -0,0 +1,170 @@
+package requests
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/boostgo/core/log"
+	"github.com/boostgo/core/trace"
+)
+
+const (
+	TraceProtocol = "http"
+	TraceKey      = "X-Trace-ID"
+
+	redactedValue = "***"
+)
+
+func init() {
+	trace.RegisterProtocol(TraceProtocol, TraceKey)
+}
+
+// Doer sends HTTP request and returns response. *http.Client implements it
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// DoerFunc is function implementation of Doer
+type DoerFunc func(req *http.Request) (*http.Response, error)
+
+func (fn DoerFunc) Do(req *http.Request) (*http.Response, error) {
+	return fn(req)
+}
+
+// Middleware wraps round trip of the request. It could modify request, response or do not call next at all (mocks).
+//
+// Middleware is called on every attempt of the request (every retry)
+type Middleware func(next Doer) Doer
+
+// send does request through middlewares chain. First middleware is the outer one
+func (request *Request) send(req *http.Request) (*http.Response, error) {
+	var doer Doer = request.getClient()
+	if request.breakers != nil {
+		doer = request.breakers.middleware()(doer)
+	}
+
+	for i := len(request.middlewares) - 1; i >= 0; i-- {
+		doer = request.middlewares[i](doer)
+	}
+
+	return doer.Do(req)
+}
+
+// TraceMiddleware sets trace id from request context to the header ("X-Trace-ID" by default).
+//
+// Header is not changed if it is already set
+func TraceMiddleware(header ...string) Middleware {
+	key := TraceKey
+	if len(header) > 0 && header[0] != "" {
+		key = header[0]
+	}
+
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			if req.Header.Get(key) != "" {
+				return next.Do(req)
+			}
+
+			traceID, ok := trace.TryGet(req.Context())
+			if !ok {
+				return next.Do(req)
+			}
+
+			req = req.Clone(req.Context())
+			req.Header.Set(key, traceID)
+			return next.Do(req)
+		})
+	}
+}
+
+// LogOption modifies LogMiddleware settings
+type LogOption func(options *logOptions)
+
+type logOptions struct {
+	headers bool
+	redact  []string
+}
+
+// WithLogHeaders turns on logging of request & response headers
+func WithLogHeaders() LogOption {
+	return func(options *logOptions) {
+		options.headers = true
+	}
+}
+
+// WithLogRedact adds headers which values are hidden in logs.
+//
+// By default, "Authorization", "Proxy-Authorization", "Cookie" & "Set-Cookie" are hidden
+func WithLogRedact(headers ...string) LogOption {
+	return func(options *logOptions) {
+		for _, header := range headers {
+			options.redact = append(options.redact, http.CanonicalHeaderKey(header))
+		}
+	}
+}
+
+// LogMiddleware writes log of every request attempt: method, url, status & duration.
+//
+// Failed requests (transport errors & 5xx statuses) are logged on error level, others on info level
+func LogMiddleware(opts ...LogOption) Middleware {
+	options := logOptions{
+		redact: []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"},
+	}
+
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next.Do(req)
+
+			event := log.Info()
+			if err != nil || (resp != nil && resp.StatusCode >= http.StatusInternalServerError) {
+				event = log.Error()
+			}
+
+			event = event.
+				Ctx(req.Context()).
+				Str("method", req.Method).
+				Str("url", req.URL.Redacted()).
+				Duration("duration", time.Since(start))
+
+			if options.headers {
+				event = event.Any("request_headers", redactHeaders(req.Header, options.redact))
+			}
+
+			if resp != nil {
+				event = event.Int("status", resp.StatusCode)
+				if options.headers {
+					event = event.Any("response_headers", redactHeaders(resp.Header, options.redact))
+				}
+			}
+
+			if err != nil {
+				event = event.Err(err)
+			}
+
+			event.Msg("HTTP request")
+			return resp, err
+		})
+	}
+}
+
+// redactHeaders returns headers copy with hidden values of provided headers
+func redactHeaders(header http.Header, redact []string) map[string]string {
+	headers := make(map[string]string, len(header))
+	for key, values := range header {
+		if slices.Contains(redact, http.CanonicalHeaderKey(key)) {
+			headers[key] = redactedValue
+			continue
+		}
+
+		headers[key] = strings.Join(values, ", ")
+	}
+
+	return headers
+}
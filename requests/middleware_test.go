@@ -0,0 +1,106 @@
+package requests
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/boostgo/core/trace"
+)
+
+func TestMiddlewareOrder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Header.Get("X-Order")))
+	}))
+	defer server.Close()
+
+	order := func(name string) Middleware {
+		return func(next Doer) Doer {
+			return DoerFunc(func(req *http.Request) (*http.Response, error) {
+				req.Header.Set("X-Order", req.Header.Get("X-Order")+name)
+				return next.Do(req)
+			})
+		}
+	}
+
+	resp, err := New().
+		Use(order("a"), order("b")).
+		R(context.Background()).
+		Use(order("c")).
+		GET(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if string(resp.BodyRaw()) != "abc" {
+		t.Errorf("expected middlewares order abc, got %q", resp.BodyRaw())
+	}
+}
+
+func TestMiddlewareMock(t *testing.T) {
+	mock := func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusTeapot,
+				Header:     http.Header{},
+				Body:       io.NopCloser(bytes.NewBufferString(`{"mocked":true}`)),
+				Request:    req,
+			}, nil
+		})
+	}
+
+	var export struct {
+		Mocked bool `json:"mocked"`
+	}
+
+	resp, err := R(context.Background()).
+		Use(mock).
+		Result(&export).
+		GET("http://mocked.local/resource")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if resp.StatusCode() != http.StatusTeapot || !export.Mocked {
+		t.Errorf("expected mocked response, got %d %+v", resp.StatusCode(), export)
+	}
+}
+
+func TestTraceMiddleware(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Header.Get(TraceKey)))
+	}))
+	defer server.Close()
+
+	ctx := trace.SetID(context.Background(), "trace-123")
+	resp, err := R(ctx).
+		Use(TraceMiddleware(), LogMiddleware(WithLogHeaders())).
+		BearerToken("secret").
+		GET(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if string(resp.BodyRaw()) != "trace-123" {
+		t.Errorf("expected trace id header, got %q", resp.BodyRaw())
+	}
+}
+
+func TestRedactHeaders(t *testing.T) {
+	headers := redactHeaders(http.Header{
+		"Authorization": {"Bearer secret"},
+		"X-Api-Key":     {"key"},
+		"Accept":        {"application/json"},
+	}, []string{"Authorization", "X-Api-Key"})
+
+	if headers["Authorization"] != redactedValue || headers["X-Api-Key"] != redactedValue {
+		t.Errorf("expected secret headers to be hidden, got %v", headers)
+	}
+
+	if headers["Accept"] != "application/json" {
+		t.Errorf("expected other headers to be kept, got %v", headers)
+	}
+}
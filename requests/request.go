@@ -3,10 +3,10 @@ package requests
 import (
 	"bytes"
 	"context"
-	"crypto/tls"
 	"encoding/json"
 	"io"
 	"net/http"
+	"strings"
 	"sync"
 	"time"
 
@@ -15,10 +15,12 @@ import (
 	"github.com/boostgo/core/errorx"
 	"github.com/boostgo/core/httpx"
 	"github.com/boostgo/core/reflectx"
+	"github.com/boostgo/core/retry"
 
 	"github.com/rs/zerolog/log"
 )
 
+// RequestOption modifies created request. Options are applied before every attempt (every retry)
 type RequestOption func(request *http.Request)
 
 type Request struct {
@@ -32,11 +34,22 @@ type Request struct {
 	headers        map[string]any
 	cookies        map[string]any
 
-	retryCount int
-	retryWait  time.Duration
+	retryCount    int
+	retryWait     time.Duration
+	policy        retry.Policy
+	retryIf       RetryIfFunc
+	retryMethods  []string
+	retryAfterMax time.Duration
 
 	timeout time.Duration
 
+	breakers    *hostBreakers
+	middlewares []Middleware
+
+	streamResponse bool
+	uploadProgress ProgressFunc
+	singleAttempt  bool
+
 	basic       basicAuth
 	bearerToken string
 
@@ -62,8 +75,9 @@ func R(ctx context.Context) *Request {
 		headers:        make(map[string]any),
 		cookies:        make(map[string]any),
 
-		retryCount: 1,
-		retryWait:  time.Millisecond * 100,
+		retryCount:    1,
+		retryWait:     time.Millisecond * 100,
+		retryAfterMax: defaultRetryAfterMax,
 
 		options: make([]RequestOption, 0),
 
@@ -76,7 +90,12 @@ func (request *Request) setBaseURL(baseURL string) *Request {
 	return request
 }
 
-// Options sets option functions which can modify created request.
+func (request *Request) setBreakers(breakers *hostBreakers) *Request {
+	request.breakers = breakers
+	return request
+}
+
+// Options sets option functions which can modify created request. Options are applied before every attempt.
 func (request *Request) Options(opts ...RequestOption) *Request {
 	if len(opts) == 0 {
 		return request
@@ -86,6 +105,12 @@ func (request *Request) Options(opts ...RequestOption) *Request {
 	return request
 }
 
+// Use adds middlewares which wrap round trip of the request. First middleware is the outer one
+func (request *Request) Use(middlewares ...Middleware) *Request {
+	request.middlewares = append(request.middlewares, middlewares...)
+	return request
+}
+
 // Client set default http client for current Request object.
 func (request *Request) Client(client *http.Client) *Request {
 	if client == nil {
@@ -120,6 +145,59 @@ func (request *Request) RetryWait(wait time.Duration) *Request {
 	return request
 }
 
+// RetryPolicy sets retry strategy, for example retry.NewExponentialBackoffWithJitter.
+//
+// If policy is not set, RetryCount & RetryWait are used as fixed delay policy
+func (request *Request) RetryPolicy(policy retry.Policy) *Request {
+	if policy == nil {
+		return request
+	}
+
+	request.policy = policy
+	return request
+}
+
+// RetryIf sets function which decides if request must be retried.
+//
+// By default, DefaultRetryIf is used
+func (request *Request) RetryIf(retryIf RetryIfFunc) *Request {
+	if retryIf == nil {
+		return request
+	}
+
+	request.retryIf = retryIf
+	return request
+}
+
+// RetryAfterMax sets max delay which is taken from "Retry-After" response header.
+// If server asks to wait longer, request is not retried and the response is returned.
+//
+// By default, max delay is 1 minute
+func (request *Request) RetryAfterMax(max time.Duration) *Request {
+	if max <= 0 {
+		return request
+	}
+
+	request.retryAfterMax = max
+	return request
+}
+
+// RetryMethods sets methods which could be retried.
+//
+// By default, only idempotent methods are retried: GET, HEAD, OPTIONS, TRACE, PUT & DELETE
+func (request *Request) RetryMethods(methods ...string) *Request {
+	if len(methods) == 0 {
+		return request
+	}
+
+	request.retryMethods = make([]string, 0, len(methods))
+	for _, method := range methods {
+		request.retryMethods = append(request.retryMethods, strings.ToUpper(method))
+	}
+
+	return request
+}
+
 // Timeout sets timeout for waiting for request.
 //
 // By default, there is no timeout
@@ -333,6 +411,18 @@ func (request *Request) initRequest(method, url string, body ...any) error {
 		} else if formEncodedWriter, isFormEncodedWriter := body[0].(FormUrlEncodedWriter); isFormEncodedWriter {
 			request.Header("Content-Type", httpx.ContentTypeForm)
 			request.req, err = http.NewRequestWithContext(request.ctx, method, fullURL, formEncodedWriter.Reader())
+		} else if streamFormData, isStreamFormData := body[0].(StreamFormDataWriter); isStreamFormData {
+			request.Header("Content-Type", streamFormData.ContentType())
+			// body is created by GetBody for every attempt, so there are no writing goroutines of unsent bodies
+			request.req, err = http.NewRequestWithContext(request.ctx, method, fullURL, http.NoBody)
+			if err == nil {
+				request.req.GetBody = streamFormDataBody(streamFormData)
+				request.singleAttempt = !streamFormData.Rewindable()
+			}
+		} else if streamBody, isStreamBody := body[0].(StreamBody); isStreamBody {
+			request.req, err = request.newStreamRequest(method, fullURL, streamBody.Reader, streamBody.Size)
+		} else if reader, isReader := body[0].(io.Reader); isReader {
+			request.req, err = request.newStreamRequest(method, fullURL, reader, readerSize(reader))
 		} else {
 			var bodyBlob []byte
 			bodyBlob, err = json.Marshal(body[0])
@@ -369,12 +459,29 @@ func (request *Request) initRequest(method, url string, body ...any) error {
 		request.req.AddCookie(&http.Cookie{Name: key, Value: convert.String(value)})
 	}
 
-	// options
-	for _, opt := range request.options {
-		opt(request.req)
+	return nil
+}
+
+// newStreamRequest creates request with body which is read from reader while sending
+func (request *Request) newStreamRequest(method, url string, reader io.Reader, size int64) (*http.Request, error) {
+	if _, ok := request.headers["Content-Type"]; !ok {
+		request.Header("Content-Type", "application/octet-stream")
 	}
 
-	return nil
+	req, err := http.NewRequestWithContext(request.ctx, method, url, reader)
+	if err != nil {
+		return nil, err
+	}
+
+	// length of known readers (bytes.Reader, strings.Reader, etc.) is already set
+	if size >= 0 && req.GetBody == nil {
+		req.ContentLength = size
+		if size == 0 {
+			req.Body = http.NoBody
+		}
+	}
+
+	return req, nil
 }
 
 func (request *Request) retryDo(method, url string, body ...any) (_ *Response, err error) {
@@ -412,46 +519,82 @@ func (request *Request) retryDo(method, url string, body ...any) (_ *Response, e
 	if request.timeout > 0 {
 		var cancel context.CancelFunc
 		request.ctx, cancel = context.WithTimeout(request.ctx, request.timeout)
-		defer cancel()
+		defer func() {
+			// streamed body is read after return, so context is canceled when body is closed
+			if err == nil && request.response != nil && request.response.stream != nil {
+				request.response.stream = &cancelReadCloser{ReadCloser: request.response.stream, cancel: cancel}
+				return
+			}
+
+			cancel()
+		}()
+	}
+
+	// request is created once, every attempt sends its copy with body read again
+	if err = request.initRequest(method, url, body...); err != nil {
+		return nil, err
+	}
+
+	retryIf := request.retryIf
+	if retryIf == nil {
+		retryIf = DefaultRetryIf
 	}
 
 	// run request with retries
-	for i := 0; i < request.retryCount; i++ {
+	maxAttempts := request.maxAttempts(method)
+	for attempt := 1; ; attempt++ {
 		if err = contextx.Timeout(request.ctx); err != nil {
 			return nil, err
 		}
 
-		isLast := i == request.retryCount-1
+		request.response = nil
+		err = request.do(method, url)
+		if attempt >= maxAttempts || !retryIf(request.response, err) {
+			break
+		}
 
-		if err = request.do(method, url, body...); err != nil {
-			if isLast {
-				return nil, err
-			}
+		delay, ok := request.retryDelay(attempt)
+		if !ok {
+			break
+		}
 
-			time.Sleep(request.retryWait)
-			continue
+		// streamed body of the failed attempt is not needed
+		if request.response != nil && request.response.stream != nil {
+			_ = request.response.stream.Close()
 		}
 
-		// break retry cycle if request was success
-		break
+		if err = wait(request.ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+	if err != nil {
+		return nil, err
 	}
 
 	return request.response, nil
 }
 
-func (request *Request) do(method, url string, body ...any) error {
-	var err error
-
-	// create request object, if request does again it uses cached request (created before)
-	if err = request.initRequest(method, url, body...); err != nil {
+func (request *Request) do(method, url string) error {
+	req, err := request.attemptRequest()
+	if err != nil {
 		return err
 	}
 
 	// do request
-	request.resp, err = request.getClient().Do(request.req)
+	request.resp, err = request.send(req)
 	if err != nil {
 		return err
 	}
+
+	// build *web.Response object
+	request.response = newResponse(request, request.resp)
+
+	// streamed body is read & closed by response reader
+	if request.streamResponse {
+		request.response.stream = request.resp.Body
+		return nil
+	}
+
 	defer func() {
 		if err = request.resp.Body.Close(); err != nil {
 			log.
@@ -463,9 +606,6 @@ func (request *Request) do(method, url string, body ...any) error {
 		}
 	}()
 
-	// build *web.Response object
-	request.response = newResponse(request, request.resp)
-
 	// parse response body
 	var respBlob []byte
 	respBlob, err = io.ReadAll(request.resp.Body)
@@ -491,20 +631,38 @@ func (request *Request) do(method, url string, body ...any) error {
 	return nil
 }
 
+// attemptRequest returns copy of created request with new body reader, so request could be sent again
+func (request *Request) attemptRequest() (*http.Request, error) {
+	req := request.req.Clone(request.ctx)
+	if request.req.GetBody != nil {
+		body, err := request.req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+
+		req.Body = body
+	}
+
+	// options are applied to every attempt, so headers could be changed for every retry
+	for _, opt := range request.options {
+		opt(req)
+	}
+
+	if request.uploadProgress != nil && req.Body != nil && req.Body != http.NoBody {
+		req.Body = newProgressReader(req.Body, req.ContentLength, request.uploadProgress)
+	}
+
+	return req, nil
+}
+
 func (request *Request) getClient() *http.Client {
 	if request.client != nil {
 		// return provided client
 		return request.client
 	}
 
-	// return default client
-	return &http.Client{
-		Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{
-				InsecureSkipVerify: true,
-			},
-		},
-	}
+	// return default client with shared transport
+	return defaultClient
 }
 
 func (request *Request) initAuth() {
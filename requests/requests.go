@@ -1,9 +1,13 @@
 // Package requests is tool for sending HTTP requests.
 // Features:
-// - Retry mechanism. Retry count, time between retries.
+// - Retry mechanism. Retry policies (retry package), retry condition by response or error, Retry-After header.
 // - Client which provide basic settings to created requests. Nesting cookies, headers, etc.
+// - Middlewares around round trip: trace propagation, logging, mocks, etc.
+// - Shared transport with TLS verification, custom CA, mTLS, proxy & pool settings (TransportConfig).
 // - Cancel action if context is canceled.
 // - Export response to provided structure (JSON).
-// - FormData writer.
+// - Typed JSON helpers (GetJSON, PostJSON, etc.) with success/failure envelopes decoding & remote errors.
+// - FormData writer. Streamed form-data from files on disk.
+// - Streamed request & response bodies, upload/download progress, download to file with checksum.
 // - Bytes writer.
 package requests
@@ -1,18 +1,23 @@
 package requests
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"io"
 	"net/http"
 
+	"github.com/boostgo/core/errorx"
 	"github.com/boostgo/core/httpx"
 	"github.com/boostgo/core/reflectx"
+	"github.com/boostgo/core/validator"
 )
 
 type Response struct {
 	request  *Request
 	raw      *http.Response
 	bodyBlob []byte
+	stream   io.ReadCloser
 	isCore   bool
 }
 
@@ -35,10 +40,23 @@ func (response *Response) StatusCode() int {
 	return response.raw.StatusCode
 }
 
+// BodyRaw returns read response body. It is nil for streamed responses (see Request.StreamResponse)
 func (response *Response) BodyRaw() []byte {
 	return response.bodyBlob
 }
 
+// Stream returns response body reader.
+//
+// For streamed responses (see Request.StreamResponse) body is read from connection and reader must be closed.
+// For other responses reader of already read body is returned
+func (response *Response) Stream() io.ReadCloser {
+	if response.stream != nil {
+		return response.stream
+	}
+
+	return io.NopCloser(bytes.NewReader(response.bodyBlob))
+}
+
 func (response *Response) Core(isCore bool) *Response {
 	response.isCore = isCore
 	return response
@@ -91,3 +109,57 @@ func (response *Response) ContentType() string {
 func (response *Response) IsFailure() bool {
 	return httpx.IsFailureCode(response.StatusCode())
 }
+
+// Error returns nil for success responses. Failure response (4xx & 5xx) is converted to error.
+//
+// If body is httpx.FailureResponse, error message is remote error code (so errors.Is works with the same errorx errors),
+// error contains remote params, fields (see validator.Fields) or context and "request_id" param.
+//
+// Error also matches ErrResponseFailure & errorx error of the status code (errorx.ErrNotFound, etc.)
+func (response *Response) Error() error {
+	if !response.IsFailure() {
+		return nil
+	}
+
+	statusErr := httpx.ErrorByStatusCode(response.StatusCode())
+
+	var failure httpx.FailureResponse
+	if err := json.Unmarshal(response.bodyBlob, &failure); err != nil || failure.Status != failureStatus || failure.Code == "" {
+		return ErrResponseFailure.
+			SetError(statusErr).
+			SetData(responseBodyContext{
+				URL:  response.url(),
+				Code: response.StatusCode(),
+				Blob: response.bodyBlob,
+			})
+	}
+
+	remoteErr := errorx.
+		New(failure.Code).
+		SetError(ErrResponseFailure, statusErr).
+		SetParams(failure.Params)
+
+	if failure.Message != "" && failure.Message != failure.Code {
+		remoteErr = remoteErr.SetLocaleMessage(failure.Message)
+	}
+
+	if len(failure.Fields) > 0 {
+		remoteErr = remoteErr.SetData(validator.FieldErrors(failure.Fields))
+	} else {
+		remoteErr = remoteErr.SetData(failure.Context)
+	}
+
+	if failure.RequestID != "" {
+		remoteErr = remoteErr.AddParam("request_id", failure.RequestID)
+	}
+
+	return remoteErr
+}
+
+func (response *Response) url() string {
+	if response.raw == nil || response.raw.Request == nil {
+		return ""
+	}
+
+	return response.raw.Request.URL.String()
+}
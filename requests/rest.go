@@ -1,6 +1,10 @@
 package requests
 
-import "context"
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+)
 
 func Get(ctx context.Context, url string, params ...any) (*Response, error) {
 	return R(ctx).
@@ -21,3 +25,80 @@ func Delete(ctx context.Context, url string, params ...any) (*Response, error) {
 	return R(ctx).
 		DELETE(url, params...)
 }
+
+// GetJSON sends GET request and decodes response body to T (see DecodeJSON).
+//
+// Client is optional: if it is nil, request is created without client settings
+func GetJSON[T any](ctx context.Context, client *Client, url string) (T, error) {
+	return DoJSON[T](newRESTRequest(ctx, client), http.MethodGet, url)
+}
+
+// PostJSON sends POST request with JSON body and decodes response body to T (see DecodeJSON)
+func PostJSON[T any](ctx context.Context, client *Client, url string, body any) (T, error) {
+	return DoJSON[T](newRESTRequest(ctx, client), http.MethodPost, url, body)
+}
+
+// PutJSON sends PUT request with JSON body and decodes response body to T (see DecodeJSON)
+func PutJSON[T any](ctx context.Context, client *Client, url string, body any) (T, error) {
+	return DoJSON[T](newRESTRequest(ctx, client), http.MethodPut, url, body)
+}
+
+// PatchJSON sends PATCH request with JSON body and decodes response body to T (see DecodeJSON)
+func PatchJSON[T any](ctx context.Context, client *Client, url string, body any) (T, error) {
+	return DoJSON[T](newRESTRequest(ctx, client), http.MethodPatch, url, body)
+}
+
+// DeleteJSON sends DELETE request and decodes response body to T (see DecodeJSON)
+func DeleteJSON[T any](ctx context.Context, client *Client, url string) (T, error) {
+	return DoJSON[T](newRESTRequest(ctx, client), http.MethodDelete, url)
+}
+
+// DoJSON sends request and decodes response body to T (see DecodeJSON)
+func DoJSON[T any](request *Request, method, url string, body ...any) (T, error) {
+	response, err := request.Do(method, url, body...)
+	if err != nil {
+		var empty T
+		return empty, err
+	}
+
+	return DecodeJSON[T](response)
+}
+
+// DecodeJSON decodes response body to T.
+//
+// Failure responses (4xx & 5xx) are returned as errors (see Response.Error).
+// Body of success response could be plain JSON or httpx.SuccessResponse envelope, then envelope body is decoded
+func DecodeJSON[T any](response *Response) (T, error) {
+	var result T
+	if err := response.Error(); err != nil {
+		return result, err
+	}
+
+	blob := response.bodyBlob
+	if len(blob) == 0 {
+		return result, nil
+	}
+
+	var envelope struct {
+		Status string          `json:"status"`
+		Body   json.RawMessage `json:"body"`
+	}
+	if err := json.Unmarshal(blob, &envelope); err == nil && envelope.Status == successStatus && envelope.Body != nil {
+		blob = envelope.Body
+	}
+
+	if err := json.Unmarshal(blob, &result); err != nil {
+		return result, newParseResponseBodyError(response.url(), response.StatusCode(), response.bodyBlob).
+			SetError(err)
+	}
+
+	return result, nil
+}
+
+func newRESTRequest(ctx context.Context, client *Client) *Request {
+	if client == nil {
+		return R(ctx)
+	}
+
+	return client.R(ctx)
+}
@@ -3,10 +3,15 @@ package requests
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+
+	"github.com/boostgo/core/errorx"
+	"github.com/boostgo/core/httpx"
+	"github.com/boostgo/core/validator"
 )
 
 // Test Get convenience function
@@ -230,3 +235,111 @@ func TestConvenienceFunctionsWithCancelledContext(t *testing.T) {
 		}
 	})
 }
+
+func TestJSONHelpers(t *testing.T) {
+	type User struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/plain":
+			_, _ = w.Write([]byte(`{"id":1,"name":"plain"}`))
+		case "/envelope":
+			_ = json.NewEncoder(w).Encode(httpx.NewSuccessResponse(User{ID: 2, Name: "envelope"}, http.StatusOK, "request-1"))
+		case "/created":
+			w.WriteHeader(http.StatusCreated)
+			blob, _ := io.ReadAll(r.Body)
+			_, _ = w.Write(blob)
+		case "/empty":
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	client := New().SetBaseURL(server.URL)
+
+	user, err := GetJSON[User](context.Background(), client, "/plain")
+	if err != nil || user.ID != 1 || user.Name != "plain" {
+		t.Errorf("unexpected plain result: %+v, %v", user, err)
+	}
+
+	user, err = GetJSON[User](context.Background(), client, "/envelope")
+	if err != nil || user.ID != 2 || user.Name != "envelope" {
+		t.Errorf("unexpected envelope result: %+v, %v", user, err)
+	}
+
+	user, err = PostJSON[User](context.Background(), client, "/created", User{ID: 3, Name: "created"})
+	if err != nil || user.ID != 3 {
+		t.Errorf("unexpected created result: %+v, %v", user, err)
+	}
+
+	if _, err = DeleteJSON[struct{}](context.Background(), nil, server.URL+"/empty"); err != nil {
+		t.Errorf("unexpected empty response error: %v", err)
+	}
+}
+
+func TestJSONHelpersFailure(t *testing.T) {
+	errUserNotFound := errorx.New("user.not_found")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/envelope":
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(httpx.NewFailureResponse(
+				errUserNotFound.AddParam("id", "42").SetLocaleMessage("User not found"),
+				http.StatusNotFound,
+				"request-1",
+			))
+		case "/validation":
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(httpx.NewFailureResponse(
+				errorx.New("validator.failed").SetData(validator.FieldErrors{
+					validator.NewFieldError("name", "required", "", ""),
+				}),
+				http.StatusBadRequest,
+				"request-2",
+			))
+		default:
+			w.WriteHeader(http.StatusBadGateway)
+			_, _ = w.Write([]byte("bad gateway"))
+		}
+	}))
+	defer server.Close()
+
+	_, err := GetJSON[map[string]any](context.Background(), nil, server.URL+"/envelope")
+	if !errors.Is(err, errUserNotFound) || !errors.Is(err, errorx.ErrNotFound) || !errors.Is(err, ErrResponseFailure) {
+		t.Fatalf("expected remote not found error, got %v", err)
+	}
+
+	var remoteErr *errorx.Error
+	if !errors.As(err, &remoteErr) {
+		t.Fatalf("expected errorx error, got %T", err)
+	}
+
+	params := make(map[string]any)
+	for _, param := range remoteErr.Params() {
+		params[param.Key] = param.Value
+	}
+
+	if params["id"] != "42" || params["request_id"] != "request-1" {
+		t.Errorf("unexpected remote params: %v", remoteErr.Params())
+	}
+
+	if remoteErr.LocaleMessage() != "User not found" {
+		t.Errorf("unexpected remote message: %q", remoteErr.LocaleMessage())
+	}
+
+	_, err = GetJSON[map[string]any](context.Background(), nil, server.URL+"/validation")
+	fields, ok := validator.Fields(err)
+	if !ok || len(fields) != 1 || fields[0].Field != "name" {
+		t.Errorf("expected remote field errors, got %v", err)
+	}
+
+	_, err = GetJSON[map[string]any](context.Background(), nil, server.URL+"/plain")
+	if !errors.Is(err, ErrResponseFailure) || !errors.Is(err, errorx.ErrBadGateway) {
+		t.Errorf("expected failure status error, got %v", err)
+	}
+}
@@ -0,0 +1,146 @@
+package requests
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/boostgo/core/contextx"
+	"github.com/boostgo/core/retry"
+)
+
+// defaultRetryAfterMax is max delay taken from "Retry-After" header by default
+const defaultRetryAfterMax = time.Minute
+
+// RetryIfFunc decides if request must be retried by response (could be nil) or error of the attempt
+type RetryIfFunc func(response *Response, err error) bool
+
+// idempotentMethods are methods retried by default
+var idempotentMethods = []string{
+	http.MethodGet,
+	http.MethodHead,
+	http.MethodOptions,
+	http.MethodTrace,
+	http.MethodPut,
+	http.MethodDelete,
+}
+
+// DefaultRetryIf retries transport errors (except context errors & open circuit breaker)
+// and responses with statuses 408, 429, 500, 502, 503 & 504
+func DefaultRetryIf(response *Response, err error) bool {
+	if err != nil {
+		return !errors.Is(err, retry.ErrBreakerOpen) && retry.IsRetryable(err)
+	}
+
+	if response == nil {
+		return false
+	}
+
+	switch response.StatusCode() {
+	case http.StatusRequestTimeout,
+		http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// retryPolicy returns provided policy or fixed delay policy built by retry count & retry wait
+func (request *Request) retryPolicy() retry.Policy {
+	if request.policy != nil {
+		return request.policy
+	}
+
+	return retry.NewFixedDelay(request.retryWait, request.retryCount)
+}
+
+// maxAttempts returns count of attempts for the method. Request with body which could not be read again is sent once
+func (request *Request) maxAttempts(method string) int {
+	attempts := request.retryPolicy().MaxAttempts()
+	if attempts <= 1 || request.singleAttempt {
+		return 1
+	}
+
+	methods := request.retryMethods
+	if methods == nil {
+		methods = idempotentMethods
+	}
+
+	if !slices.Contains(methods, strings.ToUpper(method)) {
+		return 1
+	}
+
+	if request.req.Body != nil && request.req.Body != http.NoBody && request.req.GetBody == nil {
+		return 1
+	}
+
+	return attempts
+}
+
+// retryDelay returns delay before next attempt. Retry-After header is used if it is longer than policy delay.
+//
+// Returns false if Retry-After delay is longer than max delay (see RetryAfterMax), so request must not be retried
+func (request *Request) retryDelay(attempt int) (time.Duration, bool) {
+	delay := request.retryPolicy().NextDelay(attempt)
+	if request.response == nil {
+		return delay, true
+	}
+
+	after, ok := parseRetryAfter(request.response.raw.Header.Get("Retry-After"))
+	if !ok || after <= delay {
+		return delay, true
+	}
+
+	if after > request.retryAfterMax {
+		return 0, false
+	}
+
+	return after, true
+}
+
+// parseRetryAfter parses Retry-After header value in seconds or HTTP date format
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+
+	return max(time.Until(date), 0), true
+}
+
+// wait sleeps provided delay or till context is done
+func wait(ctx context.Context, delay time.Duration) error {
+	if delay <= 0 {
+		return contextx.Timeout(ctx)
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return contextx.Timeout(ctx)
+	}
+}
@@ -0,0 +1,247 @@
+package requests
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/boostgo/core/retry"
+)
+
+func TestRetryOnStatus(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	resp, err := R(context.Background()).
+		RetryPolicy(retry.NewExponentialBackoffWithJitter(time.Millisecond, time.Millisecond*10, 3, 0.5)).
+		GET(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		t.Errorf("expected status 200, got %d", resp.StatusCode())
+	}
+
+	if calls.Load() != 3 {
+		t.Errorf("expected 3 calls, got %d", calls.Load())
+	}
+}
+
+func TestRetryRebuildsBody(t *testing.T) {
+	var calls atomic.Int32
+	bodies := make(chan string, 3)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		blob, _ := io.ReadAll(r.Body)
+		bodies <- string(blob)
+
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	_, err := R(context.Background()).
+		RetryPolicy(retry.NewFixedDelay(time.Millisecond, 3)).
+		PUT(server.URL, map[string]string{"name": "test"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	close(bodies)
+	for body := range bodies {
+		if body != `{"name":"test"}` {
+			t.Errorf("expected same body on every attempt, got %q", body)
+		}
+	}
+}
+
+func TestRetryOnlyIdempotentMethods(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := New().RetryPolicy(retry.NewFixedDelay(time.Millisecond, 3))
+
+	if _, err := client.R(context.Background()).POST(server.URL, map[string]string{"name": "test"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if calls.Load() != 1 {
+		t.Errorf("expected POST not to be retried, got %d calls", calls.Load())
+	}
+
+	calls.Store(0)
+	if _, err := client.RetryMethods(http.MethodPost).R(context.Background()).POST(server.URL); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if calls.Load() != 3 {
+		t.Errorf("expected POST to be retried, got %d calls", calls.Load())
+	}
+}
+
+func TestRetryIf(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusConflict)
+	}))
+	defer server.Close()
+
+	_, err := R(context.Background()).
+		RetryPolicy(retry.NewFixedDelay(time.Millisecond, 2)).
+		RetryIf(func(response *Response, err error) bool {
+			return response != nil && response.StatusCode() == http.StatusConflict
+		}).
+		GET(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if calls.Load() != 2 {
+		t.Errorf("expected 2 calls, got %d", calls.Load())
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	start := time.Now()
+	resp, err := R(context.Background()).
+		RetryPolicy(retry.NewFixedDelay(time.Millisecond, 2)).
+		GET(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		t.Errorf("expected status 200, got %d", resp.StatusCode())
+	}
+
+	if time.Since(start) < time.Second {
+		t.Errorf("expected Retry-After delay to be respected, got %s", time.Since(start))
+	}
+}
+
+func TestRetryAfterMax(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name    string
+		request func() *Request
+	}{
+		{
+			name: "default max",
+			request: func() *Request {
+				return R(context.Background())
+			},
+		},
+		{
+			name: "request max",
+			request: func() *Request {
+				return R(context.Background()).RetryAfterMax(time.Second)
+			},
+		},
+		{
+			name: "client max",
+			request: func() *Request {
+				return New().RetryAfterMax(time.Second).R(context.Background())
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls.Store(0)
+
+			// server asks to wait longer than max, so request is not retried
+			start := time.Now()
+			resp, err := tt.request().
+				RetryPolicy(retry.NewFixedDelay(time.Millisecond, 3)).
+				GET(server.URL)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if resp.StatusCode() != http.StatusServiceUnavailable || calls.Load() != 1 {
+				t.Errorf("expected single call with 503 status, got %d status & %d calls", resp.StatusCode(), calls.Load())
+			}
+
+			if time.Since(start) > time.Second {
+				t.Errorf("expected no waiting, got %s", time.Since(start))
+			}
+		})
+	}
+}
+
+func TestRetryWaitRespectsContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*100)
+	defer cancel()
+
+	start := time.Now()
+	_, err := R(ctx).
+		RetryPolicy(retry.NewFixedDelay(time.Minute, 3)).
+		GET(server.URL)
+	if err == nil {
+		t.Fatal("expected context error")
+	}
+
+	if time.Since(start) > time.Second*5 {
+		t.Errorf("expected retry wait to be stopped by context, got %s", time.Since(start))
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	if delay, ok := parseRetryAfter("5"); !ok || delay != time.Second*5 {
+		t.Errorf("expected 5s, got %s", delay)
+	}
+
+	date := time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)
+	if delay, ok := parseRetryAfter(date); !ok || delay <= 0 || delay > time.Minute {
+		t.Errorf("expected delay about a minute, got %s", delay)
+	}
+
+	if _, ok := parseRetryAfter("invalid"); ok {
+		t.Error("expected invalid value not to be parsed")
+	}
+}
@@ -0,0 +1,218 @@
+package requests
+
+import (
+	"context"
+	"encoding/hex"
+	"hash"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/boostgo/core/fsx"
+)
+
+const defaultDownloadPermissions = 0o644
+
+// ProgressFunc is called while body is transferred. Total is -1 if body size is unknown
+type ProgressFunc func(transferred, total int64)
+
+// StreamBody is request body which is read from reader while sending, so it is not kept in memory.
+//
+// Size is content length, -1 means length is unknown (body is sent chunked).
+// Request with stream body is not retried, because reader could not be read again
+type StreamBody struct {
+	Reader io.Reader
+	Size   int64
+}
+
+// NewStreamBody creates request body which is streamed from reader
+func NewStreamBody(reader io.Reader, size int64) StreamBody {
+	return StreamBody{
+		Reader: reader,
+		Size:   size,
+	}
+}
+
+// StreamResponse turns off reading response body to memory. Body could be read by Response.Stream and must be closed.
+//
+// Result export is not parsed for streamed responses
+func (request *Request) StreamResponse() *Request {
+	request.streamResponse = true
+	return request
+}
+
+// UploadProgress sets function which is called while request body is sent
+func (request *Request) UploadProgress(progress ProgressFunc) *Request {
+	request.uploadProgress = progress
+	return request
+}
+
+// DownloadOption modifies Download settings
+type DownloadOption func(options *downloadOptions)
+
+type downloadOptions struct {
+	permissions os.FileMode
+	progress    ProgressFunc
+	hashType    fsx.HashType
+	checksum    string
+}
+
+// WithDownloadPermissions sets permissions of downloaded file. By default, it is 0644
+func WithDownloadPermissions(permissions os.FileMode) DownloadOption {
+	return func(options *downloadOptions) {
+		options.permissions = permissions
+	}
+}
+
+// WithDownloadProgress sets function which is called while file is downloaded
+func WithDownloadProgress(progress ProgressFunc) DownloadOption {
+	return func(options *downloadOptions) {
+		options.progress = progress
+	}
+}
+
+// WithDownloadChecksum sets expected checksum (hex) of downloaded file.
+// If checksum does not match, file is not written and ErrDownloadChecksum is returned
+func WithDownloadChecksum(hashType fsx.HashType, checksum string) DownloadOption {
+	return func(options *downloadOptions) {
+		options.hashType = hashType
+		options.checksum = checksum
+	}
+}
+
+// Download sends GET request and streams response body to the file by path.
+//
+// File is written atomically: it is created only if body is fully downloaded (and checksum matches).
+// Failure statuses (4xx & 5xx) return ErrDownloadStatus, file is not written
+func (request *Request) Download(url, path string, opts ...DownloadOption) (*Response, error) {
+	options := downloadOptions{
+		permissions: defaultDownloadPermissions,
+	}
+
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	response, err := request.StreamResponse().GET(url)
+	if err != nil {
+		return nil, err
+	}
+
+	body := response.Stream()
+	defer body.Close()
+
+	if response.IsFailure() {
+		return response, ErrDownloadStatus.
+			AddParam("url", url).
+			AddParam("status", response.StatusCode())
+	}
+
+	var reader io.Reader = body
+	if options.progress != nil {
+		reader = newProgressReader(io.NopCloser(reader), response.raw.ContentLength, options.progress)
+	}
+
+	if options.hashType != "" {
+		hasher, ok := fsx.NewHash(options.hashType)
+		if !ok {
+			return response, ErrDownloadChecksum.AddParam("hash_type", options.hashType)
+		}
+
+		reader = &checksumReader{
+			reader:   reader,
+			hash:     hasher,
+			expected: strings.ToLower(options.checksum),
+		}
+	}
+
+	if _, err = fsx.AtomicWriteFileFrom(path, reader, options.permissions); err != nil {
+		return response, ErrDownload.SetError(err).AddParam("path", path)
+	}
+
+	return response, nil
+}
+
+// progressReader calls progress function on every read
+type progressReader struct {
+	io.ReadCloser
+	transferred int64
+	total       int64
+	progress    ProgressFunc
+}
+
+func newProgressReader(reader io.ReadCloser, total int64, progress ProgressFunc) *progressReader {
+	if total <= 0 {
+		total = -1
+	}
+
+	return &progressReader{
+		ReadCloser: reader,
+		total:      total,
+		progress:   progress,
+	}
+}
+
+func (reader *progressReader) Read(p []byte) (int, error) {
+	n, err := reader.ReadCloser.Read(p)
+	if n > 0 {
+		reader.transferred += int64(n)
+		reader.progress(reader.transferred, reader.total)
+	}
+
+	return n, err
+}
+
+// checksumReader calculates checksum of read data and returns error instead of io.EOF if checksum does not match
+type checksumReader struct {
+	reader   io.Reader
+	hash     hash.Hash
+	expected string
+}
+
+func (reader *checksumReader) Read(p []byte) (int, error) {
+	n, err := reader.reader.Read(p)
+	reader.hash.Write(p[:n])
+
+	if err == io.EOF {
+		if actual := hex.EncodeToString(reader.hash.Sum(nil)); actual != reader.expected {
+			return n, ErrDownloadChecksum.
+				AddParam("expected", reader.expected).
+				AddParam("actual", actual)
+		}
+	}
+
+	return n, err
+}
+
+// cancelReadCloser cancels request context when streamed body is closed
+type cancelReadCloser struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (reader *cancelReadCloser) Close() error {
+	defer reader.cancel()
+	return reader.ReadCloser.Close()
+}
+
+// readerSize returns size of reader data if it is known, otherwise -1
+func readerSize(reader io.Reader) int64 {
+	switch typed := reader.(type) {
+	case interface{ Len() int }:
+		return int64(typed.Len())
+	case *os.File:
+		info, err := typed.Stat()
+		if err != nil || !info.Mode().IsRegular() {
+			return -1
+		}
+
+		offset, err := typed.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return -1
+		}
+
+		return info.Size() - offset
+	default:
+		return -1
+	}
+}
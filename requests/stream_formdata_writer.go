@@ -0,0 +1,207 @@
+package requests
+
+import (
+	"io"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+
+	"github.com/boostgo/core/convert"
+	"github.com/boostgo/core/errorx"
+)
+
+// StreamFormDataWriter uses for sending form-data request body which is streamed while sending.
+//
+// Unlike FormDataWriter files are not kept in memory: they are read from disk part by part
+type StreamFormDataWriter interface {
+	Add(key string, value any) error
+	AddFile(name, path string, fileName ...string) error
+	AddReader(name, fileName string, reader io.Reader) error
+	Set(data map[string]any) error
+	Boundary() string
+	ContentType() string
+	// Reader returns new reader of the body. Body is written by parts while it is read
+	Reader() io.ReadCloser
+	// Rewindable returns true if body could be read again (there are no parts from readers), so request could be retried
+	Rewindable() bool
+}
+
+type streamFormDataPart struct {
+	name     string
+	value    string
+	fileName string
+	path     string
+	reader   io.Reader
+}
+
+type streamFormData struct {
+	boundary string
+	parts    []streamFormDataPart
+}
+
+// NewStreamFormData creates StreamFormDataWriter
+func NewStreamFormData(initial ...map[string]any) StreamFormDataWriter {
+	fd := &streamFormData{
+		boundary: multipart.NewWriter(io.Discard).Boundary(),
+	}
+
+	if len(initial) > 0 {
+		_ = fd.Set(initial[0])
+	}
+
+	return fd
+}
+
+func (fd *streamFormData) Add(key string, value any) error {
+	fd.parts = append(fd.parts, streamFormDataPart{
+		name:  key,
+		value: convert.String(value),
+	})
+	return nil
+}
+
+// AddFile adds file from disk. File is opened only when body is sent. By default, file name is base of the path
+func (fd *streamFormData) AddFile(name, path string, fileName ...string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return ErrFormDataWriterAddFile.SetError(err).AddParam("path", path)
+	}
+
+	if info.IsDir() {
+		return ErrFormDataWriterAddFile.AddParam("path", path)
+	}
+
+	part := streamFormDataPart{
+		name:     name,
+		fileName: filepath.Base(path),
+		path:     path,
+	}
+	if len(fileName) > 0 && fileName[0] != "" {
+		part.fileName = fileName[0]
+	}
+
+	fd.parts = append(fd.parts, part)
+	return nil
+}
+
+// AddReader adds file part which is read from reader. Body with such part could be sent only once
+func (fd *streamFormData) AddReader(name, fileName string, reader io.Reader) error {
+	fd.parts = append(fd.parts, streamFormDataPart{
+		name:     name,
+		fileName: fileName,
+		reader:   reader,
+	})
+	return nil
+}
+
+func (fd *streamFormData) Set(data map[string]any) error {
+	for key, value := range data {
+		if err := fd.Add(key, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (fd *streamFormData) Boundary() string {
+	return fd.boundary
+}
+
+func (fd *streamFormData) ContentType() string {
+	return "multipart/form-data; boundary=" + fd.boundary
+}
+
+func (fd *streamFormData) Reader() io.ReadCloser {
+	reader, writer := io.Pipe()
+	go func() {
+		// if request is canceled, reader is closed and writing returns error, so goroutine is finished
+		writer.CloseWithError(fd.write(writer))
+	}()
+
+	return reader
+}
+
+func (fd *streamFormData) Rewindable() bool {
+	for _, part := range fd.parts {
+		if part.reader != nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+// streamFormDataBody returns GetBody function which creates new body reader.
+// Body with parts from readers could be created only once
+func streamFormDataBody(fd StreamFormDataWriter) func() (io.ReadCloser, error) {
+	if fd.Rewindable() {
+		return func() (io.ReadCloser, error) {
+			return fd.Reader(), nil
+		}
+	}
+
+	var used atomic.Bool
+	return func() (io.ReadCloser, error) {
+		if used.Swap(true) {
+			return nil, ErrFormDataWriterReread
+		}
+
+		return fd.Reader(), nil
+	}
+}
+
+func (fd *streamFormData) write(writer io.Writer) error {
+	multipartWriter := multipart.NewWriter(writer)
+	if err := multipartWriter.SetBoundary(fd.boundary); err != nil {
+		return ErrFormDataWriterClose.SetError(err)
+	}
+
+	for _, part := range fd.parts {
+		if err := fd.writePart(multipartWriter, part); err != nil {
+			return err
+		}
+	}
+
+	if err := multipartWriter.Close(); err != nil {
+		return ErrFormDataWriterClose.SetError(err)
+	}
+
+	return nil
+}
+
+func (fd *streamFormData) writePart(multipartWriter *multipart.Writer, part streamFormDataPart) (err error) {
+	if part.path == "" && part.reader == nil {
+		if err = multipartWriter.WriteField(part.name, part.value); err != nil {
+			return ErrFormDataWriterAdd.SetError(err)
+		}
+
+		return nil
+	}
+
+	defer func() {
+		if err != nil {
+			err = errorx.Wrap(err, ErrFormDataWriterAddFile)
+		}
+	}()
+
+	partWriter, err := multipartWriter.CreateFormFile(part.name, part.fileName)
+	if err != nil {
+		return err
+	}
+
+	reader := part.reader
+	if part.path != "" {
+		file, err := os.Open(part.path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		reader = file
+	}
+
+	_, err = io.Copy(partWriter, reader)
+	return err
+}
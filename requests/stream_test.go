@@ -0,0 +1,243 @@
+package requests
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/boostgo/core/fsx"
+	"github.com/boostgo/core/retry"
+)
+
+func TestStreamRequestBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		blob, _ := io.ReadAll(r.Body)
+		w.Header().Set("X-Content-Length", r.Header.Get("Content-Length"))
+		w.Header().Set("X-Content-Type", r.Header.Get("Content-Type"))
+		_, _ = w.Write(blob)
+	}))
+	defer server.Close()
+
+	content := strings.Repeat("stream", 1000)
+
+	var transferred, total int64
+	resp, err := R(context.Background()).
+		UploadProgress(func(current, size int64) {
+			transferred, total = current, size
+		}).
+		POST(server.URL, NewStreamBody(io.MultiReader(strings.NewReader(content)), int64(len(content))))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if string(resp.BodyRaw()) != content {
+		t.Error("expected streamed body to be sent")
+	}
+
+	if resp.Raw().Header.Get("X-Content-Length") != "6000" {
+		t.Errorf("expected known content length, got %q", resp.Raw().Header.Get("X-Content-Length"))
+	}
+
+	if resp.Raw().Header.Get("X-Content-Type") != "application/octet-stream" {
+		t.Errorf("unexpected content type %q", resp.Raw().Header.Get("X-Content-Type"))
+	}
+
+	if transferred != int64(len(content)) || total != int64(len(content)) {
+		t.Errorf("unexpected upload progress: %d of %d", transferred, total)
+	}
+
+	// unknown length is sent chunked
+	resp, err = R(context.Background()).POST(server.URL, io.MultiReader(strings.NewReader(content)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if string(resp.BodyRaw()) != content {
+		t.Error("expected chunked body to be sent")
+	}
+}
+
+func TestStreamResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("streamed response"))
+	}))
+	defer server.Close()
+
+	resp, err := R(context.Background()).
+		Timeout(time.Second).
+		StreamResponse().
+		GET(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if resp.BodyRaw() != nil {
+		t.Error("expected body not to be read")
+	}
+
+	body := resp.Stream()
+	defer body.Close()
+
+	// body could be read after request is returned (timeout context is not canceled)
+	blob, err := io.ReadAll(body)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if string(blob) != "streamed response" {
+		t.Errorf("unexpected body %q", blob)
+	}
+}
+
+func TestDownload(t *testing.T) {
+	content := strings.Repeat("file content ", 1000)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		_, _ = w.Write([]byte(content))
+	}))
+	defer server.Close()
+
+	sum := sha256.Sum256([]byte(content))
+	checksum := hex.EncodeToString(sum[:])
+	path := filepath.Join(t.TempDir(), "file.txt")
+
+	var transferred int64
+	_, err := R(context.Background()).Download(
+		server.URL+"/file",
+		path,
+		WithDownloadChecksum(fsx.HashSHA256, checksum),
+		WithDownloadProgress(func(current, total int64) {
+			transferred = current
+		}),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	blob, _ := os.ReadFile(path)
+	if string(blob) != content {
+		t.Error("unexpected downloaded content")
+	}
+
+	if transferred != int64(len(content)) {
+		t.Errorf("unexpected download progress: %d", transferred)
+	}
+
+	// checksum mismatch does not write file
+	invalidPath := filepath.Join(t.TempDir(), "invalid.txt")
+	_, err = R(context.Background()).Download(server.URL+"/file", invalidPath, WithDownloadChecksum(fsx.HashSHA256, "invalid"))
+	if !errors.Is(err, ErrDownloadChecksum) {
+		t.Fatalf("expected checksum error, got %v", err)
+	}
+
+	if fsx.FileExist(invalidPath) {
+		t.Error("expected file not to be written")
+	}
+
+	// failure status
+	_, err = R(context.Background()).Download(server.URL+"/missing", invalidPath)
+	if !errors.Is(err, ErrDownloadStatus) {
+		t.Fatalf("expected status error, got %v", err)
+	}
+}
+
+func TestStreamFormData(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		defer file.Close()
+
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		blob, _ := io.ReadAll(file)
+		_, _ = w.Write([]byte(r.FormValue("name") + ":" + string(blob)))
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "upload.txt")
+	if err := os.WriteFile(path, []byte("from disk"), 0o644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	formData := NewStreamFormData(map[string]any{"name": "upload"})
+	if err := formData.AddFile("file", path); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := formData.AddFile("missing", filepath.Join(t.TempDir(), "missing.txt")); err == nil {
+		t.Error("expected missing file error")
+	}
+
+	// file parts are read again on retry
+	resp, err := R(context.Background()).
+		RetryPolicy(retry.NewFixedDelay(time.Millisecond, 2)).
+		PUT(server.URL, formData)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if string(resp.BodyRaw()) != "upload:from disk" {
+		t.Errorf("unexpected response %q (status %d)", resp.BodyRaw(), resp.StatusCode())
+	}
+}
+
+func TestStreamFormDataNoLeak(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	send := func() {
+		formData := NewStreamFormData(map[string]any{"name": "upload"})
+		if _, err := R(context.Background()).POST(server.URL, formData); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	// warm up connections pool
+	send()
+	before := runtime.NumGoroutine()
+
+	const count = 20
+	for i := 0; i < count; i++ {
+		send()
+	}
+
+	// body writing goroutines must be finished
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before+count/2 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond * 10)
+	}
+
+	if after := runtime.NumGoroutine(); after > before+count/2 {
+		t.Errorf("goroutines leaked: before %d, after %d", before, after)
+	}
+}
@@ -0,0 +1,225 @@
+package requests
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/boostgo/core/timex"
+)
+
+const (
+	defaultMaxIdleConns          = 100
+	defaultMaxIdleConnsPerHost   = 10
+	defaultIdleConnTimeout       = time.Second * 90
+	defaultDialTimeout           = time.Second * 30
+	defaultKeepAlive             = time.Second * 30
+	defaultTLSHandshakeTimeout   = time.Second * 10
+	defaultExpectContinueTimeout = time.Second
+)
+
+// defaultClient is used by requests created without Client. It shares connections pool between requests
+var defaultClient = &http.Client{
+	Transport: newDefaultTransport(),
+}
+
+// TransportOption modifies created transport
+type TransportOption func(transport *http.Transport)
+
+// TransportConfig contains transport settings. Could be read by configx.
+//
+// Zero values are replaced by defaults. TLS verification is enabled if InsecureSkipVerify is not set
+type TransportConfig struct {
+	// tls
+	InsecureSkipVerify bool   `json:"insecure_skip_verify" yaml:"insecureSkipVerify" default:"false"`
+	ServerName         string `json:"server_name" yaml:"serverName"`
+	MinTLSVersion      string `json:"min_tls_version" yaml:"minTLSVersion" default:"1.2"`
+	CAFile             string `json:"ca_file" yaml:"caFile"`
+	CA                 string `json:"ca" yaml:"ca"`
+	CertFile           string `json:"cert_file" yaml:"certFile"`
+	KeyFile            string `json:"key_file" yaml:"keyFile"`
+
+	// proxy. If proxy url is empty, proxy is taken from HTTP_PROXY, HTTPS_PROXY & NO_PROXY environment variables
+	ProxyURL     string `json:"proxy_url" yaml:"proxyURL"`
+	DisableProxy bool   `json:"disable_proxy" yaml:"disableProxy" default:"false"`
+
+	DisableHTTP2 bool `json:"disable_http2" yaml:"disableHTTP2" default:"false"`
+
+	// connection pool
+	MaxIdleConns        int            `json:"max_idle_conns" yaml:"maxIdleConns" default:"100"`
+	MaxIdleConnsPerHost int            `json:"max_idle_conns_per_host" yaml:"maxIdleConnsPerHost" default:"10"`
+	MaxConnsPerHost     int            `json:"max_conns_per_host" yaml:"maxConnsPerHost"`
+	IdleConnTimeout     timex.Duration `json:"idle_conn_timeout" yaml:"idleConnTimeout"`
+
+	// timeouts
+	DialTimeout           timex.Duration `json:"dial_timeout" yaml:"dialTimeout"`
+	KeepAlive             timex.Duration `json:"keep_alive" yaml:"keepAlive"`
+	TLSHandshakeTimeout   timex.Duration `json:"tls_handshake_timeout" yaml:"tlsHandshakeTimeout"`
+	ResponseHeaderTimeout timex.Duration `json:"response_header_timeout" yaml:"responseHeaderTimeout"`
+	ExpectContinueTimeout timex.Duration `json:"expect_continue_timeout" yaml:"expectContinueTimeout"`
+}
+
+// NewTransport creates transport by config. Created transport must be shared between requests (see Client.Transport),
+// so connections are reused
+func NewTransport(cfg TransportConfig, opts ...TransportOption) (*http.Transport, error) {
+	tlsConfig, err := newTLSConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	proxy, err := newProxy(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	dialer := &net.Dialer{
+		Timeout:   durationOr(cfg.DialTimeout, defaultDialTimeout),
+		KeepAlive: durationOr(cfg.KeepAlive, defaultKeepAlive),
+	}
+
+	transport := &http.Transport{
+		Proxy:                 proxy,
+		DialContext:           dialer.DialContext,
+		TLSClientConfig:       tlsConfig,
+		ForceAttemptHTTP2:     !cfg.DisableHTTP2,
+		MaxIdleConns:          intOr(cfg.MaxIdleConns, defaultMaxIdleConns),
+		MaxIdleConnsPerHost:   intOr(cfg.MaxIdleConnsPerHost, defaultMaxIdleConnsPerHost),
+		MaxConnsPerHost:       cfg.MaxConnsPerHost,
+		IdleConnTimeout:       durationOr(cfg.IdleConnTimeout, defaultIdleConnTimeout),
+		TLSHandshakeTimeout:   durationOr(cfg.TLSHandshakeTimeout, defaultTLSHandshakeTimeout),
+		ResponseHeaderTimeout: cfg.ResponseHeaderTimeout.Duration(),
+		ExpectContinueTimeout: durationOr(cfg.ExpectContinueTimeout, defaultExpectContinueTimeout),
+	}
+
+	// empty map turns off HTTP/2 upgrade
+	if cfg.DisableHTTP2 {
+		transport.TLSNextProto = make(map[string]func(authority string, c *tls.Conn) http.RoundTripper)
+	}
+
+	for _, opt := range opts {
+		opt(transport)
+	}
+
+	return transport, nil
+}
+
+// MustTransport calls NewTransport and panics if there is an error
+func MustTransport(cfg TransportConfig, opts ...TransportOption) *http.Transport {
+	transport, err := NewTransport(cfg, opts...)
+	if err != nil {
+		panic(err)
+	}
+
+	return transport
+}
+
+// WithRootCAs sets pool of trusted certificate authorities
+func WithRootCAs(pool *x509.CertPool) TransportOption {
+	return func(transport *http.Transport) {
+		transport.TLSClientConfig.RootCAs = pool
+	}
+}
+
+// WithClientCertificates sets client certificates for mTLS
+func WithClientCertificates(certificates ...tls.Certificate) TransportOption {
+	return func(transport *http.Transport) {
+		transport.TLSClientConfig.Certificates = append(transport.TLSClientConfig.Certificates, certificates...)
+	}
+}
+
+func newTLSConfig(cfg TransportConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+		ServerName:         cfg.ServerName,
+		MinVersion:         tls.VersionTLS12,
+	}
+
+	switch cfg.MinTLSVersion {
+	case "", "1.2":
+	case "1.3":
+		tlsConfig.MinVersion = tls.VersionTLS13
+	default:
+		return nil, ErrTransportTLSVersion.AddParam("version", cfg.MinTLSVersion)
+	}
+
+	// custom CA bundle is added to system pool
+	if cfg.CAFile != "" || cfg.CA != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+
+		if cfg.CAFile != "" {
+			blob, err := os.ReadFile(cfg.CAFile)
+			if err != nil {
+				return nil, ErrTransportCA.SetError(err).AddParam("file", cfg.CAFile)
+			}
+
+			if !pool.AppendCertsFromPEM(blob) {
+				return nil, ErrTransportCA.AddParam("file", cfg.CAFile)
+			}
+		}
+
+		if cfg.CA != "" && !pool.AppendCertsFromPEM([]byte(cfg.CA)) {
+			return nil, ErrTransportCA
+		}
+
+		tlsConfig.RootCAs = pool
+	}
+
+	// client certificate for mTLS
+	if cfg.CertFile != "" || cfg.KeyFile != "" {
+		certificate, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return nil, ErrTransportClientCertificate.SetError(err).
+				AddParam("cert_file", cfg.CertFile).
+				AddParam("key_file", cfg.KeyFile)
+		}
+
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	return tlsConfig, nil
+}
+
+func newProxy(cfg TransportConfig) (func(*http.Request) (*url.URL, error), error) {
+	if cfg.DisableProxy {
+		return nil, nil
+	}
+
+	if cfg.ProxyURL == "" {
+		return http.ProxyFromEnvironment, nil
+	}
+
+	proxyURL, err := url.Parse(cfg.ProxyURL)
+	if err != nil {
+		return nil, ErrTransportProxy.SetError(err).AddParam("proxy_url", cfg.ProxyURL)
+	}
+
+	return http.ProxyURL(proxyURL), nil
+}
+
+// newDefaultTransport creates transport with default settings & TLS verification
+func newDefaultTransport() *http.Transport {
+	return MustTransport(TransportConfig{})
+}
+
+func durationOr(duration timex.Duration, defaultValue time.Duration) time.Duration {
+	if value := duration.Duration(); value > 0 {
+		return value
+	}
+
+	return defaultValue
+}
+
+func intOr(value, defaultValue int) int {
+	if value > 0 {
+		return value
+	}
+
+	return defaultValue
+}
@@ -0,0 +1,139 @@
+package requests
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/boostgo/core/timex"
+)
+
+func TestTransportVerifiesTLS(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	if _, err := New().R(context.Background()).GET(server.URL); err == nil {
+		t.Fatal("expected certificate verification error")
+	}
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	writePEM(t, caFile, "CERTIFICATE", server.Certificate().Raw)
+
+	transport, err := NewTransport(TransportConfig{CAFile: caFile})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	resp, err := New().Transport(transport).R(context.Background()).GET(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		t.Errorf("expected status 200, got %d", resp.StatusCode())
+	}
+}
+
+func TestTransportClientCertificate(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.TLS.PeerCertificates) == 0 {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	server.StartTLS()
+	defer server.Close()
+
+	dir := t.TempDir()
+	certificate := server.TLS.Certificates[0]
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+	writePEM(t, certFile, "CERTIFICATE", certificate.Certificate[0])
+
+	key, err := x509.MarshalPKCS8PrivateKey(certificate.PrivateKey)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	writePEM(t, keyFile, "PRIVATE KEY", key)
+
+	transport, err := NewTransport(TransportConfig{
+		InsecureSkipVerify: true,
+		CertFile:           certFile,
+		KeyFile:            keyFile,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	resp, err := New().Transport(transport).R(context.Background()).GET(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		t.Errorf("expected status 200, got %d", resp.StatusCode())
+	}
+}
+
+func TestNewTransportConfig(t *testing.T) {
+	transport, err := NewTransport(TransportConfig{
+		ProxyURL:              "http://proxy.local:8080",
+		MaxIdleConnsPerHost:   50,
+		ResponseHeaderTimeout: timex.NewDuration(time.Second * 5),
+		DisableHTTP2:          true,
+		MinTLSVersion:         "1.3",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if transport.MaxIdleConnsPerHost != 50 || transport.MaxIdleConns != defaultMaxIdleConns {
+		t.Errorf("unexpected pool sizes: %d %d", transport.MaxIdleConnsPerHost, transport.MaxIdleConns)
+	}
+
+	if transport.ResponseHeaderTimeout != time.Second*5 {
+		t.Errorf("unexpected response header timeout: %s", transport.ResponseHeaderTimeout)
+	}
+
+	if transport.ForceAttemptHTTP2 || transport.TLSNextProto == nil {
+		t.Error("expected HTTP/2 to be disabled")
+	}
+
+	if transport.TLSClientConfig.MinVersion != tls.VersionTLS13 || transport.TLSClientConfig.InsecureSkipVerify {
+		t.Error("unexpected TLS config")
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, "https://example.com", nil)
+	proxy, err := transport.Proxy(req)
+	if err != nil || proxy == nil || proxy.Host != "proxy.local:8080" {
+		t.Errorf("unexpected proxy: %v %v", proxy, err)
+	}
+
+	if _, err = NewTransport(TransportConfig{MinTLSVersion: "1.0"}); err == nil {
+		t.Error("expected unsupported TLS version error")
+	}
+
+	if _, err = NewTransport(TransportConfig{CAFile: "not-found.pem"}); err == nil {
+		t.Error("expected CA file error")
+	}
+}
+
+func writePEM(t *testing.T, path, blockType string, blob []byte) {
+	t.Helper()
+
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: blob}), 0o600); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,408 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/boostgo/core/log"
+)
+
+const (
+	defaultBreakerWindow           = time.Minute
+	defaultBreakerBuckets          = 10
+	defaultBreakerFailureRate      = 0.5
+	defaultBreakerMinRequests      = 10
+	defaultBreakerCoolDown         = time.Second * 30
+	defaultBreakerHalfOpenRequests = 1
+)
+
+// BreakerState is state of circuit breaker
+type BreakerState int
+
+const (
+	// BreakerClosed means calls are executed, failures are counted
+	BreakerClosed BreakerState = iota
+	// BreakerOpen means calls are rejected with ErrBreakerOpen till cool-down is over
+	BreakerOpen
+	// BreakerHalfOpen means limited count of probe calls is executed to check if dependency is recovered
+	BreakerHalfOpen
+)
+
+func (state BreakerState) String() string {
+	switch state {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// BreakerCounts contains calls statistic of the current rolling window
+type BreakerCounts struct {
+	Requests int
+	Failures int
+}
+
+// FailureRate returns part of failed calls from 0 to 1
+func (counts BreakerCounts) FailureRate() float64 {
+	if counts.Requests == 0 {
+		return 0
+	}
+
+	return float64(counts.Failures) / float64(counts.Requests)
+}
+
+// BreakerOption modifies Breaker settings
+type BreakerOption func(options *breakerOptions)
+
+type breakerOptions struct {
+	window           time.Duration
+	failureRate      float64
+	minRequests      int
+	coolDown         time.Duration
+	halfOpenRequests int
+	isFailure        func(err error) bool
+	onStateChange    func(name string, from, to BreakerState)
+}
+
+// WithBreakerWindow sets rolling window in which failures are counted. By default, it is 1 minute
+func WithBreakerWindow(window time.Duration) BreakerOption {
+	return func(options *breakerOptions) {
+		if window > 0 {
+			options.window = window
+		}
+	}
+}
+
+// WithBreakerFailureRate sets failure rate (from 0 to 1) which opens breaker. By default, it is 0.5
+func WithBreakerFailureRate(rate float64) BreakerOption {
+	return func(options *breakerOptions) {
+		if rate > 0 && rate <= 1 {
+			options.failureRate = rate
+		}
+	}
+}
+
+// WithBreakerMinRequests sets minimum count of calls in window before failure rate is checked. By default, it is 10
+func WithBreakerMinRequests(count int) BreakerOption {
+	return func(options *breakerOptions) {
+		if count > 0 {
+			options.minRequests = count
+		}
+	}
+}
+
+// WithBreakerCoolDown sets time breaker stays open before probe calls are allowed. By default, it is 30 seconds
+func WithBreakerCoolDown(coolDown time.Duration) BreakerOption {
+	return func(options *breakerOptions) {
+		if coolDown > 0 {
+			options.coolDown = coolDown
+		}
+	}
+}
+
+// WithBreakerHalfOpenRequests sets count of probe calls in half-open state.
+// Breaker is closed if all of them succeed. By default, it is 1
+func WithBreakerHalfOpenRequests(count int) BreakerOption {
+	return func(options *breakerOptions) {
+		if count > 0 {
+			options.halfOpenRequests = count
+		}
+	}
+}
+
+// WithBreakerIsFailure sets function which decides if call error is counted as failure.
+//
+// By default, all errors are failures. Canceled calls (context.Canceled) are never counted
+// as failure or success
+func WithBreakerIsFailure(isFailure func(err error) bool) BreakerOption {
+	return func(options *breakerOptions) {
+		options.isFailure = isFailure
+	}
+}
+
+// WithBreakerOnStateChange sets function which is called on every state change. Could be used for metrics
+func WithBreakerOnStateChange(onStateChange func(name string, from, to BreakerState)) BreakerOption {
+	return func(options *breakerOptions) {
+		options.onStateChange = onStateChange
+	}
+}
+
+type breakerBucket struct {
+	index    int64
+	requests int
+	failures int
+}
+
+// Breaker is circuit breaker. It stops calling failing dependency for a cool-down time.
+//
+// Breaker is opened when failure rate over rolling window reaches the limit (if there are enough calls).
+// After cool-down breaker is half-open: a few probe calls are executed. If they succeed breaker is closed,
+// otherwise it is opened again
+type Breaker struct {
+	name    string
+	options breakerOptions
+	now     func() time.Time
+
+	state      BreakerState
+	generation uint64
+	openedAt   time.Time
+	buckets    []breakerBucket
+	probes     int
+	successes  int
+	mx         sync.Mutex
+}
+
+// NewBreaker creates circuit breaker. Name is used in logs and state change callback
+func NewBreaker(name string, opts ...BreakerOption) *Breaker {
+	options := breakerOptions{
+		window:           defaultBreakerWindow,
+		failureRate:      defaultBreakerFailureRate,
+		minRequests:      defaultBreakerMinRequests,
+		coolDown:         defaultBreakerCoolDown,
+		halfOpenRequests: defaultBreakerHalfOpenRequests,
+		isFailure:        isBreakerFailure,
+	}
+
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return &Breaker{
+		name:    name,
+		options: options,
+		now:     time.Now,
+		buckets: make([]breakerBucket, defaultBreakerBuckets),
+	}
+}
+
+// Name returns breaker name
+func (breaker *Breaker) Name() string {
+	return breaker.name
+}
+
+// State returns current breaker state
+func (breaker *Breaker) State() BreakerState {
+	breaker.mx.Lock()
+	state, change := breaker.currentState()
+	breaker.mx.Unlock()
+
+	breaker.notify(change)
+	return state
+}
+
+// Counts returns calls statistic of the current rolling window
+func (breaker *Breaker) Counts() BreakerCounts {
+	breaker.mx.Lock()
+	defer breaker.mx.Unlock()
+
+	return breaker.counts()
+}
+
+// Execute calls fn if breaker allows it and records the result.
+//
+// Returns ErrBreakerOpen (which is errorx.ErrServiceUnavailable) without calling fn if breaker is open
+func (breaker *Breaker) Execute(ctx context.Context, fn RetryableFunc) error {
+	generation, err := breaker.allow()
+	if err != nil {
+		return err
+	}
+
+	err = fn(ctx)
+	if errors.Is(err, context.Canceled) {
+		breaker.release(generation)
+		return err
+	}
+
+	breaker.record(generation, breaker.options.isFailure(err))
+	return err
+}
+
+// BreakerDoWithData is a generic convenience function for breaker calls that return data
+func BreakerDoWithData[T any](ctx context.Context, breaker *Breaker, fn func(ctx context.Context) (T, error)) (T, error) {
+	var result T
+	err := breaker.Execute(ctx, func(ctx context.Context) error {
+		var fnErr error
+		result, fnErr = fn(ctx)
+		return fnErr
+	})
+	return result, err
+}
+
+// allow checks if call could be executed and returns generation of the state the call belongs to
+func (breaker *Breaker) allow() (uint64, error) {
+	breaker.mx.Lock()
+	state, change := breaker.currentState()
+
+	var err error
+	switch state {
+	case BreakerOpen:
+		err = ErrBreakerOpen.AddParam("breaker", breaker.name)
+	case BreakerHalfOpen:
+		if breaker.probes >= breaker.options.halfOpenRequests {
+			err = ErrBreakerOpen.AddParam("breaker", breaker.name)
+		} else {
+			breaker.probes++
+		}
+	}
+
+	generation := breaker.generation
+	breaker.mx.Unlock()
+
+	breaker.notify(change)
+	return generation, err
+}
+
+// record counts call result. Results of calls started in previous state are ignored
+func (breaker *Breaker) record(generation uint64, failed bool) {
+	breaker.mx.Lock()
+	if generation != breaker.generation {
+		breaker.mx.Unlock()
+		return
+	}
+
+	var change *breakerChange
+	switch breaker.state {
+	case BreakerClosed:
+		bucket := breaker.bucket()
+		bucket.requests++
+		if failed {
+			bucket.failures++
+		}
+
+		counts := breaker.counts()
+		if failed && counts.Requests >= breaker.options.minRequests && counts.FailureRate() >= breaker.options.failureRate {
+			change = breaker.setState(BreakerOpen)
+		}
+	case BreakerHalfOpen:
+		if failed {
+			change = breaker.setState(BreakerOpen)
+			break
+		}
+
+		breaker.successes++
+		if breaker.successes >= breaker.options.halfOpenRequests {
+			change = breaker.setState(BreakerClosed)
+		}
+	}
+	breaker.mx.Unlock()
+
+	breaker.notify(change)
+}
+
+// release frees half-open probe slot of canceled call without counting its result
+func (breaker *Breaker) release(generation uint64) {
+	breaker.mx.Lock()
+	defer breaker.mx.Unlock()
+
+	if generation == breaker.generation && breaker.state == BreakerHalfOpen && breaker.probes > 0 {
+		breaker.probes--
+	}
+}
+
+// currentState returns state, moving open breaker to half-open if cool-down is over
+func (breaker *Breaker) currentState() (BreakerState, *breakerChange) {
+	if breaker.state == BreakerOpen && breaker.now().Sub(breaker.openedAt) >= breaker.options.coolDown {
+		return BreakerHalfOpen, breaker.setState(BreakerHalfOpen)
+	}
+
+	return breaker.state, nil
+}
+
+type breakerChange struct {
+	from   BreakerState
+	to     BreakerState
+	counts BreakerCounts
+}
+
+// setState changes state and resets counters. Must be called under lock
+func (breaker *Breaker) setState(state BreakerState) *breakerChange {
+	change := &breakerChange{
+		from:   breaker.state,
+		to:     state,
+		counts: breaker.counts(),
+	}
+
+	breaker.state = state
+	breaker.generation++
+	breaker.probes = 0
+	breaker.successes = 0
+
+	switch state {
+	case BreakerOpen:
+		breaker.openedAt = breaker.now()
+	case BreakerClosed:
+		clear(breaker.buckets)
+	}
+
+	return change
+}
+
+// notify writes log & calls state change callback. Must be called without lock
+func (breaker *Breaker) notify(change *breakerChange) {
+	if change == nil {
+		return
+	}
+
+	event := log.Info()
+	if change.to == BreakerOpen {
+		event = log.Warn()
+	}
+
+	event.
+		Str("breaker", breaker.name).
+		Str("from", change.from.String()).
+		Str("to", change.to.String()).
+		Int("requests", change.counts.Requests).
+		Int("failures", change.counts.Failures).
+		Msg("Circuit breaker state changed")
+
+	if breaker.options.onStateChange != nil {
+		breaker.options.onStateChange(breaker.name, change.from, change.to)
+	}
+}
+
+// bucket returns bucket of the current time, resetting it if it belongs to the previous window
+func (breaker *Breaker) bucket() *breakerBucket {
+	index := breaker.bucketIndex()
+	bucket := &breaker.buckets[index%int64(len(breaker.buckets))]
+	if bucket.index != index {
+		*bucket = breakerBucket{index: index}
+	}
+
+	return bucket
+}
+
+func (breaker *Breaker) counts() BreakerCounts {
+	index := breaker.bucketIndex()
+	size := int64(len(breaker.buckets))
+
+	var counts BreakerCounts
+	for _, bucket := range breaker.buckets {
+		if bucket.index > index-size && bucket.index <= index {
+			counts.Requests += bucket.requests
+			counts.Failures += bucket.failures
+		}
+	}
+
+	return counts
+}
+
+func (breaker *Breaker) bucketIndex() int64 {
+	bucketSize := breaker.options.window / time.Duration(len(breaker.buckets))
+	if bucketSize <= 0 {
+		bucketSize = 1
+	}
+
+	return breaker.now().UnixNano() / int64(bucketSize)
+}
+
+func isBreakerFailure(err error) bool {
+	return err != nil
+}
@@ -0,0 +1,206 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/boostgo/core/errorx"
+)
+
+func newTestBreaker(now *time.Time, opts ...BreakerOption) *Breaker {
+	breaker := NewBreaker("test", opts...)
+	breaker.now = func() time.Time {
+		return *now
+	}
+	return breaker
+}
+
+func TestBreakerOpensOnFailureRate(t *testing.T) {
+	now := time.Now()
+	breaker := newTestBreaker(&now, WithBreakerMinRequests(4), WithBreakerFailureRate(0.5))
+
+	fail := func(ctx context.Context) error { return errors.New("fail") }
+	success := func(ctx context.Context) error { return nil }
+
+	_ = breaker.Execute(context.Background(), success)
+	_ = breaker.Execute(context.Background(), success)
+	_ = breaker.Execute(context.Background(), fail)
+	if breaker.State() != BreakerClosed {
+		t.Fatalf("expected closed breaker before min requests, got %s", breaker.State())
+	}
+
+	_ = breaker.Execute(context.Background(), fail)
+	if breaker.State() != BreakerOpen {
+		t.Fatalf("expected open breaker, got %s", breaker.State())
+	}
+
+	called := false
+	err := breaker.Execute(context.Background(), func(ctx context.Context) error {
+		called = true
+		return nil
+	})
+	if called {
+		t.Fatal("expected function not to be called by open breaker")
+	}
+
+	if !errors.Is(err, ErrBreakerOpen) || !errors.Is(err, errorx.ErrServiceUnavailable) {
+		t.Fatalf("expected breaker open error, got %v", err)
+	}
+}
+
+func TestBreakerHalfOpen(t *testing.T) {
+	now := time.Now()
+	var changes []BreakerState
+	breaker := newTestBreaker(
+		&now,
+		WithBreakerMinRequests(1),
+		WithBreakerCoolDown(time.Second),
+		WithBreakerHalfOpenRequests(2),
+		WithBreakerOnStateChange(func(name string, from, to BreakerState) {
+			changes = append(changes, to)
+		}),
+	)
+
+	_ = breaker.Execute(context.Background(), func(ctx context.Context) error { return errors.New("fail") })
+	if breaker.State() != BreakerOpen {
+		t.Fatalf("expected open breaker, got %s", breaker.State())
+	}
+
+	now = now.Add(time.Second)
+	if breaker.State() != BreakerHalfOpen {
+		t.Fatalf("expected half-open breaker, got %s", breaker.State())
+	}
+
+	// failed probe opens breaker again
+	_ = breaker.Execute(context.Background(), func(ctx context.Context) error { return errors.New("fail") })
+	if breaker.State() != BreakerOpen {
+		t.Fatalf("expected open breaker after failed probe, got %s", breaker.State())
+	}
+
+	now = now.Add(time.Second)
+	for i := 0; i < 2; i++ {
+		if err := breaker.Execute(context.Background(), func(ctx context.Context) error { return nil }); err != nil {
+			t.Fatalf("expected probe to be executed, got %v", err)
+		}
+	}
+
+	if breaker.State() != BreakerClosed {
+		t.Fatalf("expected closed breaker after probes, got %s", breaker.State())
+	}
+
+	expected := []BreakerState{BreakerOpen, BreakerHalfOpen, BreakerOpen, BreakerHalfOpen, BreakerClosed}
+	if len(changes) != len(expected) {
+		t.Fatalf("expected %v state changes, got %v", expected, changes)
+	}
+
+	for i := range expected {
+		if changes[i] != expected[i] {
+			t.Fatalf("expected %v state changes, got %v", expected, changes)
+		}
+	}
+}
+
+func TestBreakerHalfOpenLimitsProbes(t *testing.T) {
+	now := time.Now()
+	breaker := newTestBreaker(&now, WithBreakerMinRequests(1), WithBreakerCoolDown(time.Second))
+
+	_ = breaker.Execute(context.Background(), func(ctx context.Context) error { return errors.New("fail") })
+	now = now.Add(time.Second)
+
+	probe := make(chan struct{})
+	done := make(chan error)
+	go func() {
+		done <- breaker.Execute(context.Background(), func(ctx context.Context) error {
+			<-probe
+			return nil
+		})
+	}()
+
+	// wait till probe is started
+	for breaker.State() != BreakerHalfOpen || !breakerHasProbe(breaker) {
+		time.Sleep(time.Millisecond)
+	}
+
+	if err := breaker.Execute(context.Background(), func(ctx context.Context) error { return nil }); !errors.Is(err, ErrBreakerOpen) {
+		t.Fatalf("expected second probe to be rejected, got %v", err)
+	}
+
+	close(probe)
+	if err := <-done; err != nil {
+		t.Fatalf("expected probe success, got %v", err)
+	}
+
+	if breaker.State() != BreakerClosed {
+		t.Fatalf("expected closed breaker, got %s", breaker.State())
+	}
+}
+
+func TestBreakerRollingWindow(t *testing.T) {
+	now := time.Now()
+	breaker := newTestBreaker(&now, WithBreakerWindow(time.Second*10), WithBreakerMinRequests(2))
+
+	_ = breaker.Execute(context.Background(), func(ctx context.Context) error { return errors.New("fail") })
+	if counts := breaker.Counts(); counts.Requests != 1 || counts.Failures != 1 {
+		t.Fatalf("unexpected counts: %+v", counts)
+	}
+
+	// old failures are out of window
+	now = now.Add(time.Second * 11)
+	if counts := breaker.Counts(); counts.Requests != 0 {
+		t.Fatalf("expected empty window, got %+v", counts)
+	}
+
+	_ = breaker.Execute(context.Background(), func(ctx context.Context) error { return errors.New("fail") })
+	if breaker.State() != BreakerClosed {
+		t.Fatalf("expected closed breaker, got %s", breaker.State())
+	}
+}
+
+func TestBreakerIgnoresCanceledContext(t *testing.T) {
+	now := time.Now()
+	breaker := newTestBreaker(&now, WithBreakerMinRequests(1))
+
+	_ = breaker.Execute(context.Background(), func(ctx context.Context) error { return context.Canceled })
+	if breaker.State() != BreakerClosed {
+		t.Fatalf("expected closed breaker, got %s", breaker.State())
+	}
+
+	if counts := breaker.Counts(); counts.Requests != 0 {
+		t.Fatalf("expected canceled call not to be counted, got %+v", counts)
+	}
+}
+
+func TestBreakerHalfOpenIgnoresCanceledProbe(t *testing.T) {
+	now := time.Now()
+	breaker := newTestBreaker(&now, WithBreakerMinRequests(1), WithBreakerCoolDown(time.Second))
+
+	_ = breaker.Execute(context.Background(), func(ctx context.Context) error { return errors.New("fail") })
+	now = now.Add(time.Second)
+
+	// canceled probe does not close breaker
+	_ = breaker.Execute(context.Background(), func(ctx context.Context) error { return context.Canceled })
+	if breaker.State() != BreakerHalfOpen {
+		t.Fatalf("expected half-open breaker after canceled probe, got %s", breaker.State())
+	}
+
+	// probe slot is released
+	if breakerHasProbe(breaker) {
+		t.Fatal("expected probe slot to be released")
+	}
+
+	if err := breaker.Execute(context.Background(), func(ctx context.Context) error { return errors.New("fail") }); errors.Is(err, ErrBreakerOpen) {
+		t.Fatalf("expected next probe to be executed, got %v", err)
+	}
+
+	if breaker.State() != BreakerOpen {
+		t.Fatalf("expected open breaker after failed probe, got %s", breaker.State())
+	}
+}
+
+func breakerHasProbe(breaker *Breaker) bool {
+	breaker.mx.Lock()
+	defer breaker.mx.Unlock()
+	return breaker.probes > 0
+}
@@ -11,6 +11,7 @@ import (
 var (
 	ErrMaxRetriesExceeded = errorx.New("retry.maximum_retry_attempts")
 	ErrNonRetryable       = errorx.New("retry.non_retryable_error")
+	ErrBreakerOpen        = errorx.New("retry.breaker_open").SetError(errorx.ErrServiceUnavailable)
 )
 
 // Error wraps the original error with retry metadata
@@ -2,8 +2,10 @@ package samples
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/boostgo/core/authx"
 	"github.com/boostgo/core/echox"
 	"github.com/boostgo/core/log"
 	"github.com/boostgo/core/log/logx"
@@ -32,6 +34,14 @@ type Server struct {
 	Host         string         `json:"host" yaml:"host" default:"0.0.0.0"`
 	Port         int            `json:"port" yaml:"port" default:"80"`
 	ShutdownWait timex.Duration `json:"shutdown_wait" yaml:"shutdownWait"`
+	HTTP         echox.Options  `json:"http" yaml:"http"`
+}
+
+// Options returns echox server options with address built from host & port
+func (s Server) Options() echox.Options {
+	options := s.HTTP
+	options.Address = s.Address()
+	return options
 }
 
 func (s Server) Address() string {
@@ -124,6 +134,22 @@ func (s Swagger) Init(cfg *swag.Spec) {
 	}
 }
 
+func (s Swagger) OpenAPIInfo() echox.OpenAPIInfo {
+	servers := make([]string, 0, len(s.Schemes))
+	if s.Host != "" {
+		for _, scheme := range s.Schemes {
+			servers = append(servers, scheme+"://"+s.Host+s.BasePath)
+		}
+	}
+
+	return echox.OpenAPIInfo{
+		Title:       s.Title,
+		Version:     s.Version,
+		Description: s.Description,
+		Servers:     servers,
+	}
+}
+
 func (s Swagger) Log() {
 	log.
 		Info().
@@ -361,13 +387,30 @@ func (r Redis) Log() {
 }
 
 type Keycloak struct {
-	Host string `json:"host" yaml:"host"`
+	Host  string `json:"host" yaml:"host"`
+	Realm string `json:"realm" yaml:"realm"`
+}
+
+// Issuer returns realm issuer URL ("iss" claim of realm tokens)
+func (k Keycloak) Issuer() string {
+	return strings.TrimSuffix(k.Host, "/") + "/realms/" + k.Realm
+}
+
+// JWKSURL returns realm public keys URL
+func (k Keycloak) JWKSURL() string {
+	return k.Issuer() + "/protocol/openid-connect/certs"
+}
+
+// JWKS returns realm keys provider which follows keys rotation
+func (k Keycloak) JWKS(opts ...authx.JWKSOption) *authx.JWKS {
+	return authx.NewJWKS(k.JWKSURL(), opts...)
 }
 
 func (k Keycloak) Log() {
 	log.
 		Info().
 		Str("host", k.Host).
+		Str("realm", k.Realm).
 		Msg("Keycloak config")
 }
 
@@ -2,6 +2,8 @@ package sorts
 
 import (
 	"fmt"
+	"regexp"
+	"slices"
 )
 
 const (
@@ -9,6 +11,9 @@ const (
 	Desc = "DESC"
 )
 
+// fieldPattern allows plain column names, like "created_at" or "o.created_at"
+var fieldPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+
 type Params struct {
 	Field string
 	Asc   bool
@@ -18,7 +23,24 @@ func (p Params) Empty() bool {
 	return p.Field == ""
 }
 
+// Valid returns true if field is plain column name and (if allowed fields are provided) is one of allowed fields
+func (p Params) Valid(allowed ...string) bool {
+	if !fieldPattern.MatchString(p.Field) {
+		return false
+	}
+
+	return len(allowed) == 0 || slices.Contains(allowed, p.Field)
+}
+
+// Query returns SQL sort like "created_at DESC".
+//
+// Field comes from request, so it is checked to be plain column name: if it is not, empty string is returned.
+// Use Valid with allowed fields to check field before, or filterx package for multiple sorts with allowlist
 func (p Params) Query(alias ...string) string {
+	if !p.Valid() {
+		return ""
+	}
+
 	var direction string
 	if p.Asc {
 		direction = Asc
@@ -0,0 +1,52 @@
+package sorts
+
+import "testing"
+
+func TestParamsValid(t *testing.T) {
+	tests := []struct {
+		name    string
+		field   string
+		allowed []string
+		valid   bool
+	}{
+		{name: "plain column", field: "created_at", valid: true},
+		{name: "column with alias", field: "o.created_at", valid: true},
+		{name: "allowed column", field: "name", allowed: []string{"name", "created_at"}, valid: true},
+		{name: "not allowed column", field: "password", allowed: []string{"name"}, valid: false},
+		{name: "empty", field: "", valid: false},
+		{name: "injection", field: "name; DROP TABLE users", valid: false},
+		{name: "expression", field: "(SELECT 1)", valid: false},
+		{name: "starts with digit", field: "1name", valid: false},
+		{name: "nested alias", field: "a.b.c", valid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if valid := (Params{Field: tt.field}).Valid(tt.allowed...); valid != tt.valid {
+				t.Errorf("expected %v, got %v", tt.valid, valid)
+			}
+		})
+	}
+}
+
+func TestParamsQuery(t *testing.T) {
+	tests := []struct {
+		name     string
+		params   Params
+		alias    []string
+		expected string
+	}{
+		{name: "desc", params: Params{Field: "created_at"}, expected: "created_at DESC"},
+		{name: "asc", params: Params{Field: "name", Asc: true}, expected: "name ASC"},
+		{name: "alias", params: Params{Field: "name", Asc: true}, alias: []string{"u"}, expected: "u.name ASC"},
+		{name: "invalid field", params: Params{Field: "name DESC; --"}, expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if query := tt.params.Query(tt.alias...); query != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, query)
+			}
+		})
+	}
+}
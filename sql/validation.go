@@ -0,0 +1,108 @@
+package sql
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/boostgo/core/validator"
+)
+
+// UniqueOption modifies UniqueRule settings
+type UniqueOption func(options *uniqueOptions)
+
+type uniqueOptions struct {
+	excludeColumn string
+	excludeField  string
+}
+
+// WithUniqueExclude excludes row of the validating model from the check, so the rule could be used for updates.
+//
+// Column is table ID column, field is name of the struct field (of the same struct) which contains ID.
+// If ID field is empty (creating), no rows are excluded
+//
+//	validator.RegisterContext(validator.Get(), "unique_email", sql.UniqueRule(db, "users", "email", sql.WithUniqueExclude("id", "ID")))
+func WithUniqueExclude(column, field string) UniqueOption {
+	return func(options *uniqueOptions) {
+		options.excludeColumn = column
+		options.excludeField = field
+	}
+}
+
+// UniqueRule returns context-aware validation rule which checks that there is no row with field value in the table column.
+//
+// Empty values are valid (use "required" tag for them). Without WithUniqueExclude option the rule could be used only
+// for creating: on update the row itself has the same value.
+//
+//	validator.RegisterContext(validator.Get(), "unique_email", sql.UniqueRule(db, "users", "email"))
+func UniqueRule(db DB, table, column string, opts ...UniqueOption) validator.RuleFunc {
+	var options uniqueOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return func(ctx context.Context, field validator.FieldLevel) (bool, error) {
+		if field.Field().IsZero() {
+			return true, nil
+		}
+
+		args := NewArguments()
+		condition := column + " = " + args.Add(field.Field().Interface()).Number()
+
+		if options.excludeField != "" {
+			if id, ok := structField(field.Parent(), options.excludeField); ok && !id.IsZero() {
+				condition += " AND " + options.excludeColumn + " <> " + args.Add(id.Interface()).Number()
+			}
+		}
+
+		exists, err := rowExists(ctx, db, existsQuery(table, condition), args)
+		return !exists, err
+	}
+}
+
+// ExistsRule returns context-aware validation rule which checks that there is row with field value in the table column,
+// for example foreign ID.
+//
+// Empty values are valid (use "required" tag for them).
+//
+//	validator.RegisterContext(validator.Get(), "category_exists", sql.ExistsRule(db, "categories", "id"))
+func ExistsRule(db DB, table, column string) validator.RuleFunc {
+	return func(ctx context.Context, field validator.FieldLevel) (bool, error) {
+		if field.Field().IsZero() {
+			return true, nil
+		}
+
+		args := NewArguments()
+		condition := column + " = " + args.Add(field.Field().Interface()).Number()
+		return rowExists(ctx, db, existsQuery(table, condition), args)
+	}
+}
+
+func existsQuery(table, condition string) string {
+	return "SELECT EXISTS(SELECT 1 FROM " + table + " WHERE " + condition + ")"
+}
+
+func rowExists(ctx context.Context, db DB, query string, args *Arguments) (exists bool, err error) {
+	if err = db.GetContext(ctx, &exists, query, args.Args()...); err != nil {
+		return false, err
+	}
+
+	return exists, nil
+}
+
+// structField returns field of struct (or pointer to struct) by name
+func structField(value reflect.Value, name string) (reflect.Value, bool) {
+	for value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return reflect.Value{}, false
+		}
+
+		value = value.Elem()
+	}
+
+	if value.Kind() != reflect.Struct {
+		return reflect.Value{}, false
+	}
+
+	field := value.FieldByName(name)
+	return field, field.IsValid()
+}
@@ -0,0 +1,159 @@
+package sql
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/boostgo/core/validator"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+)
+
+type testProduct struct {
+	ID         int    `json:"id"`
+	Name       string `json:"name" validate:"unique_name"`
+	CategoryID int    `json:"category_id" validate:"category_exists"`
+}
+
+func newTestValidator(t *testing.T) (*validator.Validator, sqlmock.Sqlmock) {
+	t.Helper()
+
+	conn, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	db := Client(sqlx.NewDb(conn, "postgres"))
+	v := validator.New()
+	if err = validator.RegisterContext(v, "unique_name", UniqueRule(db, "products", "name", WithUniqueExclude("id", "ID"))); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err = validator.RegisterContext(v, "category_exists", ExistsRule(db, "categories", "id")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return v, mock
+}
+
+func expectExists(mock sqlmock.Sqlmock, query string, exists bool, args ...driver.Value) {
+	mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WithArgs(args...).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(exists))
+}
+
+func TestUniqueRule(t *testing.T) {
+	const (
+		createQuery = `SELECT EXISTS(SELECT 1 FROM products WHERE name = $1)`
+		updateQuery = `SELECT EXISTS(SELECT 1 FROM products WHERE name = $1 AND id <> $2)`
+	)
+
+	tests := []struct {
+		name    string
+		product testProduct
+		expect  func(mock sqlmock.Sqlmock)
+		valid   bool
+	}{
+		{
+			name:    "create unique",
+			product: testProduct{Name: "phone"},
+			expect: func(mock sqlmock.Sqlmock) {
+				expectExists(mock, createQuery, false, "phone")
+			},
+			valid: true,
+		},
+		{
+			name:    "create duplicate",
+			product: testProduct{Name: "phone"},
+			expect: func(mock sqlmock.Sqlmock) {
+				expectExists(mock, createQuery, true, "phone")
+			},
+		},
+		{
+			name:    "update excludes current row",
+			product: testProduct{ID: 10, Name: "phone"},
+			expect: func(mock sqlmock.Sqlmock) {
+				expectExists(mock, updateQuery, false, "phone", 10)
+			},
+			valid: true,
+		},
+		{
+			name:    "update duplicate of other row",
+			product: testProduct{ID: 10, Name: "phone"},
+			expect: func(mock sqlmock.Sqlmock) {
+				expectExists(mock, updateQuery, true, "phone", 10)
+			},
+		},
+		{
+			name:    "empty value is not checked",
+			product: testProduct{},
+			expect:  func(sqlmock.Sqlmock) {},
+			valid:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, mock := newTestValidator(t)
+			tt.expect(mock)
+
+			err := v.StructCtx(context.Background(), &tt.product)
+			if tt.valid && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !tt.valid {
+				fields, ok := validator.Fields(err)
+				if !ok || len(fields) != 1 || fields[0].Field != "name" || fields[0].Rule != "unique_name" {
+					t.Fatalf("expected unique name field error, got %v", err)
+				}
+			}
+
+			if err = mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestExistsRule(t *testing.T) {
+	const query = `SELECT EXISTS(SELECT 1 FROM categories WHERE id = $1)`
+
+	t.Run("exists", func(t *testing.T) {
+		v, mock := newTestValidator(t)
+		expectExists(mock, query, true, 5)
+
+		if err := v.StructCtx(context.Background(), testProduct{CategoryID: 5}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("not exists", func(t *testing.T) {
+		v, mock := newTestValidator(t)
+		expectExists(mock, query, false, 5)
+
+		fields, ok := validator.Fields(v.StructCtx(context.Background(), testProduct{CategoryID: 5}))
+		if !ok || len(fields) != 1 || fields[0].Field != "category_id" {
+			t.Fatalf("expected category field error, got %v", fields)
+		}
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		v, mock := newTestValidator(t)
+		queryErr := errors.New("connection refused")
+		mock.ExpectQuery(regexp.QuoteMeta(query)).WillReturnError(queryErr)
+
+		// storage error is not validation error of the field
+		err := v.StructCtx(context.Background(), testProduct{CategoryID: 5})
+		if !errors.Is(err, validator.ErrRule) || !errors.Is(err, queryErr) {
+			t.Fatalf("expected rule error, got %v", err)
+		}
+	})
+}
@@ -6,10 +6,15 @@ import (
 	"github.com/boostgo/core/echox"
 	"github.com/boostgo/core/errorx"
 	"github.com/boostgo/core/translate"
+	"github.com/boostgo/core/validator"
 
 	"github.com/labstack/echo/v4"
 )
 
+// FailureMiddleware translates error message and messages of validation field errors by locale from provided header.
+//
+// Field messages are translated by "validator.rule.<rule>" keys (see validator.RuleMessageKey).
+// If there is no translation, message is not changed
 func FailureMiddleware(
 	translator *translate.Translator,
 	localeHeaderName string,
@@ -21,6 +26,13 @@ func FailureMiddleware(
 		}
 
 		locale := translate.Locale(echox.Header(ctx, localeHeaderName).String(translate.LocaleRussian.String()))
+
+		// fields are translated to the copy, so field errors of shared errors are not changed
+		if fields, ok := validator.Fields(converted); ok {
+			_ = converted.NoCopy()
+			_ = validator.ReplaceFields(converted, translateFields(translator, locale, fields))
+		}
+
 		text, err := translator.TextByKey(locale, converted.Message())
 		if err != nil {
 			return
@@ -30,3 +42,19 @@ func FailureMiddleware(
 		_ = converted.SetLocaleMessage(text)
 	}
 }
+
+func translateFields(translator *translate.Translator, locale translate.Locale, fields []validator.FieldError) []validator.FieldError {
+	translated := make([]validator.FieldError, len(fields))
+	copy(translated, fields)
+
+	for i := range translated {
+		template, err := translator.TextByKey(locale, validator.RuleMessageKey(translated[i].Rule))
+		if err != nil {
+			continue
+		}
+
+		translated[i].Message = translated[i].Format(template)
+	}
+
+	return translated
+}
@@ -50,6 +50,25 @@ var defaultTexts = map[Locale]map[string]string{
 		"server_start":       "Ошибка запуска сервера",
 
 		"validator.model": "Ошибка валидации модели",
+		"param.bind":      "Неверные параметры запроса",
+
+		"validator.rule.required":  "Поле {field} обязательно",
+		"validator.rule.email":     "Поле {field} должно быть корректным email",
+		"validator.rule.url":       "Поле {field} должно быть корректным URL",
+		"validator.rule.uuid":      "Поле {field} должно быть корректным UUID",
+		"validator.rule.min":       "Поле {field} должно быть не меньше {param}",
+		"validator.rule.max":       "Поле {field} должно быть не больше {param}",
+		"validator.rule.len":       "Длина поля {field} должна быть {param}",
+		"validator.rule.eq":        "Поле {field} должно быть равно {param}",
+		"validator.rule.ne":        "Поле {field} не должно быть равно {param}",
+		"validator.rule.gt":        "Поле {field} должно быть больше {param}",
+		"validator.rule.gte":       "Поле {field} должно быть больше или равно {param}",
+		"validator.rule.lt":        "Поле {field} должно быть меньше {param}",
+		"validator.rule.lte":       "Поле {field} должно быть меньше или равно {param}",
+		"validator.rule.oneof":     "Поле {field} должно быть одним из [{param}]",
+		"validator.rule.numeric":   "Поле {field} должно быть числом",
+		"validator.rule.undefined": "Поле {field} не должно быть \"undefined\"",
+		"validator.rule.type":      "Поле {field} должно быть корректным значением типа {param}",
 
 		"translate.key_not_found": "Ключ перевода не найден",
 
@@ -108,7 +127,9 @@ var defaultTexts = map[Locale]map[string]string{
 		"request_parse_body": "Parse request body error",
 		"server_start":       "Start server error",
 
+		// rule messages ("validator.rule.<rule>") are default messages of validator field errors
 		"validator.model": "Model validation error",
+		"param.bind":      "Invalid request params",
 
 		"translate.key_not_found": "Translation key not found",
 
@@ -168,6 +189,25 @@ var defaultTexts = map[Locale]map[string]string{
 		"server_start":       "Серверді іске қосу қатесі",
 
 		"validator.model": "Модельді тексеру қатесі",
+		"param.bind":      "Сұрау параметрлері қате",
+
+		"validator.rule.required":  "{field} өрісі міндетті",
+		"validator.rule.email":     "{field} өрісі дұрыс email болуы керек",
+		"validator.rule.url":       "{field} өрісі дұрыс URL болуы керек",
+		"validator.rule.uuid":      "{field} өрісі дұрыс UUID болуы керек",
+		"validator.rule.min":       "{field} өрісі кемінде {param} болуы керек",
+		"validator.rule.max":       "{field} өрісі көп дегенде {param} болуы керек",
+		"validator.rule.len":       "{field} өрісінің ұзындығы {param} болуы керек",
+		"validator.rule.eq":        "{field} өрісі {param} мәніне тең болуы керек",
+		"validator.rule.ne":        "{field} өрісі {param} мәніне тең болмауы керек",
+		"validator.rule.gt":        "{field} өрісі {param} мәнінен үлкен болуы керек",
+		"validator.rule.gte":       "{field} өрісі {param} мәнінен үлкен немесе тең болуы керек",
+		"validator.rule.lt":        "{field} өрісі {param} мәнінен кіші болуы керек",
+		"validator.rule.lte":       "{field} өрісі {param} мәнінен кіші немесе тең болуы керек",
+		"validator.rule.oneof":     "{field} өрісі [{param}] мәндерінің бірі болуы керек",
+		"validator.rule.numeric":   "{field} өрісі сан болуы керек",
+		"validator.rule.undefined": "{field} өрісі \"undefined\" болмауы керек",
+		"validator.rule.type":      "{field} өрісі дұрыс {param} мәні болуы керек",
 
 		"translate.key_not_found": "Аударма кілті табылмады",
 
@@ -1,13 +1,97 @@
 package validator
 
-import "github.com/boostgo/core/errorx"
+import (
+	"errors"
+
+	"github.com/boostgo/core/errorx"
+)
 
 var (
 	ErrModelValidation    = errorx.New("validator.model")
 	ErrVariableValidation = errorx.New("validator.variable")
+	ErrRule               = errorx.New("validator.rule_failed")
 )
 
 type validationContext struct {
-	Validation  string   `json:"validation,omitempty"`
-	Validations []string `json:"validations,omitempty"`
+	Validation  string       `json:"validation,omitempty"`
+	Validations []string     `json:"validations,omitempty"`
+	Fields      []FieldError `json:"-"`
+}
+
+// FieldError is validation error of one field.
+//
+// Field is JSON path of the field built by json tag names, like "address.city" or "items[0].name".
+// For variable validation it is empty
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Param   string `json:"param,omitempty"`
+	Value   any    `json:"value,omitempty"`
+	Message string `json:"message"`
+}
+
+func (validation validationContext) FieldErrors() []FieldError {
+	return validation.Fields
+}
+
+// FieldErrors is error data (see errorx.Error.SetData) which contains only field errors.
+//
+// Could be used by other packages (for example, params binding) to return field errors like validation does
+type FieldErrors []FieldError
+
+func (fields FieldErrors) FieldErrors() []FieldError {
+	return fields
+}
+
+// fieldErrorsContainer is error data which contains field errors
+type fieldErrorsContainer interface {
+	FieldErrors() []FieldError
+}
+
+// Fields returns field errors of validation error (ErrModelValidation, ErrVariableValidation)
+// or any error with FieldErrors data.
+//
+// Error could be wrapped by other errors. Returned slice is not copied, so it must not be changed.
+// Use ReplaceFields to set changed (for example, translated) field errors
+func Fields(err error) ([]FieldError, bool) {
+	for err != nil {
+		var converted *errorx.Error
+		if !errors.As(err, &converted) {
+			return nil, false
+		}
+
+		if container, ok := converted.Data().(fieldErrorsContainer); ok && len(container.FieldErrors()) > 0 {
+			return container.FieldErrors(), true
+		}
+
+		err = converted.Inner()
+	}
+
+	return nil, false
+}
+
+// ReplaceFields sets field errors to the error which contains them (see Fields) and returns result error.
+//
+// Errors are changed like by errorx.Error.SetData & SetError: they are copied, if errorx.Error.NoCopy was not called.
+// If there are no field errors, provided error is returned
+func ReplaceFields(err *errorx.Error, fields []FieldError) *errorx.Error {
+	if container, ok := err.Data().(fieldErrorsContainer); ok && len(container.FieldErrors()) > 0 {
+		return err.SetData(replaceFields(err.Data(), fields))
+	}
+
+	var inner *errorx.Error
+	if !errors.As(err.Inner(), &inner) {
+		return err
+	}
+
+	return err.SetError(ReplaceFields(inner, fields))
+}
+
+func replaceFields(data any, fields []FieldError) any {
+	if validation, ok := data.(validationContext); ok {
+		validation.Fields = fields
+		return validation
+	}
+
+	return FieldErrors(fields)
 }
@@ -0,0 +1,103 @@
+package validator
+
+import (
+	"strings"
+
+	baseValidator "github.com/go-playground/validator/v10"
+)
+
+const (
+	messageFieldPlaceholder = "{field}"
+	messageParamPlaceholder = "{param}"
+	messageRulePlaceholder  = "{rule}"
+
+	variableFieldName = "value"
+	ruleMessagePrefix = "validator.rule."
+)
+
+// defaultRuleMessages are english messages of rules. Could be translated by translate package with RuleMessageKey keys,
+// english messages are taken only from here
+var defaultRuleMessages = map[string]string{
+	"required":  "{field} is required",
+	"email":     "{field} must be a valid email",
+	"url":       "{field} must be a valid URL",
+	"uuid":      "{field} must be a valid UUID",
+	"min":       "{field} must be at least {param}",
+	"max":       "{field} must be at most {param}",
+	"len":       "{field} must have length {param}",
+	"eq":        "{field} must be equal to {param}",
+	"ne":        "{field} must not be equal to {param}",
+	"gt":        "{field} must be greater than {param}",
+	"gte":       "{field} must be greater than or equal to {param}",
+	"lt":        "{field} must be less than {param}",
+	"lte":       "{field} must be less than or equal to {param}",
+	"oneof":     "{field} must be one of [{param}]",
+	"numeric":   "{field} must be numeric",
+	"undefined": "{field} must not be \"undefined\"",
+	"type":      "{field} must be a valid {param}",
+}
+
+const defaultRuleMessage = "{field} failed on \"{rule}\" rule"
+
+// RuleMessageKey returns translation key of the rule message, like "validator.rule.required".
+//
+// Translation text could contain {field} & {param} placeholders
+func RuleMessageKey(rule string) string {
+	return ruleMessagePrefix + rule
+}
+
+// Format replaces {field} & {param} placeholders of message template by field error values
+func (fieldError FieldError) Format(template string) string {
+	field := fieldError.Field
+	if field == "" {
+		field = variableFieldName
+	}
+
+	return strings.NewReplacer(
+		messageFieldPlaceholder, field,
+		messageParamPlaceholder, fieldError.Param,
+		messageRulePlaceholder, fieldError.Rule,
+	).Replace(template)
+}
+
+func newFieldErrors(validationErrors baseValidator.ValidationErrors) []FieldError {
+	fields := make([]FieldError, 0, len(validationErrors))
+	for _, validationError := range validationErrors {
+		fields = append(fields, NewFieldError(
+			fieldPath(validationError.Namespace()),
+			validationError.Tag(),
+			validationError.Param(),
+			validationError.Value(),
+		))
+	}
+
+	return fields
+}
+
+// NewFieldError creates field error with default (english) message of the rule
+func NewFieldError(field, rule, param string, value any) FieldError {
+	fieldError := FieldError{
+		Field: field,
+		Rule:  rule,
+		Param: param,
+		Value: value,
+	}
+
+	template, ok := defaultRuleMessages[rule]
+	if !ok {
+		template = defaultRuleMessage
+	}
+
+	fieldError.Message = fieldError.Format(template)
+	return fieldError
+}
+
+// fieldPath cuts root struct name from namespace: "User.address.city" -> "address.city"
+func fieldPath(namespace string) string {
+	if index := strings.IndexByte(namespace, '.'); index != -1 {
+		return namespace[index+1:]
+	}
+
+	// variables have no namespace
+	return ""
+}
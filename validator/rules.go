@@ -0,0 +1,207 @@
+package validator
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"sync"
+
+	"github.com/boostgo/core/errorx"
+
+	baseValidator "github.com/go-playground/validator/v10"
+)
+
+// FieldLevel contains validating field and its parent
+type FieldLevel = baseValidator.FieldLevel
+
+// RuleFunc is context-aware validation rule. Context is the one provided to Validator.StructCtx or Validator.VarCtx,
+// so rule could use storage, for example to check email uniqueness.
+//
+// Returned error (for example, storage is unavailable) is not validation error: validation returns ErrRule with it
+type RuleFunc func(ctx context.Context, field FieldLevel) (bool, error)
+
+// RegisterContext registers context-aware validation rule by tag
+func RegisterContext(validator *Validator, tag string, rule RuleFunc) error {
+	return validator.RegisterValidationCtx(tag, func(ctx context.Context, field baseValidator.FieldLevel) bool {
+		valid, err := rule(ctx, field)
+		if err != nil {
+			// error is returned by validation instead of field validation error.
+			// If context is not validation context, rule is just failed
+			return addRuleError(ctx, ErrRule.SetError(err).AddParam("tag", tag))
+		}
+
+		return valid
+	})
+}
+
+// StructRule is struct level validation rule of T, for example cross-field or conditional rule.
+//
+// Rule reports field errors by report
+type StructRule[T any] func(ctx context.Context, object T, report *Report)
+
+// RegisterStruct registers struct level rule of T (T must be struct, not pointer).
+//
+// If scenarios are provided, rule is applied only if validation context has one of them (see WithScenario).
+// Several rules could be registered for the same type
+func RegisterStruct[T any](validator *Validator, rule StructRule[T], scenarios ...string) {
+	var zero T
+	validator.registerStructRule(reflect.TypeOf(zero), structRule{
+		scenarios: scenarios,
+		validate: func(ctx context.Context, level baseValidator.StructLevel) {
+			object, ok := level.Current().Interface().(T)
+			if !ok {
+				return
+			}
+
+			rule(ctx, object, &Report{
+				level:     level,
+				validator: validator,
+			})
+		},
+	})
+}
+
+// When returns rule which applies provided rules only if condition is true.
+//
+// For example, validate "phone_code" only when "phone" is set:
+//
+//	validator.RegisterStruct(v, validator.When(func(user User) bool {
+//		return user.Phone != ""
+//	}, func(ctx context.Context, user User, report *validator.Report) {
+//		report.Var(ctx, "phone_code", user.PhoneCode, "required,numeric")
+//	}))
+func When[T any](condition func(object T) bool, rules ...StructRule[T]) StructRule[T] {
+	return func(ctx context.Context, object T, report *Report) {
+		if !condition(object) {
+			return
+		}
+
+		for _, rule := range rules {
+			rule(ctx, object, report)
+		}
+	}
+}
+
+// Report collects field errors of struct level rule
+type Report struct {
+	level     baseValidator.StructLevel
+	validator *Validator
+}
+
+// Error reports field error. Field is field name in the error path (json name)
+func (report *Report) Error(field, rule string, value any, param ...string) {
+	var ruleParam string
+	if len(param) > 0 {
+		ruleParam = param[0]
+	}
+
+	report.level.ReportError(value, field, field, rule, ruleParam)
+}
+
+// Var validates value by tag (like "required,email") and reports errors as errors of the field
+func (report *Report) Var(ctx context.Context, field string, value any, tag string) {
+	err := report.validator.Validate.VarCtx(ctx, value, tag)
+	if err == nil {
+		return
+	}
+
+	validationErrors, ok := err.(baseValidator.ValidationErrors)
+	if !ok {
+		_ = addRuleError(ctx, ErrRule.SetError(err).AddParam("field", field))
+		return
+	}
+
+	for _, validationError := range validationErrors {
+		report.Error(field, validationError.Tag(), value, validationError.Param())
+	}
+}
+
+type scenarioKey struct{}
+
+// WithScenario sets validation scenario (like "create" or "update") to context.
+//
+// Struct rules registered with scenarios are applied only for validation with the scenario
+func WithScenario(ctx context.Context, scenario string) context.Context {
+	return context.WithValue(ctx, scenarioKey{}, scenario)
+}
+
+// Scenario returns validation scenario set by WithScenario
+func Scenario(ctx context.Context) string {
+	scenario, _ := ctx.Value(scenarioKey{}).(string)
+	return scenario
+}
+
+type structRule struct {
+	scenarios []string
+	validate  func(ctx context.Context, level baseValidator.StructLevel)
+}
+
+func (validator *Validator) registerStructRule(structType reflect.Type, rule structRule) {
+	validator.mx.Lock()
+	defer validator.mx.Unlock()
+
+	if validator.structRules == nil {
+		validator.structRules = make(map[reflect.Type][]structRule)
+	}
+
+	_, registered := validator.structRules[structType]
+	validator.structRules[structType] = append(validator.structRules[structType], rule)
+	if registered {
+		return
+	}
+
+	validator.RegisterStructValidationCtx(func(ctx context.Context, level baseValidator.StructLevel) {
+		validator.mx.RLock()
+		rules := validator.structRules[structType]
+		validator.mx.RUnlock()
+
+		scenario := Scenario(ctx)
+		for _, rule := range rules {
+			if len(rule.scenarios) > 0 && !slices.Contains(rule.scenarios, scenario) {
+				continue
+			}
+
+			rule.validate(ctx, level)
+		}
+	}, reflect.New(structType).Elem().Interface())
+}
+
+type ruleErrorsKey struct{}
+
+type ruleErrors struct {
+	errors []error
+	mx     sync.Mutex
+}
+
+func withRuleErrors(ctx context.Context) (context.Context, *ruleErrors) {
+	collected := &ruleErrors{}
+	return context.WithValue(ctx, ruleErrorsKey{}, collected), collected
+}
+
+// addRuleError adds error to validation context. Returns false if context is not validation context
+func addRuleError(ctx context.Context, err error) bool {
+	collected, ok := ctx.Value(ruleErrorsKey{}).(*ruleErrors)
+	if !ok {
+		return false
+	}
+
+	collected.mx.Lock()
+	defer collected.mx.Unlock()
+
+	collected.errors = append(collected.errors, err)
+	return true
+}
+
+func (collected *ruleErrors) err() error {
+	collected.mx.Lock()
+	defer collected.mx.Unlock()
+
+	switch len(collected.errors) {
+	case 0:
+		return nil
+	case 1:
+		return collected.errors[0]
+	default:
+		return errorx.Join(collected.errors...)
+	}
+}
@@ -0,0 +1,182 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type testUser struct {
+	ID        int    `json:"id"`
+	Email     string `json:"email" validate:"required,unique_email"`
+	Phone     string `json:"phone"`
+	PhoneCode string `json:"phone_code"`
+	Password  string `json:"password"`
+}
+
+// fieldRules returns "field:rule" of every field error
+func fieldRules(t *testing.T, err error) []string {
+	t.Helper()
+
+	if err == nil {
+		return nil
+	}
+
+	fields, ok := Fields(err)
+	if !ok {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+
+	rules := make([]string, 0, len(fields))
+	for _, field := range fields {
+		rules = append(rules, field.Field+":"+field.Rule)
+	}
+
+	return rules
+}
+
+func equalRules(left, right []string) bool {
+	if len(left) != len(right) {
+		return false
+	}
+
+	for i := range left {
+		if left[i] != right[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func newTestUserValidator(t *testing.T, taken map[string]bool, storageErr error) *Validator {
+	t.Helper()
+
+	validator := New()
+	if err := RegisterContext(validator, "unique_email", func(ctx context.Context, field FieldLevel) (bool, error) {
+		if storageErr != nil {
+			return false, storageErr
+		}
+
+		return !taken[field.Field().String()], nil
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return validator
+}
+
+func TestRegisterContext(t *testing.T) {
+	validator := newTestUserValidator(t, map[string]bool{"taken@mail.com": true}, nil)
+
+	if err := validator.StructCtx(context.Background(), testUser{Email: "free@mail.com"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err := validator.StructCtx(context.Background(), testUser{Email: "taken@mail.com"})
+	if rules := fieldRules(t, err); !equalRules(rules, []string{"email:unique_email"}) {
+		t.Errorf("unexpected field errors: %v", rules)
+	}
+
+	// context rules work for variables too
+	if err = validator.VarCtx(context.Background(), "taken@mail.com", "unique_email"); !errors.Is(err, ErrVariableValidation) {
+		t.Errorf("expected variable validation error, got %v", err)
+	}
+}
+
+func TestRegisterContextRuleError(t *testing.T) {
+	storageErr := errors.New("storage is unavailable")
+	validator := newTestUserValidator(t, nil, storageErr)
+
+	err := validator.StructCtx(context.Background(), testUser{Email: "free@mail.com"})
+	if !errors.Is(err, ErrRule) || !errors.Is(err, storageErr) {
+		t.Fatalf("expected rule error with storage error, got %v", err)
+	}
+
+	if _, ok := Fields(err); ok {
+		t.Error("expected rule error not to contain field errors")
+	}
+}
+
+func TestRegisterStructWhen(t *testing.T) {
+	validator := newTestUserValidator(t, nil, nil)
+	RegisterStruct(validator, When(func(user testUser) bool {
+		return user.Phone != ""
+	}, func(ctx context.Context, user testUser, report *Report) {
+		report.Var(ctx, "phone_code", user.PhoneCode, "required,numeric")
+	}))
+
+	tests := []struct {
+		name     string
+		user     testUser
+		expected []string
+	}{
+		{name: "no phone", user: testUser{Email: "user@mail.com"}},
+		{name: "phone with code", user: testUser{Email: "user@mail.com", Phone: "555", PhoneCode: "7"}},
+		{name: "phone without code", user: testUser{Email: "user@mail.com", Phone: "555"}, expected: []string{"phone_code:required"}},
+		{name: "phone with invalid code", user: testUser{Email: "user@mail.com", Phone: "555", PhoneCode: "x"}, expected: []string{"phone_code:numeric"}},
+		{name: "tag & struct errors together", user: testUser{Phone: "555"}, expected: []string{"email:required", "phone_code:required"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.StructCtx(context.Background(), tt.user)
+			if rules := fieldRules(t, err); !equalRules(rules, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, rules)
+			}
+		})
+	}
+}
+
+func TestRegisterStructScenarios(t *testing.T) {
+	validator := newTestUserValidator(t, nil, nil)
+
+	// password is required on create, ID is required on update
+	RegisterStruct(validator, func(ctx context.Context, user testUser, report *Report) {
+		if user.Password == "" {
+			report.Error("password", "required", user.Password)
+		}
+	}, "create")
+	RegisterStruct(validator, func(ctx context.Context, user testUser, report *Report) {
+		if user.ID == 0 {
+			report.Error("id", "required", user.ID)
+		}
+	}, "update")
+	RegisterStruct(validator, func(ctx context.Context, user testUser, report *Report) {
+		if len(user.Password) > 0 && len(user.Password) < 8 {
+			report.Error("password", "min", user.Password, "8")
+		}
+	})
+
+	user := testUser{Email: "user@mail.com"}
+	tests := []struct {
+		name     string
+		scenario string
+		user     testUser
+		expected []string
+	}{
+		{name: "no scenario", user: user},
+		{name: "create", scenario: "create", user: user, expected: []string{"password:required"}},
+		{name: "update", scenario: "update", user: user, expected: []string{"id:required"}},
+		{name: "unknown scenario", scenario: "delete", user: user},
+		{name: "rule without scenario", scenario: "update", user: testUser{ID: 1, Email: "user@mail.com", Password: "short"}, expected: []string{"password:min"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.scenario != "" {
+				ctx = WithScenario(ctx, tt.scenario)
+			}
+
+			err := validator.StructCtx(ctx, tt.user)
+			if rules := fieldRules(t, err); !equalRules(rules, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, rules)
+			}
+		})
+	}
+
+	if scenario := Scenario(WithScenario(context.Background(), "create")); scenario != "create" {
+		t.Errorf("unexpected scenario: %q", scenario)
+	}
+}
@@ -1,11 +1,14 @@
 package validator
 
 import (
+	"context"
 	"errors"
+	"reflect"
+	"strings"
 	"sync"
 
 	"github.com/boostgo/core/errorx"
-	
+
 	baseValidator "github.com/go-playground/validator/v10"
 )
 
@@ -29,12 +32,36 @@ func Get() *Validator {
 type Validator struct {
 	*baseValidator.Validate
 	turnOff bool
+
+	structRules map[reflect.Type][]structRule
+	mx          sync.RWMutex
 }
 
+// New creates validator. Field errors contain field names from json tags (query, form & param tags if there is no json tag)
 func New() *Validator {
+	validate := baseValidator.New()
+	validate.RegisterTagNameFunc(fieldName)
+
 	return &Validator{
-		Validate: baseValidator.New(),
+		Validate: validate,
+	}
+}
+
+var fieldNameTags = []string{"json", "query", "form", "param"}
+
+func fieldName(field reflect.StructField) string {
+	for _, tag := range fieldNameTags {
+		name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+		if name == "-" {
+			continue
+		}
+
+		if name != "" {
+			return name
+		}
 	}
+
+	return field.Name
 }
 
 func (validator *Validator) TurnOff() *Validator {
@@ -42,12 +69,26 @@ func (validator *Validator) TurnOff() *Validator {
 	return validator
 }
 
+// Struct validates struct by "validate" tags and registered struct rules
 func (validator *Validator) Struct(object any) error {
+	return validator.StructCtx(context.Background(), object)
+}
+
+// StructCtx validates struct like Struct, but provides context to context-aware rules (see RegisterContext)
+// and struct rules (see RegisterStruct).
+//
+// If some rule returns error, ErrRule is returned
+func (validator *Validator) StructCtx(ctx context.Context, object any) error {
 	if validator.turnOff {
 		return nil
 	}
 
-	validateError := validator.Validate.Struct(object)
+	ctx, collected := withRuleErrors(ctx)
+	validateError := validator.Validate.StructCtx(ctx, object)
+	if ruleErr := collected.err(); ruleErr != nil {
+		return ruleErr
+	}
+
 	if validateError == nil {
 		return nil
 	}
@@ -64,26 +105,30 @@ func (validator *Validator) Struct(object any) error {
 		return nil
 	}
 
-	validations := make([]string, 0, len(validationErrors))
-	for _, validationError := range validationErrors {
-		validations = append(validations, validationError.Error())
-	}
-
-	return err.SetData(validationContext{
-		Validations: validations,
-	})
+	return err.SetData(newValidationContext(validationErrors))
 }
 
+// Var validates variable by tag (like "required,email")
 func (validator *Validator) Var(variable any, tag string) error {
+	return validator.VarCtx(context.Background(), variable, tag)
+}
+
+// VarCtx validates variable like Var, but provides context to context-aware rules (see RegisterContext)
+func (validator *Validator) VarCtx(ctx context.Context, variable any, tag string) error {
 	if validator.turnOff {
 		return nil
 	}
 
 	err := ErrVariableValidation.SetError(errorx.ErrUnprocessableEntity)
 
-	validateError := validator.Validate.Var(variable, tag)
-	if err == nil {
-		return err
+	ctx, collected := withRuleErrors(ctx)
+	validateError := validator.Validate.VarCtx(ctx, variable, tag)
+	if ruleErr := collected.err(); ruleErr != nil {
+		return ruleErr
+	}
+
+	if validateError == nil {
+		return nil
 	}
 
 	var validationErrors baseValidator.ValidationErrors
@@ -98,12 +143,17 @@ func (validator *Validator) Var(variable any, tag string) error {
 		return nil
 	}
 
+	return err.SetData(newValidationContext(validationErrors))
+}
+
+func newValidationContext(validationErrors baseValidator.ValidationErrors) validationContext {
 	validations := make([]string, 0, len(validationErrors))
 	for _, validationError := range validationErrors {
 		validations = append(validations, validationError.Error())
 	}
 
-	return err.SetData(validationContext{
+	return validationContext{
 		Validations: validations,
-	})
+		Fields:      newFieldErrors(validationErrors),
+	}
 }